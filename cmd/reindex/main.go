@@ -0,0 +1,9 @@
+package main
+
+import "go-clean-architecture/internal/infrastructure"
+
+// Rebuilds the article search index from the stored articles, for example
+// after importing data or switching database.
+func main() {
+	infrastructure.Reindex()
+}
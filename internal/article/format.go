@@ -0,0 +1,299 @@
+package article
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-clean-architecture/internal/domain"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formatTypes are the content types of the export and import formats.
+var formatTypes = map[string]string{
+	"ndjson": "application/x-ndjson",
+	"csv":    "text/csv",
+}
+
+// csvColumns are the columns of exported CSV files. Imports read the title,
+// content, contentFormat, authorId and tags columns and ignore the others, so
+// that exported files can be imported again.
+var csvColumns = []string{"id", "title", "slug", "content", "contentFormat", "authorId", "status", "tags", "publishedAt", "createdAt", "updatedAt"}
+
+// csvRequiredColumns are the columns an imported CSV file must have.
+var csvRequiredColumns = []string{"title", "content", "authorId"}
+
+// maxImportLine is the longest NDJSON line that can be imported.
+const maxImportLine = 8 << 20
+
+// errImportTooLarge is returned by an importLimitReader past its limit.
+var errImportTooLarge = errors.New("import is too large")
+
+// importLimitReader reads an import body up to a number of bytes. Unlike
+// io.LimitReader it fails past the limit, so a truncated file is not taken
+// for a complete one.
+type importLimitReader struct {
+	r io.Reader
+	// n is the number of bytes left.
+	n int64
+}
+
+func (r *importLimitReader) Read(p []byte) (int, error) {
+	if r.n <= 0 {
+		// only a body that ends at the limit fits in it
+		n, err := r.r.Read(make([]byte, 1))
+		if n > 0 {
+			return 0, errImportTooLarge
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > r.n {
+		p = p[:r.n]
+	}
+	n, err := r.r.Read(p)
+	r.n -= int64(n)
+	return n, err
+}
+
+// articleWriter writes articles in an export format.
+type articleWriter interface {
+	Write(article *domain.Article) error
+	// Flush writes buffered articles to the underlying writer.
+	Flush() error
+}
+
+func newArticleWriter(format string, w io.Writer) articleWriter {
+	if format == "csv" {
+		return &csvArticleWriter{w: csv.NewWriter(w)}
+	}
+	return &ndjsonArticleWriter{enc: json.NewEncoder(w)}
+}
+
+type ndjsonArticleWriter struct {
+	enc *json.Encoder
+}
+
+func (w *ndjsonArticleWriter) Write(article *domain.Article) error {
+	return w.enc.Encode(article)
+}
+
+func (w *ndjsonArticleWriter) Flush() error {
+	return nil
+}
+
+type csvArticleWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (w *csvArticleWriter) Write(article *domain.Article) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	return w.w.Write([]string{
+		strconv.FormatUint(uint64(article.ID), 10),
+		article.Title,
+		article.Slug,
+		article.Content,
+		string(article.ContentFormat),
+		strconv.FormatUint(uint64(article.AuthorID), 10),
+		string(article.Status),
+		strings.Join(tagNames(article.Tags), ","),
+		formatTime(article.PublishedAt),
+		formatTime(&article.CreatedAt),
+		formatTime(&article.UpdatedAt),
+	})
+}
+
+func (w *csvArticleWriter) Flush() error {
+	// an export without articles still has the header
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvArticleWriter) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+	return w.w.Write(csvColumns)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// articleRow is a row of an imported file.
+type articleRow struct {
+	// Line is the line of the row in the file, starting at 1.
+	Line    int
+	Request domain.ArticleStoreRequest
+	// Err is set when the row could not be decoded.
+	Err error
+}
+
+// articleReader reads the rows of an import format.
+type articleReader interface {
+	// Read returns the next row, or io.EOF after the last one. Rows that
+	// cannot be decoded are returned with Err set; an error stops reading.
+	Read() (*articleRow, error)
+}
+
+func newArticleReader(format string, r io.Reader) (articleReader, error) {
+	if format == "csv" {
+		return newCSVArticleReader(r)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxImportLine)
+	return &ndjsonArticleReader{scanner: scanner}, nil
+}
+
+type ndjsonArticleReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// ndjsonArticle is an imported NDJSON row. Tags are either names or tag
+// objects, as written by exports.
+type ndjsonArticle struct {
+	domain.ArticleStoreRequest
+	Tags []importTag `json:"tags"`
+}
+
+type importTag string
+
+func (t *importTag) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = importTag(name)
+		return nil
+	}
+
+	var tag domain.Tag
+	if err := json.Unmarshal(data, &tag); err != nil {
+		return errors.New("tags must be names or tag objects")
+	}
+	*t = importTag(tag.Name)
+	return nil
+}
+
+func (r *ndjsonArticleReader) Read() (*articleRow, error) {
+	for r.scanner.Scan() {
+		r.line++
+		// the last line is cut short when the body could not be read
+		if err := r.scanner.Err(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		row := &articleRow{Line: r.line}
+		var article ndjsonArticle
+		if err := json.Unmarshal(data, &article); err != nil {
+			row.Err = err
+			return row, nil
+		}
+
+		row.Request = article.ArticleStoreRequest
+		for _, tag := range article.Tags {
+			row.Request.Tags = append(row.Request.Tags, string(tag))
+		}
+		return row, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("line %d: %w", r.line+1, err)
+	}
+	return nil, io.EOF
+}
+
+type csvArticleReader struct {
+	r *csv.Reader
+	// columns maps column names to their positions.
+	columns map[string]int
+}
+
+func newCSVArticleReader(r io.Reader) (*csvArticleReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("header row is missing")
+		}
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// spreadsheets often start the file with a byte order mark
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range csvRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("column %q is missing", name)
+		}
+	}
+
+	return &csvArticleReader{r: reader, columns: columns}, nil
+}
+
+func (r *csvArticleReader) Read() (*articleRow, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return &articleRow{Line: parseErr.StartLine, Err: parseErr.Err}, nil
+		}
+		return nil, err
+	}
+
+	line, _ := r.r.FieldPos(0)
+	row := &articleRow{Line: line}
+	field := func(name string) string {
+		i, ok := r.columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	row.Request.Title = field("title")
+	row.Request.Content = field("content")
+	row.Request.ContentFormat = domain.ContentFormat(strings.TrimSpace(field("contentFormat")))
+	if authorID := strings.TrimSpace(field("authorId")); authorID != "" {
+		id, err := strconv.ParseUint(authorID, 10, 0)
+		if err != nil {
+			row.Err = errors.New("authorId must be a positive integer")
+			return row, nil
+		}
+		row.Request.AuthorID = uint(id)
+	}
+	if tags := strings.TrimSpace(field("tags")); tags != "" {
+		for _, tag := range strings.Split(tags, ",") {
+			row.Request.Tags = append(row.Request.Tags, strings.TrimSpace(tag))
+		}
+	}
+	return row, nil
+}
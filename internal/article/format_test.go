@@ -0,0 +1,148 @@
+package article
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func exportArticle() *domain.Article {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &domain.Article{
+		ID:            1,
+		Title:         "Hello, world",
+		Slug:          "hello-world",
+		Content:       "line one\nline \"two\"",
+		ContentFormat: domain.ContentFormatMarkdown,
+		AuthorID:      2,
+		Status:        domain.ArticleStatusDraft,
+		Tags:          []*domain.Tag{{ID: 3, Name: "go"}, {ID: 4, Name: "web"}},
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+}
+
+func TestArticleWriter_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	w := newArticleWriter("csv", &buf)
+	assert.NoError(t, w.Write(exportArticle()))
+	assert.NoError(t, w.Flush())
+
+	assert.Equal(t, "id,title,slug,content,contentFormat,authorId,status,tags,publishedAt,createdAt,updatedAt\n"+
+		"1,\"Hello, world\",hello-world,\"line one\nline \"\"two\"\"\",markdown,2,draft,\"go,web\",,2024-01-02T03:04:05Z,2024-01-02T03:04:05Z\n", buf.String())
+}
+
+func TestArticleWriter_CSVEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	w := newArticleWriter("csv", &buf)
+	assert.NoError(t, w.Flush())
+	assert.NoError(t, w.Flush())
+
+	assert.Equal(t, "id,title,slug,content,contentFormat,authorId,status,tags,publishedAt,createdAt,updatedAt\n", buf.String())
+}
+
+func TestArticleWriter_NDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	w := newArticleWriter("ndjson", &buf)
+	assert.NoError(t, w.Write(exportArticle()))
+	assert.NoError(t, w.Write(exportArticle()))
+	assert.NoError(t, w.Flush())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"title":"Hello, world"`)
+}
+
+// readRows reads every row of an import.
+func readRows(t *testing.T, format string, data string) []*articleRow {
+	reader, err := newArticleReader(format, strings.NewReader(data))
+	assert.NoError(t, err)
+
+	var rows []*articleRow
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return rows
+		}
+		assert.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestArticleReader_NDJSON(t *testing.T) {
+	rows := readRows(t, "ndjson", `{"title":"First","content":"a","authorId":1,"tags":["go"]}
+
+{"title":"Second","content":"b","authorId":2,"tags":[{"id":3,"name":"web"}]}
+{"title":
+{"title":"Third","authorId":"x"}
+`)
+
+	assert.Len(t, rows, 4)
+	assert.Equal(t, &articleRow{Line: 1, Request: domain.ArticleStoreRequest{Title: "First", Content: "a", AuthorID: 1, Tags: []string{"go"}}}, rows[0])
+	assert.Equal(t, &articleRow{Line: 3, Request: domain.ArticleStoreRequest{Title: "Second", Content: "b", AuthorID: 2, Tags: []string{"web"}}}, rows[1])
+	assert.Equal(t, 4, rows[2].Line)
+	assert.Error(t, rows[2].Err)
+	assert.Equal(t, 5, rows[3].Line)
+	assert.Error(t, rows[3].Err)
+}
+
+func TestArticleReader_NDJSONLineTooLong(t *testing.T) {
+	reader, err := newArticleReader("ndjson", strings.NewReader(`{"title":"`+strings.Repeat("a", maxImportLine)+`"}`))
+	assert.NoError(t, err)
+
+	_, err = reader.Read()
+	assert.EqualError(t, err, "line 1: bufio.Scanner: token too long")
+}
+
+func TestArticleReader_CSV(t *testing.T) {
+	rows := readRows(t, "csv", "\ufeffid,title,content,authorId,tags,contentFormat\n"+
+		"9,First,\"multi\nline\",1,\"go, web\", markdown\n"+
+		"9,Second,b,x,,\n"+
+		"9,Third,\"c\"d,1,,\n"+
+		"9,Short\n")
+
+	assert.Len(t, rows, 4)
+	assert.Equal(t, &articleRow{Line: 2, Request: domain.ArticleStoreRequest{Title: "First", Content: "multi\nline", ContentFormat: domain.ContentFormatMarkdown, AuthorID: 1, Tags: []string{"go", "web"}}}, rows[0])
+	assert.Equal(t, 4, rows[1].Line)
+	assert.EqualError(t, rows[1].Err, "authorId must be a positive integer")
+	assert.Equal(t, 5, rows[2].Line)
+	assert.Error(t, rows[2].Err)
+	assert.Equal(t, &articleRow{Line: 6, Request: domain.ArticleStoreRequest{Title: "Short"}}, rows[3])
+}
+
+func TestArticleReader_CSVHeader(t *testing.T) {
+	_, err := newArticleReader("csv", strings.NewReader(""))
+	assert.EqualError(t, err, "header row is missing")
+
+	_, err = newArticleReader("csv", strings.NewReader("title,content\nA,b\n"))
+	assert.EqualError(t, err, `column "authorId" is missing`)
+}
+
+func TestImportLimitReader(t *testing.T) {
+	data, err := io.ReadAll(&importLimitReader{r: strings.NewReader("12345678"), n: 8})
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678", string(data))
+
+	data, err = io.ReadAll(&importLimitReader{r: strings.NewReader("123456789"), n: 8})
+	assert.ErrorIs(t, err, errImportTooLarge)
+	assert.Equal(t, "12345678", string(data))
+}
+
+func TestArticleReader_NDJSONTooLarge(t *testing.T) {
+	row := `{"title":"First","content":"a","authorId":1}` + "\n"
+	reader, err := newArticleReader("ndjson", &importLimitReader{r: strings.NewReader(row + row), n: int64(len(row) + 8)})
+	assert.NoError(t, err)
+
+	first, err := reader.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, first.Line)
+
+	// the second row is cut short and not taken for an invalid row
+	_, err = reader.Read()
+	assert.ErrorIs(t, err, errImportTooLarge)
+	assert.EqualError(t, err, "line 2: import is too large")
+}
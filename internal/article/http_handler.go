@@ -1,47 +1,171 @@
 package article
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"go-clean-architecture/internal/attachment"
+	"go-clean-architecture/internal/config"
 	"go-clean-architecture/internal/domain"
 	"go-clean-architecture/internal/middleware/validation"
 	"go-clean-architecture/internal/utilities"
-	"strconv"
+	"go-clean-architecture/pkg/xcursor"
+	"go-clean-architecture/pkg/xfilter"
+	"go-clean-architecture/pkg/xlogger"
+	"gorm.io/gorm/clause"
+	"io"
+	"slices"
+	"strings"
 )
 
+// sortColumns maps the fields that article listings can be sorted by to
+// their column.
+var sortColumns = map[string]string{
+	"title":       "title",
+	"createdAt":   "created_at",
+	"updatedAt":   "updated_at",
+	"publishedAt": "published_at",
+	"views":       "view_count",
+}
+
+// articleFields maps the fields of article responses to the columns they are
+// loaded from. Tags are a relation, highlights are made from the title and
+// content, and the HTML may have to be rendered again from the content.
+var articleFields = map[string][]string{
+	"id":                 {"id"},
+	"title":              {"title"},
+	"slug":               {"slug"},
+	"content":            {"content"},
+	"contentFormat":      {"content_format"},
+	"contentHtml":        {"content", "content_format", "rendered_content", "render_key"},
+	"excerpt":            {"excerpt"},
+	"wordCount":          {"word_count"},
+	"readingTimeMinutes": {"reading_time_minutes"},
+	"authorId":           {"author_id"},
+	"tags":               nil,
+	"status":             {"status"},
+	"publishedAt":        {"published_at"},
+	"publishAt":          {"publish_at"},
+	"unpublishAt":        {"unpublish_at"},
+	"version":            {"version"},
+	"viewCount":          {"view_count"},
+	"createdAt":          {"created_at"},
+	"updatedAt":          {"updated_at"},
+	"deletedAt":          {"deleted_at"},
+	"highlight":          {"title", "content"},
+}
+
+// listFields are the fields of listed articles when none are asked for. The
+// content is left out in favour of its excerpt.
+var listFields = []string{
+	"id", "title", "slug", "excerpt", "wordCount", "readingTimeMinutes", "contentFormat", "authorId", "tags",
+	"status", "publishedAt", "publishAt", "unpublishAt", "version", "viewCount", "createdAt", "updatedAt", "deletedAt",
+}
+
+// articleIncludes are the relations that can be embedded in article
+// responses, and detailIncludes the ones that can be embedded in a single
+// article.
+var (
+	articleIncludes = map[string]bool{"author": true}
+	detailIncludes  = map[string]bool{"author": true, "attachments": true}
+)
+
+// filterFields are the fields that the filter expression of article listings
+// may refer to.
+var filterFields = xfilter.Schema{
+	"id":            {Column: "id", Type: xfilter.TypeInteger},
+	"title":         {Column: "title", Type: xfilter.TypeString},
+	"slug":          {Column: "slug", Type: xfilter.TypeString},
+	"content":       {Column: "content", Type: xfilter.TypeString},
+	"contentFormat": {Column: "content_format", Type: xfilter.TypeString},
+	"status":        {Column: "status", Type: xfilter.TypeString},
+	"authorId":      {Column: "author_id", Type: xfilter.TypeInteger},
+	"createdAt":     {Column: "created_at", Type: xfilter.TypeTime},
+	"updatedAt":     {Column: "updated_at", Type: xfilter.TypeTime},
+	"publishedAt":   {Column: "published_at", Type: xfilter.TypeTime, Nullable: true},
+}
+
 type HttpArticleHandler struct {
 	articleSvc domain.ArticleService
+	cfg        config.Config
+	cursor     *xcursor.Signer
+	linker     *attachment.Linker
 }
 
-func NewHttpHandler(r fiber.Router, articleSvc domain.ArticleService) {
+func NewHttpHandler(r fiber.Router, articleSvc domain.ArticleService, cfg config.Config) {
 	handler := &HttpArticleHandler{
 		articleSvc: articleSvc,
+		cfg:        cfg,
+		cursor:     xcursor.New([]byte(cfg.Pagination.CursorSecret)),
+		linker:     attachment.NewLinker(cfg),
 	}
 	r.Post("/", validation.New[domain.ArticleStoreRequest](), handler.Store)
+	r.Post("/bulk", handler.StoreBulk)
+	r.Post("/bulk/delete", validation.New[domain.ArticleBulkDeleteRequest](), handler.DeleteBulk)
+	r.Post("/bulk/reassign", validation.New[domain.ArticleBulkReassignRequest](), handler.ReassignBulk)
+	r.Post("/import", handler.Import)
 	r.Get("/", handler.Fetch)
+	r.Get("/export", handler.Export)
+	r.Get("/trash", handler.FetchTrash)
+	r.Get("/slug/:slug", handler.GetBySlug)
 	r.Get("/:id", handler.GetByID)
+	r.Post("/:id/views", handler.RecordView)
 	r.Put("/:id", validation.New[domain.ArticleUpdateRequest](), handler.Update)
+	r.Patch("/:id", handler.Patch)
 	r.Delete("/:id", handler.Delete)
+	r.Post("/:id/restore", handler.Restore)
+	r.Post("/:id/submit", handler.Submit)
+	r.Post("/:id/publish", handler.Publish)
+	r.Post("/:id/unpublish", handler.Unpublish)
+	r.Post("/:id/archive", handler.Archive)
+	r.Put("/:id/schedule", validation.New[domain.ArticleScheduleRequest](), handler.Schedule)
+	r.Get("/:id/revisions", handler.FetchRevisions)
+	r.Get("/:id/revisions/diff", handler.DiffRevisions)
+	r.Get("/:id/revisions/:rev", handler.GetRevision)
+	r.Post("/:id/revisions/:rev/revert", handler.Revert)
 }
 
 // Fetch used to get list of articles
 //
 //	@Summary		Get list of articles
-//	@Description	Get list of articles
+//	@Description	Get list of articles. Sending the cursor parameter (empty for the first page) switches from page to
+//	@Description	keyset pagination; the next cursor is then returned in X-Cursor and Link, and totals are omitted.
 //	@Tags			articles
 //	@Accept			json
 //	@Produce		json
-//	@Param			page	query		int				false	"Page number (default 1)"
-//	@Param			size	query		int				false	"Size of page (default 10)"
-//	@Param			q		query		string			false	"Search query"
-//	@Header			200		{string}	X-Cursor		"Next page"
-//	@Header			200		{string}	X-Total-Count	"Total item"
-//	@Header			200		{string}	X-Max-Page		"Max page"
-//	@Success		200		{array}		domain.Article	"List of articles"
-//	@Failure		400		{object}	domain.Error	"Bad Request"
-//	@Failure		500		{object}	domain.Error	"Internal Server Error"
+//	@Param			page			query		int				false	"Page number (default 1)"
+//	@Param			size			query		int				false	"Size of page (default 10)"
+//	@Param			cursor			query		string			false	"Opaque cursor from a previous X-Cursor header"
+//	@Param			q				query		string			false	"Full-text search over title and content, ordered by relevance unless a cursor is used. It needs a letter or a digit"
+//	@Param			authorId		query		int				false	"Filter by author ID"
+//	@Param			status			query		string			false	"Filter by status (default published). Other statuses need the X-Editor header"	Enums(draft, review, published, archived, all)
+//	@Param			tag				query		string			false	"Filter by tag"
+//	@Param			tags			query		string			false	"Filter by comma separated tags"
+//	@Param			tagMatch		query		string			false	"Match any or all of the tags (default any)"	Enums(any, all)
+//	@Param			createdFrom		query		string			false	"Only articles created at or after this RFC 3339 time"
+//	@Param			createdTo		query		string			false	"Only articles created at or before this RFC 3339 time"
+//	@Param			updatedSince	query		string			false	"Only articles updated at or after this RFC 3339 time"
+//	@Param			sort			query		string			false	"Comma separated title, createdAt, updatedAt, publishedAt or views, prefixed with - for descending order, e.g. title,-createdAt. Not supported with a cursor"
+//	@Param			fields			query		string			false	"Comma separated fields to return, e.g. id,title,createdAt. Only these columns are loaded. By default the content is left out in favour of its excerpt"
+//	@Param			include			query		string			false	"Comma separated relations to embed"					Enums(author)
+//	@Param			render			query		string			false	"Set contentHtml to the sanitised HTML of the content"	Enums(html)
+//	@Param			filter			query		string			false	"Filter expression over id, title, slug, content, contentFormat, status, authorId, createdAt, updatedAt and publishedAt, e.g. createdAt gt 2024-01-01 and authorId in (1,2). Operators are eq, ne, gt, ge, lt, le, co, sw, ew and in, joined with and, or, not and parentheses; strings are double quoted"
+//	@Header			200				{string}	X-Cursor		"Next page or next cursor"
+//	@Header			200				{string}	Link			"URL of the next page"
+//	@Header			200				{string}	X-Total-Count	"Total item"
+//	@Header			200				{string}	X-Max-Page		"Max page"
+//	@Success		200				{array}		domain.Article	"List of articles"
+//	@Failure		400				{object}	domain.Error	"Bad Request, or a domain.FilterError for an invalid filter"
+//	@Failure		403				{object}	domain.Error	"Status other than published without the X-Editor header"
+//	@Failure		500				{object}	domain.Error	"Internal Server Error"
 //	@Router			/articles [get]
 func (h *HttpArticleHandler) Fetch(c *fiber.Ctx) error {
-	page, size, query := c.QueryInt("page", 1), c.QueryInt("size", 10), c.Query("q")
+	page, size := c.QueryInt("page", 1), c.QueryInt("size", 10)
 	if page <= 0 {
 		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
 			Code:    fiber.StatusBadRequest,
@@ -55,8 +179,57 @@ func (h *HttpArticleHandler) Fetch(c *fiber.Ctx) error {
 		})
 	}
 
-	filter := &domain.Article{Title: query}
-	articles, nextPage, err := h.articleSvc.Fetch(uint(page), uint(size), filter)
+	if maxSize := h.cfg.Pagination.MaxSize; maxSize > 0 && size > maxSize {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: fmt.Sprintf("size must not be greater than %d", maxSize),
+		})
+	}
+
+	filter, err := parseFilter(c)
+	if err != nil {
+		return writeFilterError(c, err)
+	}
+
+	byCursor := c.Context().QueryArgs().Has("cursor")
+	var required []string
+	if byCursor {
+		// the next cursor is made from the last article
+		required = []string{"created_at"}
+	}
+	defaults := listFields
+	if filter.Query != "" {
+		defaults = append(slices.Clip(listFields), "highlight")
+	}
+	view, fields, err := parseView(c, articleIncludes, defaults, required...)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+	filter.View = view
+
+	sort, err := utilities.ParseSort(c.Query("sort"), sortColumns)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	if byCursor {
+		if len(sort) > 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+				Code:    fiber.StatusBadRequest,
+				Message: "sort is not supported with cursor pagination",
+			})
+		}
+		return h.fetchByCursor(c, uint(size), filter, fields)
+	}
+	filter.Sort = sort
+
+	articles, nextPage, err := h.articleSvc.Fetch(c.UserContext(), uint(page), uint(size), filter)
 	if err != nil {
 		return err
 	}
@@ -65,36 +238,143 @@ func (h *HttpArticleHandler) Fetch(c *fiber.Ctx) error {
 		return c.JSON([]domain.Article{})
 	}
 
-	totalItem, err := h.articleSvc.Count(filter)
+	totalItem, err := h.articleSvc.Count(c.UserContext(), filter)
 	if err != nil {
 		return err
 	}
 
-	maxPage := int(totalItem) / size
+	utilities.SetPaginationHeaders(c, nextPage, totalItem, size)
+	return writeView(c, articles, fields)
+}
+
+// Export used to download articles
+//
+//	@Summary		Export articles
+//	@Description	Stream every article matching the filters of the listing, ordered by ID, as NDJSON (one article per
+//	@Description	line) or CSV with a header row. Tags are written as tag objects in NDJSON and as comma separated
+//	@Description	names in CSV.
+//	@Tags			articles
+//	@Produce		application/x-ndjson
+//	@Produce		text/csv
+//	@Param			format			query		string			false	"Export format (default ndjson)"	Enums(ndjson, csv)
+//	@Param			q				query		string			false	"Full-text search over title and content. It needs a letter or a digit"
+//	@Param			authorId		query		int				false	"Filter by author ID"
+//	@Param			status			query		string			false	"Filter by status (default published). Other statuses need the X-Editor header"	Enums(draft, review, published, archived, all)
+//	@Param			tag				query		string			false	"Filter by tag"
+//	@Param			tags			query		string			false	"Filter by comma separated tags"
+//	@Param			tagMatch		query		string			false	"Match any or all of the tags (default any)"	Enums(any, all)
+//	@Param			createdFrom		query		string			false	"Only articles created at or after this RFC 3339 time"
+//	@Param			createdTo		query		string			false	"Only articles created at or before this RFC 3339 time"
+//	@Param			updatedSince	query		string			false	"Only articles updated at or after this RFC 3339 time"
+//	@Param			filter			query		string			false	"Filter expression, as in the listing"
+//	@Success		200				{file}		file			"Articles"
+//	@Failure		400				{object}	domain.Error	"Bad Request, or a domain.FilterError for an invalid filter"
+//	@Failure		403				{object}	domain.Error	"Status other than published without the X-Editor header"
+//	@Router			/articles/export [get]
+func (h *HttpArticleHandler) Export(c *fiber.Ctx) error {
+	format := c.Query("format", "ndjson")
+	contentType, ok := formatTypes[format]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "format must be one of ndjson or csv",
+		})
+	}
 
-	if nextPage > 0 && nextPage <= uint(maxPage) {
-		c.Set("X-Cursor", strconv.Itoa(int(nextPage)))
+	filter, err := parseFilter(c)
+	if err != nil {
+		return writeFilterError(c, err)
 	}
-	c.Set("X-Total-Count", strconv.Itoa(int(totalItem)))
-	c.Set("X-Max-Page", strconv.Itoa(maxPage))
-	return c.JSON(articles)
+
+	// the body is written after the handler returns, when the request
+	// deadline no longer applies; a closed connection still stops the export
+	ctx := context.WithoutCancel(c.UserContext())
+
+	c.Attachment("articles." + format)
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		out := newArticleWriter(format, w)
+		err := h.articleSvc.Export(ctx, filter, func(articles []*domain.Article) error {
+			for _, article := range articles {
+				if err := out.Write(article); err != nil {
+					return err
+				}
+			}
+			if err := out.Flush(); err != nil {
+				return err
+			}
+			return w.Flush()
+		})
+		if err == nil {
+			err = out.Flush()
+		}
+		// the status is already sent, so the export can only end early
+		if err != nil && xlogger.Logger != nil {
+			xlogger.Logger.Error().Err(err).Msg("Article export failed")
+		}
+	})
+	return nil
 }
 
-// GetByID used to get article by id
+// importBatchSize is the number of valid rows stored at a time by Import.
+const importBatchSize = 100
+
+// Import used to store articles from a file
 //
-//	@Summary		Get article by id
-//	@Description	Get article by id
+//	@Summary		Import articles
+//	@Description	Store the articles of an NDJSON or CSV file, as sent by the export. Each row is validated like a
+//	@Description	single article and stored on its own; CSV files need a header row with the title, content and
+//	@Description	authorId columns, and an optional tags column of comma separated names. Rows that were not stored
+//	@Description	are reported by line. The response is 201 when every row was stored or 207 otherwise. Files are
+//	@Description	read as they arrive, up to ARTICLE_IMPORT_MAX_SIZE bytes.
 //	@Tags			articles
-//	@Accept			json
+//	@Accept			application/x-ndjson
+//	@Accept			text/csv
 //	@Produce		json
-//	@Param			id	path		int				true	"Article ID"
-//	@Success		200	{object}	domain.Article	"Article detail"
-//	@Failure		400	{object}	domain.Error	"Bad Request"
-//	@Failure		404	{object}	domain.Error	"Not Found"
-//	@Failure		500	{object}	domain.Error	"Internal Server Error"
-//	@Router			/articles/{id} [get]
-func (h *HttpArticleHandler) GetByID(c *fiber.Ctx) error {
-	id, err := c.ParamsInt("id")
+//	@Param			format	query		string						false	"Import format, taken from the content type when missing"	Enums(ndjson, csv)
+//	@Success		201		{object}	domain.ArticleImportResult	"Import result"
+//	@Success		207		{object}	domain.ArticleImportResult	"Import result"
+//	@Failure		400		{object}	domain.Error				"Bad Request"
+//	@Failure		413		{object}	domain.Error				"Request Entity Too Large"
+//	@Failure		500		{object}	domain.Error				"Internal Server Error"
+//	@Router			/articles/import [post]
+func (h *HttpArticleHandler) Import(c *fiber.Ctx) error {
+	format := c.Query("format")
+	if format == "" {
+		contentType := strings.TrimSpace(strings.Split(c.Get(fiber.HeaderContentType), ";")[0])
+		for name, formatType := range formatTypes {
+			if contentType == formatType {
+				format = name
+			}
+		}
+	}
+	if _, ok := formatTypes[format]; !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "format must be one of ndjson or csv",
+		})
+	}
+
+	maxSize := h.cfg.Article.ImportMaxSize
+	if maxSize > 0 && int64(c.Request().Header.ContentLength()) > maxSize {
+		c.Context().SetConnectionClose()
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(domain.Error{
+			Code:    fiber.StatusRequestEntityTooLarge,
+			Message: fmt.Sprintf("import must not be larger than %d bytes", maxSize),
+		})
+	}
+
+	// the body is decoded as it arrives when the server streams request
+	// bodies, instead of being held in memory
+	body := c.Context().RequestBodyStream()
+	if body == nil {
+		body = bytes.NewReader(c.Body())
+	}
+	if maxSize > 0 {
+		body = &importLimitReader{r: body, n: maxSize}
+	}
+
+	reader, err := newArticleReader(format, body)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
 			Code:    fiber.StatusBadRequest,
@@ -102,58 +382,365 @@ func (h *HttpArticleHandler) GetByID(c *fiber.Ctx) error {
 		})
 	}
 
-	article, err := h.articleSvc.GetByID(uint(id))
+	result := domain.ArticleImportResult{Errors: []domain.ArticleImportError{}}
+	fail := func(line int, status int, message string, errors []string) {
+		result.Failed++
+		result.Errors = append(result.Errors, domain.ArticleImportError{Line: line, Status: status, Message: message, Errors: errors})
+	}
+
+	var articles []*domain.Article
+	var lines []int
+	store := func() error {
+		if len(articles) == 0 {
+			return nil
+		}
+
+		results, err := h.articleSvc.StoreBulk(c.UserContext(), articles, false)
+		if err != nil {
+			return err
+		}
+		for i, stored := range results {
+			if stored.Status != fiber.StatusCreated {
+				fail(lines[i], stored.Status, stored.Message, nil)
+				continue
+			}
+			result.Imported++
+		}
+
+		articles, lines = nil, nil
+		return nil
+	}
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, errImportTooLarge) {
+			// the rest of the body is left unread on the connection
+			c.Context().SetConnectionClose()
+			fail(0, fiber.StatusRequestEntityTooLarge, fmt.Sprintf("import must not be larger than %d bytes", maxSize), nil)
+			break
+		}
+		if err != nil {
+			// the rest of the file cannot be read, but the rows before it
+			// are still stored
+			fail(0, fiber.StatusBadRequest, err.Error(), nil)
+			break
+		}
+
+		if row.Err != nil {
+			fail(row.Line, fiber.StatusBadRequest, "invalid row", []string{row.Err.Error()})
+			continue
+		}
+		if errors := validation.Validate(row.Request); errors != nil {
+			fail(row.Line, fiber.StatusBadRequest, "validation error", errors)
+			continue
+		}
+
+		articles = append(articles, &domain.Article{
+			Title:         row.Request.Title,
+			Content:       row.Request.Content,
+			ContentFormat: row.Request.ContentFormat,
+			AuthorID:      row.Request.AuthorID,
+			Tags:          toTags(row.Request.Tags),
+		})
+		lines = append(lines, row.Line)
+		if len(articles) == importBatchSize {
+			if err := store(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := store(); err != nil {
+		return err
+	}
+
+	if result.Imported == 0 && result.Failed == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "at least one row is required",
+		})
+	}
+
+	status := fiber.StatusCreated
+	if result.Failed > 0 {
+		status = fiber.StatusMultiStatus
+	}
+	return c.Status(status).JSON(result)
+}
+
+// parseFilter reads the filters shared by listings and exports. Invalid
+// filters are reported as a domain.Error or a domain.FilterError.
+func parseFilter(c *fiber.Ctx) (*domain.ArticleFilter, error) {
+	authorID := c.QueryInt("authorId", 0)
+	if authorID < 0 {
+		return nil, domain.NewError(fiber.StatusBadRequest, "authorId must be a positive integer")
+	}
+
+	status, err := utilities.QueryStatus(c)
+	if err != nil {
+		return nil, err
+	}
+
+	tagMatch := c.Query("tagMatch", "any")
+	if tagMatch != "any" && tagMatch != "all" {
+		return nil, domain.NewError(fiber.StatusBadRequest, "tagMatch must be one of any or all")
+	}
+	tags := utilities.NormalizeTags(append([]string{c.Query("tag")}, strings.Split(c.Query("tags"), ",")...))
+
+	query, err := utilities.QuerySearch(c)
 	if err != nil {
+		return nil, domain.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	filter := &domain.ArticleFilter{Query: query, AuthorID: uint(authorID), Status: status, Tags: tags, AllTags: tagMatch == "all"}
+	if err := parseFilterTimes(c, filter); err != nil {
+		return nil, domain.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if expression := c.Query("filter"); expression != "" {
+		expr, err := compileFilter(expression)
+		if err != nil {
+			var filterErr *xfilter.Error
+			if !errors.As(err, &filterErr) {
+				return nil, err
+			}
+			return nil, domain.FilterError{
+				Code:    fiber.StatusBadRequest,
+				Message: filterErr.Message,
+				Filter:  expression,
+				Offset:  filterErr.Offset,
+			}
+		}
+		filter.Expression = expr
+	}
+
+	return filter, nil
+}
+
+// writeFilterError writes the invalid filters reported by parseFilter with
+// their code, and returns other errors.
+func writeFilterError(c *fiber.Ctx, err error) error {
+	var filterErr domain.FilterError
+	if errors.As(err, &filterErr) {
+		return c.Status(filterErr.Code).JSON(filterErr)
+	}
+	var domainErr domain.Error
+	if errors.As(err, &domainErr) {
+		return c.Status(domainErr.Code).JSON(domainErr)
+	}
+	return err
+}
+
+// parseFilterTimes reads the time bounds of a listing into filter.
+func parseFilterTimes(c *fiber.Ctx, filter *domain.ArticleFilter) error {
+	var err error
+	if filter.CreatedFrom, err = utilities.QueryTime(c, "createdFrom"); err != nil {
+		return err
+	}
+	if filter.CreatedTo, err = utilities.QueryTime(c, "createdTo"); err != nil {
+		return err
+	}
+	if filter.UpdatedSince, err = utilities.QueryTime(c, "updatedSince"); err != nil {
 		return err
 	}
 
-	return c.JSON(article)
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && filter.CreatedFrom.After(*filter.CreatedTo) {
+		return errors.New("createdFrom must not be after createdTo")
+	}
+	return nil
 }
 
-// Store used to store article
+// compileFilter parses a filter expression into a condition on articles.
+func compileFilter(expression string) (clause.Expression, error) {
+	node, err := xfilter.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	return filterFields.Compile(node)
+}
+
+func (h *HttpArticleHandler) fetchByCursor(c *fiber.Ctx, size uint, filter *domain.ArticleFilter, fields []string) error {
+	var cursor *domain.ArticleCursor
+	if token := c.Query("cursor"); token != "" {
+		cursor = &domain.ArticleCursor{}
+		if err := h.cursor.Decode(token, cursor); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+				Code:    fiber.StatusBadRequest,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	articles, nextCursor, err := h.articleSvc.FetchByCursor(c.UserContext(), cursor, size, filter)
+	if err != nil {
+		return err
+	}
+
+	if nextCursor != nil {
+		token, err := h.cursor.Encode(nextCursor)
+		if err != nil {
+			return err
+		}
+		utilities.SetCursorHeaders(c, token)
+	}
+
+	if articles == nil {
+		return c.JSON([]domain.Article{})
+	}
+	return writeView(c, articles, fields)
+}
+
+// parseView reads the fields, include and render query parameters into the
+// view of the articles to load, which always has the id and the required
+// columns. Without the fields parameter the defaults are used, and only the
+// given relations can be included. The returned fields are the ones to
+// write, or nil to write them all.
+func parseView(c *fiber.Ctx, relations map[string]bool, defaults []string, required ...string) (*domain.ArticleView, []string, error) {
+	fields, err := utilities.ParseList(c.Query("fields"), "field", articleFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(fields) == 0 {
+		fields = slices.Clone(defaults)
+	}
+	includes, err := utilities.ParseList(c.Query("include"), "include", relations)
+	if err != nil {
+		return nil, nil, err
+	}
+	render := c.Query("render")
+	if render != "" && render != "html" {
+		return nil, nil, errors.New("render must be html")
+	}
+
+	view := &domain.ArticleView{
+		Author:      slices.Contains(includes, "author"),
+		Tags:        len(fields) == 0,
+		HTML:        render == "html" || slices.Contains(fields, "contentHtml"),
+		Attachments: slices.Contains(includes, "attachments"),
+	}
+	if len(fields) == 0 {
+		return view, nil, nil
+	}
+	if view.HTML && !slices.Contains(fields, "contentHtml") {
+		fields = append(fields, "contentHtml")
+	}
+
+	columns := append([]string{"id"}, required...)
+	for _, field := range fields {
+		view.Tags = view.Tags || field == "tags"
+		columns = append(columns, articleFields[field]...)
+	}
+	if view.Author {
+		columns = append(columns, "author_id")
+		fields = append(fields, "author")
+	}
+	if view.Attachments {
+		fields = append(fields, "attachments")
+	}
+
+	seen := make(map[string]bool)
+	for _, column := range columns {
+		if !seen[column] {
+			seen[column] = true
+			view.Columns = append(view.Columns, column)
+		}
+	}
+	return view, fields, nil
+}
+
+// writeView writes v as JSON, with only the given fields when there are any.
+func writeView(c *fiber.Ctx, v any, fields []string) error {
+	if len(fields) == 0 {
+		return c.JSON(v)
+	}
+
+	picked, err := utilities.PickFields(v, fields)
+	if err != nil {
+		return err
+	}
+	return c.JSON(picked)
+}
+
+// FetchTrash used to get list of deleted articles
 //
-//	@Summary		Store article
-//	@Description	Store article
+//	@Summary		Get list of deleted articles
+//	@Description	Get list of soft deleted articles, most recently deleted first
 //	@Tags			articles
 //	@Accept			json
 //	@Produce		json
-//	@Param			article	body		domain.ArticleStoreRequest	true	"Article data"
-//	@Success		201		{object}	domain.Article				"Article detail"
-//	@Failure		400		{object}	domain.Error				"Bad Request"
-//	@Failure		500		{object}	domain.Error				"Internal Server Error"
-//	@Router			/articles [post]
-func (h *HttpArticleHandler) Store(c *fiber.Ctx) error {
-	articleReq := utilities.ExtractStructFromValidator[domain.ArticleStoreRequest](c)
+//	@Param			page	query		int				false	"Page number (default 1)"
+//	@Param			size	query		int				false	"Size of page (default 10)"
+//	@Header			200		{string}	X-Cursor		"Next page"
+//	@Header			200		{string}	Link			"URL of the next page"
+//	@Header			200		{string}	X-Total-Count	"Total item"
+//	@Header			200		{string}	X-Max-Page		"Max page"
+//	@Success		200		{array}		domain.Article	"List of deleted articles"
+//	@Failure		400		{object}	domain.Error	"Bad Request"
+//	@Failure		500		{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/trash [get]
+func (h *HttpArticleHandler) FetchTrash(c *fiber.Ctx) error {
+	page, size := c.QueryInt("page", 1), c.QueryInt("size", 10)
+	if page <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "page must be a positive integer",
+		})
+	}
+	if size <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "size must be a positive integer",
+		})
+	}
 
-	article := &domain.Article{
-		Title:    articleReq.Title,
-		Content:  articleReq.Content,
-		AuthorID: articleReq.AuthorID,
+	if maxSize := h.cfg.Pagination.MaxSize; maxSize > 0 && size > maxSize {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: fmt.Sprintf("size must not be greater than %d", maxSize),
+		})
 	}
 
-	if err := h.articleSvc.Store(article); err != nil {
+	articles, nextPage, err := h.articleSvc.FetchTrash(c.UserContext(), uint(page), uint(size))
+	if err != nil {
 		return err
 	}
 
-	c.Status(fiber.StatusCreated)
-	return c.JSON(article)
+	if articles == nil {
+		return c.JSON([]domain.Article{})
+	}
+
+	totalItem, err := h.articleSvc.CountTrash(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	utilities.SetPaginationHeaders(c, nextPage, totalItem, size)
+	return c.JSON(articles)
 }
 
-// Update used to update article
+// GetByID used to get article by id
 //
-//	@Summary		Update article
-//	@Description	Update article
+//	@Summary		Get article by id
+//	@Description	Get article by id. The ETag is the article version, to be sent back in If-Match when writing.
+//	@Description	Articles that are not published are only found with the X-Editor header.
+//	@Description	The author is only embedded with include=author, and the attachments with include=attachments, each
+//	@Description	with signed URLs of its file and of the variants of images.
 //	@Tags			articles
 //	@Accept			json
 //	@Produce		json
-//	@Param			id		path		int							true	"Article ID"
-//	@Param			article	body		domain.ArticleUpdateRequest	true	"Article data"
-//	@Success		200		{object}	domain.Article				"Article detail"
-//	@Failure		400		{object}	domain.Error				"Bad Request"
-//	@Failure		404		{object}	domain.Error				"Not Found"
-//	@Failure		500		{object}	domain.Error				"Internal Server Error"
-//	@Router			/articles/{id} [put]
-func (h *HttpArticleHandler) Update(c *fiber.Ctx) error {
+//	@Param			id		path		int				true	"Article ID"
+//	@Param			fields	query		string			false	"Comma separated fields to return, e.g. id,title,createdAt. Only these columns are loaded"
+//	@Param			include	query		string			false	"Comma separated relations to embed"					Enums(author, attachments)
+//	@Param			render	query		string			false	"Set contentHtml to the sanitised HTML of the content"	Enums(html)
+//	@Header			200		{string}	ETag			"Article version"
+//	@Success		200		{object}	domain.Article	"Article detail"
+//	@Failure		400		{object}	domain.Error	"Bad Request"
+//	@Failure		404		{object}	domain.Error	"Not Found"
+//	@Failure		500		{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/{id} [get]
+func (h *HttpArticleHandler) GetByID(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
@@ -162,35 +749,57 @@ func (h *HttpArticleHandler) Update(c *fiber.Ctx) error {
 		})
 	}
 
-	articleReq := utilities.ExtractStructFromValidator[domain.ArticleUpdateRequest](c)
-
-	article := &domain.Article{
-		ID:      uint(id),
-		Title:   articleReq.Title,
-		Content: articleReq.Content,
+	// the status tells whether to show the article, and the version is the ETag
+	view, fields, err := parseView(c, detailIncludes, nil, "status", "version")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
 	}
 
-	if err := h.articleSvc.Update(article); err != nil {
+	article, err := h.articleSvc.GetByID(c.UserContext(), uint(id), view)
+	if err != nil {
+		return err
+	}
+	if !visible(c, article) {
+		return fiber.ErrNotFound
+	}
+	if err := h.linker.Link(c, article.Attachments...); err != nil {
 		return err
 	}
 
-	return c.JSON(article)
+	c.Set(fiber.HeaderETag, utilities.FormatETag(article.Version))
+	return writeView(c, article, fields)
 }
 
-// Delete used to delete article
+// GetBySlug used to get article by slug
 //
-//	@Summary		Delete article
-//	@Description	Delete article
+//	@Summary		Get article by slug
+//	@Description	Get article by slug. A previous slug of an article redirects to its current slug.
+//	@Description	Articles that are not published are only found with the X-Editor header.
+//	@Description	The author is only embedded with include=author, and the attachments with include=attachments, each
+//	@Description	with signed URLs of its file and of the variants of images.
 //	@Tags			articles
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		int				true	"Article ID"
-//	@Success		200	{object}	domain.Message	"Success delete article"
-//	@Failure		400	{object}	domain.Error	"Bad Request"
-//	@Failure		500	{object}	domain.Error	"Internal Server Error"
-//	@Router			/articles/{id} [delete]
-func (h *HttpArticleHandler) Delete(c *fiber.Ctx) error {
-	id, err := c.ParamsInt("id")
+//	@Param			slug	path		string			true	"Article slug"
+//	@Param			fields	query		string			false	"Comma separated fields to return, e.g. id,title,createdAt. Only these columns are loaded"
+//	@Param			include	query		string			false	"Comma separated relations to embed"					Enums(author, attachments)
+//	@Param			render	query		string			false	"Set contentHtml to the sanitised HTML of the content"	Enums(html)
+//	@Header			200		{string}	ETag			"Article version"
+//	@Success		200		{object}	domain.Article	"Article detail"
+//	@Success		301		"Moved to the current slug"
+//	@Failure		400		{object}	domain.Error	"Bad Request"
+//	@Failure		404		{object}	domain.Error	"Not Found"
+//	@Failure		500		{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/slug/{slug} [get]
+func (h *HttpArticleHandler) GetBySlug(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+
+	// the status tells whether to show the article, the slug whether to
+	// redirect, and the version is the ETag
+	view, fields, err := parseView(c, detailIncludes, nil, "status", "slug", "version")
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
 			Code:    fiber.StatusBadRequest,
@@ -198,12 +807,875 @@ func (h *HttpArticleHandler) Delete(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.articleSvc.Delete(uint(id)); err != nil {
+	article, err := h.articleSvc.GetBySlug(c.UserContext(), slug, view)
+	if err != nil {
 		return err
 	}
+	if !visible(c, article) {
+		return fiber.ErrNotFound
+	}
 
-	return c.JSON(domain.Message{
-		Code:    fiber.StatusOK,
+	if article.Slug != slug {
+		location := strings.TrimSuffix(c.Path(), slug) + article.Slug
+		// the fields and relations asked for apply to the new location too
+		if query := c.Request().URI().QueryString(); len(query) > 0 {
+			location += "?" + string(query)
+		}
+		return c.Redirect(location, fiber.StatusMovedPermanently)
+	}
+	if err := h.linker.Link(c, article.Attachments...); err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, utilities.FormatETag(article.Version))
+	return writeView(c, article, fields)
+}
+
+// visible reports whether the article may be shown: articles that are not
+// published are only shown to editors, as if they did not exist otherwise.
+func visible(c *fiber.Ctx, article *domain.Article) bool {
+	return article.Status == domain.ArticleStatusPublished || domain.EditorFromContext(c.UserContext()) != ""
+}
+
+// RecordView used to record a view of an article
+//
+//	@Summary		Record article view
+//	@Description	Count a view of a published article, to be sent by the page showing it. Views of the same visitor,
+//	@Description	told apart by IP address and user agent, are counted once per VIEWS_WINDOW. Views are written every
+//	@Description	VIEWS_FLUSH_INTERVAL, so viewCount lags behind for that long.
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				true	"Article ID"
+//	@Success		202	{object}	domain.Message	"Success record view"
+//	@Failure		400	{object}	domain.Error	"Bad Request"
+//	@Failure		404	{object}	domain.Error	"Not Found"
+//	@Failure		409	{object}	domain.Error	"Article is not published"
+//	@Failure		500	{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/{id}/views [post]
+func (h *HttpArticleHandler) RecordView(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	visitor := c.IP() + " " + c.Get(fiber.HeaderUserAgent)
+	if err := h.articleSvc.RecordView(c.UserContext(), uint(id), visitor); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(domain.Message{
+		Code:    fiber.StatusAccepted,
+		Message: "Success record view",
+	})
+}
+
+// Store used to store article
+//
+//	@Summary		Store article
+//	@Description	Store article
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			article	body		domain.ArticleStoreRequest	true	"Article data"
+//	@Success		201		{object}	domain.Article				"Article detail"
+//	@Failure		400		{object}	domain.Error				"Bad Request"
+//	@Failure		500		{object}	domain.Error				"Internal Server Error"
+//	@Router			/articles [post]
+func (h *HttpArticleHandler) Store(c *fiber.Ctx) error {
+	articleReq := utilities.ExtractStructFromValidator[domain.ArticleStoreRequest](c)
+
+	article := &domain.Article{
+		Title:         articleReq.Title,
+		Content:       articleReq.Content,
+		ContentFormat: articleReq.ContentFormat,
+		AuthorID:      articleReq.AuthorID,
+		Tags:          toTags(articleReq.Tags),
+	}
+
+	if err := h.articleSvc.Store(c.UserContext(), article); err != nil {
+		return err
+	}
+
+	c.Status(fiber.StatusCreated)
+	return c.JSON(article)
+}
+
+// StoreBulk used to store many articles at once
+//
+//	@Summary		Store many articles
+//	@Description	Store an array of articles, each validated like a single one. In atomic mode (the default) the
+//	@Description	articles are stored in one transaction and none is stored when one fails; in best-effort mode each
+//	@Description	article is stored on its own. The response has one result per article, in order, and is 201 when
+//	@Description	every article was stored or 207 otherwise. Articles skipped in atomic mode have status 424.
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			mode		query		string							false	"Atomic or best-effort (default atomic)"	Enums(atomic, best-effort)
+//	@Param			articles	body		[]domain.ArticleStoreRequest	true	"Articles data"
+//	@Success		201			{array}		domain.BulkResult				"Results"
+//	@Success		207			{array}		domain.BulkResult				"Results"
+//	@Failure		400			{object}	domain.Error					"Bad Request"
+//	@Failure		500			{object}	domain.Error					"Internal Server Error"
+//	@Router			/articles/bulk [post]
+func (h *HttpArticleHandler) StoreBulk(c *fiber.Ctx) error {
+	atomic, err := bulkMode(c)
+	if err != nil {
+		return err
+	}
+
+	var requests []domain.ArticleStoreRequest
+	if err := c.BodyParser(&requests); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err := h.checkBulkSize(len(requests)); err != nil {
+		return err
+	}
+
+	results := make([]domain.BulkResult, len(requests))
+	articles := make([]*domain.Article, 0, len(requests))
+	indexes := make([]int, 0, len(requests))
+	for i, articleReq := range requests {
+		results[i].Index = i
+		if errors := validation.Validate(articleReq); errors != nil {
+			results[i].Status = fiber.StatusBadRequest
+			results[i].Message = "validation error"
+			results[i].Errors = errors
+			continue
+		}
+
+		articles = append(articles, &domain.Article{
+			Title:         articleReq.Title,
+			Content:       articleReq.Content,
+			ContentFormat: articleReq.ContentFormat,
+			AuthorID:      articleReq.AuthorID,
+			Tags:          toTags(articleReq.Tags),
+		})
+		indexes = append(indexes, i)
+	}
+
+	if len(articles) > 0 && (!atomic || len(articles) == len(requests)) {
+		stored, err := h.articleSvc.StoreBulk(c.UserContext(), articles, atomic)
+		if err != nil {
+			return err
+		}
+		for i, result := range stored {
+			result.Index = indexes[i]
+			results[indexes[i]] = result
+		}
+	}
+
+	domain.SkipPending(results)
+	return writeBulk(c, results, fiber.StatusCreated)
+}
+
+// DeleteBulk used to delete many articles at once
+//
+//	@Summary		Delete many articles
+//	@Description	Move articles to the trash by ID, without checking their versions. In atomic mode (the default)
+//	@Description	none is deleted when one does not exist. The response has one result per ID, in order, and is 200
+//	@Description	when every article was deleted or 207 otherwise. Articles skipped in atomic mode have status 424.
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			mode	query		string							false	"Atomic or best-effort (default atomic)"	Enums(atomic, best-effort)
+//	@Param			ids		body		domain.ArticleBulkDeleteRequest	true	"Article IDs"
+//	@Success		200		{array}		domain.BulkResult				"Results"
+//	@Success		207		{array}		domain.BulkResult				"Results"
+//	@Failure		400		{object}	domain.Error					"Bad Request"
+//	@Failure		500		{object}	domain.Error					"Internal Server Error"
+//	@Router			/articles/bulk/delete [post]
+func (h *HttpArticleHandler) DeleteBulk(c *fiber.Ctx) error {
+	deleteReq := utilities.ExtractStructFromValidator[domain.ArticleBulkDeleteRequest](c)
+
+	atomic, err := bulkMode(c)
+	if err != nil {
+		return err
+	}
+	if err := h.checkBulkSize(len(deleteReq.IDs)); err != nil {
+		return err
+	}
+
+	results, err := h.articleSvc.DeleteBulk(c.UserContext(), deleteReq.IDs, atomic)
+	if err != nil {
+		return err
+	}
+	return writeBulk(c, results, fiber.StatusOK)
+}
+
+// ReassignBulk used to change the author of many articles at once
+//
+//	@Summary		Reassign many articles
+//	@Description	Change the author of articles by ID, without checking their versions. In atomic mode (the
+//	@Description	default) none is changed when one does not exist. The response has one result per ID, in order,
+//	@Description	and is 200 when every article was changed or 207 otherwise. Articles skipped in atomic mode have
+//	@Description	status 424.
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			mode	query		string								false	"Atomic or best-effort (default atomic)"	Enums(atomic, best-effort)
+//	@Param			request	body		domain.ArticleBulkReassignRequest	true	"Article IDs and the new author"
+//	@Success		200		{array}		domain.BulkResult					"Results"
+//	@Success		207		{array}		domain.BulkResult					"Results"
+//	@Failure		400		{object}	domain.Error						"Bad Request"
+//	@Failure		404		{object}	domain.Error						"Author Not Found"
+//	@Failure		500		{object}	domain.Error						"Internal Server Error"
+//	@Router			/articles/bulk/reassign [post]
+func (h *HttpArticleHandler) ReassignBulk(c *fiber.Ctx) error {
+	reassignReq := utilities.ExtractStructFromValidator[domain.ArticleBulkReassignRequest](c)
+
+	atomic, err := bulkMode(c)
+	if err != nil {
+		return err
+	}
+	if err := h.checkBulkSize(len(reassignReq.IDs)); err != nil {
+		return err
+	}
+
+	results, err := h.articleSvc.ReassignBulk(c.UserContext(), reassignReq.IDs, reassignReq.AuthorID, atomic)
+	if err != nil {
+		return err
+	}
+	return writeBulk(c, results, fiber.StatusOK)
+}
+
+// bulkMode tells whether a bulk request is atomic.
+func bulkMode(c *fiber.Ctx) (bool, error) {
+	switch c.Query("mode", "atomic") {
+	case "atomic":
+		return true, nil
+	case "best-effort":
+		return false, nil
+	}
+	return false, fiber.NewError(fiber.StatusBadRequest, "mode must be one of atomic or best-effort")
+}
+
+func (h *HttpArticleHandler) checkBulkSize(size int) error {
+	if size == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "at least one item is required")
+	}
+	if maxSize := h.cfg.Article.BulkMaxSize; maxSize > 0 && size > maxSize {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("at most %d items can be sent at once", maxSize))
+	}
+	return nil
+}
+
+// writeBulk writes the results of a bulk request with the success status when
+// every item succeeded, or 207 Multi-Status otherwise.
+func writeBulk(c *fiber.Ctx, results []domain.BulkResult, success int) error {
+	status := success
+	for _, result := range results {
+		if result.Status != success {
+			status = fiber.StatusMultiStatus
+			break
+		}
+	}
+
+	c.Status(status)
+	return c.JSON(results)
+}
+
+// Update used to replace article
+//
+//	@Summary		Replace article
+//	@Description	Replace every editable field of an article
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int							true	"Article ID"
+//	@Param			article		body		domain.ArticleUpdateRequest	true	"Article data"
+//	@Param			If-Match	header		string						false	"ETags of the article being replaced, or *"
+//	@Header			200			{string}	ETag						"Article version"
+//	@Success		200			{object}	domain.Article				"Article detail"
+//	@Failure		400			{object}	domain.Error				"Bad Request"
+//	@Failure		404			{object}	domain.Error				"Not Found"
+//	@Failure		412			{object}	domain.Error				"Precondition Failed"
+//	@Failure		428			{object}	domain.Error				"Precondition Required"
+//	@Failure		500			{object}	domain.Error				"Internal Server Error"
+//	@Router			/articles/{id} [put]
+func (h *HttpArticleHandler) Update(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	version, err := h.ifMatch(c, uint(id))
+	if err != nil {
+		return err
+	}
+
+	articleReq := utilities.ExtractStructFromValidator[domain.ArticleUpdateRequest](c)
+
+	article := &domain.Article{
+		ID:            uint(id),
+		Title:         articleReq.Title,
+		Content:       articleReq.Content,
+		ContentFormat: articleReq.ContentFormat,
+		AuthorID:      articleReq.AuthorID,
+		Tags:          toTags(articleReq.Tags),
+		Version:       version,
+	}
+
+	if err := h.articleSvc.Update(c.UserContext(), article); err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, utilities.FormatETag(article.Version))
+	return c.JSON(article)
+}
+
+// Patch used to partially update article
+//
+//	@Summary		Patch article
+//	@Description	Partially update article with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) document.
+//	@Description	The patch is applied to the editable fields (title, content, contentFormat, authorId, tags) and the result is validated
+//	@Description	with the same rules as a full replace.
+//	@Tags			articles
+//	@Accept			application/merge-patch+json,application/json-patch+json
+//	@Produce		json
+//	@Param			id			path		int				true	"Article ID"
+//	@Param			patch		body		object			true	"Merge patch object or JSON patch operations"
+//	@Param			If-Match	header		string			false	"ETags of the article being patched, or *"
+//	@Header			200			{string}	ETag			"Article version"
+//	@Success		200			{object}	domain.Article	"Article detail"
+//	@Failure		400			{object}	domain.Error	"Bad Request"
+//	@Failure		404			{object}	domain.Error	"Not Found"
+//	@Failure		409			{object}	domain.Error	"Patch cannot be applied"
+//	@Failure		412			{object}	domain.Error	"Precondition Failed"
+//	@Failure		415			{object}	domain.Error	"Unsupported Media Type"
+//	@Failure		428			{object}	domain.Error	"Precondition Required"
+//	@Failure		500			{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/{id} [patch]
+func (h *HttpArticleHandler) Patch(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	mediaType := utils.ToLower(strings.TrimSpace(strings.Split(c.Get(fiber.HeaderContentType), ";")[0]))
+	if mediaType != mimeMergePatch && mediaType != mimeJSONPatch {
+		c.Set("Accept-Patch", mimeMergePatch+", "+mimeJSONPatch)
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(domain.Error{
+			Code:    fiber.StatusUnsupportedMediaType,
+			Message: "content type must be " + mimeMergePatch + " or " + mimeJSONPatch,
+		})
+	}
+
+	version, err := h.ifMatch(c, uint(id))
+	if err != nil {
+		return err
+	}
+
+	current, err := h.articleSvc.GetByID(c.UserContext(), uint(id), nil)
+	if err != nil {
+		return err
+	}
+
+	// the patch was computed against current, so it must not be applied over
+	// a newer version
+	if version == 0 {
+		version = current.Version
+	}
+
+	document, err := json.Marshal(domain.ArticleUpdateRequest{
+		Title:         current.Title,
+		Content:       current.Content,
+		ContentFormat: current.ContentFormat,
+		AuthorID:      current.AuthorID,
+		Tags:          tagNames(current.Tags),
+	})
+	if err != nil {
+		return err
+	}
+
+	patched, err := applyPatch(mediaType, document, c.Body())
+	if err != nil {
+		return err
+	}
+
+	var articleReq domain.ArticleUpdateRequest
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&articleReq); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+	if errors := validation.Validate(articleReq); errors != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Errors:  errors,
+			Message: "validation error",
+		})
+	}
+
+	article := &domain.Article{
+		ID:            uint(id),
+		Title:         articleReq.Title,
+		Content:       articleReq.Content,
+		ContentFormat: articleReq.ContentFormat,
+		AuthorID:      articleReq.AuthorID,
+		Tags:          toTags(articleReq.Tags),
+		Version:       version,
+	}
+
+	if err := h.articleSvc.Update(c.UserContext(), article); err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderETag, utilities.FormatETag(article.Version))
+	return c.JSON(article)
+}
+
+// Delete used to delete article
+//
+//	@Summary		Delete article
+//	@Description	Move article to the trash, or remove it for good when permanent is true. If-Match is checked in
+//	@Description	both cases, against the version the article had when it was moved to the trash.
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int				true	"Article ID"
+//	@Param			permanent	query		bool			false	"Permanently delete the article"
+//	@Param			If-Match	header		string			false	"ETags of the article being deleted, or *"
+//	@Success		200			{object}	domain.Message	"Success delete article"
+//	@Failure		400			{object}	domain.Error	"Bad Request"
+//	@Failure		404			{object}	domain.Error	"Not Found"
+//	@Failure		412			{object}	domain.Error	"Precondition Failed"
+//	@Failure		428			{object}	domain.Error	"Precondition Required"
+//	@Failure		500			{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/{id} [delete]
+func (h *HttpArticleHandler) Delete(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	version, err := h.ifMatch(c, uint(id))
+	if err != nil {
+		return err
+	}
+
+	if c.QueryBool("permanent") {
+		err = h.articleSvc.DeletePermanent(c.UserContext(), uint(id), version)
+	} else {
+		err = h.articleSvc.Delete(c.UserContext(), uint(id), version)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(domain.Message{
+		Code:    fiber.StatusOK,
 		Message: "Success delete article",
 	})
 }
+
+// Restore used to restore deleted article
+//
+//	@Summary		Restore article
+//	@Description	Restore article from the trash
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				true	"Article ID"
+//	@Success		200	{object}	domain.Article	"Article detail"
+//	@Failure		400	{object}	domain.Error	"Bad Request"
+//	@Failure		404	{object}	domain.Error	"Not Found"
+//	@Failure		500	{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/{id}/restore [post]
+func (h *HttpArticleHandler) Restore(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	article, err := h.articleSvc.Restore(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(article)
+}
+
+// Submit used to submit article for review
+//
+//	@Summary		Submit article for review
+//	@Description	Move a draft article to review
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				true	"Article ID"
+//	@Success		200	{object}	domain.Article	"Article detail"
+//	@Failure		400	{object}	domain.Error	"Bad Request"
+//	@Failure		404	{object}	domain.Error	"Not Found"
+//	@Failure		409	{object}	domain.Error	"Status cannot be changed"
+//	@Failure		500	{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/{id}/submit [post]
+func (h *HttpArticleHandler) Submit(c *fiber.Ctx) error {
+	return h.transition(c, domain.ArticleStatusReview)
+}
+
+// Publish used to publish article
+//
+//	@Summary		Publish article
+//	@Description	Publish a draft or in review article. The first publish sets publishedAt.
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				true	"Article ID"
+//	@Success		200	{object}	domain.Article	"Article detail"
+//	@Failure		400	{object}	domain.Error	"Bad Request"
+//	@Failure		404	{object}	domain.Error	"Not Found"
+//	@Failure		409	{object}	domain.Error	"Status cannot be changed"
+//	@Failure		500	{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/{id}/publish [post]
+func (h *HttpArticleHandler) Publish(c *fiber.Ctx) error {
+	return h.transition(c, domain.ArticleStatusPublished)
+}
+
+// Unpublish used to unpublish article
+//
+//	@Summary		Unpublish article
+//	@Description	Move a published article back to draft
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				true	"Article ID"
+//	@Success		200	{object}	domain.Article	"Article detail"
+//	@Failure		400	{object}	domain.Error	"Bad Request"
+//	@Failure		404	{object}	domain.Error	"Not Found"
+//	@Failure		409	{object}	domain.Error	"Status cannot be changed"
+//	@Failure		500	{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/{id}/unpublish [post]
+func (h *HttpArticleHandler) Unpublish(c *fiber.Ctx) error {
+	return h.transition(c, domain.ArticleStatusDraft)
+}
+
+// Archive used to archive article
+//
+//	@Summary		Archive article
+//	@Description	Archive article
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				true	"Article ID"
+//	@Success		200	{object}	domain.Article	"Article detail"
+//	@Failure		400	{object}	domain.Error	"Bad Request"
+//	@Failure		404	{object}	domain.Error	"Not Found"
+//	@Failure		409	{object}	domain.Error	"Status cannot be changed"
+//	@Failure		500	{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/{id}/archive [post]
+func (h *HttpArticleHandler) Archive(c *fiber.Ctx) error {
+	return h.transition(c, domain.ArticleStatusArchived)
+}
+
+// Schedule used to schedule article publication
+//
+//	@Summary		Schedule article
+//	@Description	Set when the article is published and unpublished automatically. A null value clears the time.
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int								true	"Article ID"
+//	@Param			schedule	body		domain.ArticleScheduleRequest	true	"Publication schedule"
+//	@Success		200			{object}	domain.Article					"Article detail"
+//	@Failure		400			{object}	domain.Error					"Bad Request"
+//	@Failure		404			{object}	domain.Error					"Not Found"
+//	@Failure		500			{object}	domain.Error					"Internal Server Error"
+//	@Router			/articles/{id}/schedule [put]
+func (h *HttpArticleHandler) Schedule(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	scheduleReq := utilities.ExtractStructFromValidator[domain.ArticleScheduleRequest](c)
+
+	article, err := h.articleSvc.Schedule(c.UserContext(), uint(id), scheduleReq.PublishAt, scheduleReq.UnpublishAt)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(article)
+}
+
+// FetchRevisions used to get list of article revisions
+//
+//	@Summary		Get list of article revisions
+//	@Description	Get the revisions of an article, newest first. A revision is recorded every time the article is stored
+//	@Description	or updated.
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Article ID"
+//	@Param			page	query		int						false	"Page number (default 1)"
+//	@Param			size	query		int						false	"Size of page (default 10)"
+//	@Header			200		{string}	X-Cursor				"Next page"
+//	@Header			200		{string}	Link					"URL of the next page"
+//	@Header			200		{string}	X-Total-Count			"Total item"
+//	@Header			200		{string}	X-Max-Page				"Max page"
+//	@Success		200		{array}		domain.ArticleRevision	"List of revisions"
+//	@Failure		400		{object}	domain.Error			"Bad Request"
+//	@Failure		404		{object}	domain.Error			"Not Found"
+//	@Failure		500		{object}	domain.Error			"Internal Server Error"
+//	@Router			/articles/{id}/revisions [get]
+func (h *HttpArticleHandler) FetchRevisions(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	page, size := c.QueryInt("page", 1), c.QueryInt("size", 10)
+	if page <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "page must be a positive integer",
+		})
+	}
+	if size <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "size must be a positive integer",
+		})
+	}
+
+	if maxSize := h.cfg.Pagination.MaxSize; maxSize > 0 && size > maxSize {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: fmt.Sprintf("size must not be greater than %d", maxSize),
+		})
+	}
+
+	revisions, nextPage, err := h.articleSvc.FetchRevisions(c.UserContext(), uint(id), uint(page), uint(size))
+	if err != nil {
+		return err
+	}
+
+	if revisions == nil {
+		return c.JSON([]domain.ArticleRevision{})
+	}
+
+	totalItem, err := h.articleSvc.CountRevisions(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	utilities.SetPaginationHeaders(c, nextPage, totalItem, size)
+	return c.JSON(revisions)
+}
+
+// GetRevision used to get article revision
+//
+//	@Summary		Get article revision
+//	@Description	Get one revision of an article
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int						true	"Article ID"
+//	@Param			rev	path		int						true	"Revision number"
+//	@Success		200	{object}	domain.ArticleRevision	"Revision detail"
+//	@Failure		400	{object}	domain.Error			"Bad Request"
+//	@Failure		404	{object}	domain.Error			"Not Found"
+//	@Failure		500	{object}	domain.Error			"Internal Server Error"
+//	@Router			/articles/{id}/revisions/{rev} [get]
+func (h *HttpArticleHandler) GetRevision(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	rev, err := c.ParamsInt("rev")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	revision, err := h.articleSvc.GetRevision(c.UserContext(), uint(id), uint(rev))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(revision)
+}
+
+// DiffRevisions used to compare two article revisions
+//
+//	@Summary		Compare article revisions
+//	@Description	Get the word level changes of the title and content between two revisions of an article
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Article ID"
+//	@Param			from	query		int							true	"Old revision number"
+//	@Param			to		query		int							true	"New revision number"
+//	@Success		200		{object}	domain.ArticleRevisionDiff	"Changes between the revisions"
+//	@Failure		400		{object}	domain.Error				"Bad Request"
+//	@Failure		404		{object}	domain.Error				"Not Found"
+//	@Failure		500		{object}	domain.Error				"Internal Server Error"
+//	@Router			/articles/{id}/revisions/diff [get]
+func (h *HttpArticleHandler) DiffRevisions(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	from, to := c.QueryInt("from", 0), c.QueryInt("to", 0)
+	if from <= 0 || to <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "from and to must be positive integers",
+		})
+	}
+
+	diff, err := h.articleSvc.DiffRevisions(c.UserContext(), uint(id), uint(from), uint(to))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(diff)
+}
+
+// Revert used to revert article to a revision
+//
+//	@Summary		Revert article
+//	@Description	Restore the title and content of an article from one of its revisions. The revert is recorded as a
+//	@Description	new revision.
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				true	"Article ID"
+//	@Param			rev	path		int				true	"Revision number"
+//	@Success		200	{object}	domain.Article	"Article detail"
+//	@Failure		400	{object}	domain.Error	"Bad Request"
+//	@Failure		404	{object}	domain.Error	"Not Found"
+//	@Failure		500	{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/{id}/revisions/{rev}/revert [post]
+func (h *HttpArticleHandler) Revert(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	rev, err := c.ParamsInt("rev")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	article, err := h.articleSvc.Revert(c.UserContext(), uint(id), uint(rev))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(article)
+}
+
+// ifMatch returns the article version required by the If-Match header, or 0
+// when the header allows any version. When the header lists several tags,
+// the current version is looked up and required if it is one of them; the
+// write is then still guarded against changes made in the meantime.
+func (h *HttpArticleHandler) ifMatch(c *fiber.Ctx, id uint) (uint, error) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		if h.cfg.Article.RequireIfMatch {
+			return 0, fiber.NewError(fiber.StatusPreconditionRequired, "If-Match header is required")
+		}
+		return 0, nil
+	}
+
+	versions, wildcard := utilities.ParseIfMatch(header)
+	switch {
+	case wildcard:
+		return 0, nil
+	case len(versions) == 0:
+		// weak or malformed tags never match under strong comparison
+		return 0, fiber.NewError(fiber.StatusPreconditionFailed, "If-Match must be the ETag of the article")
+	case len(versions) == 1:
+		return versions[0], nil
+	}
+
+	// articles in the trash are looked up too, as they can be deleted permanently
+	current, err := h.articleSvc.GetByID(c.UserContext(), id, &domain.ArticleView{Columns: []string{"id", "version"}, Deleted: true})
+	if err != nil {
+		return 0, err
+	}
+	if !slices.Contains(versions, current.Version) {
+		return 0, fiber.NewError(fiber.StatusPreconditionFailed, "article was changed by another request")
+	}
+	return current.Version, nil
+}
+
+func (h *HttpArticleHandler) transition(c *fiber.Ctx, status domain.ArticleStatus) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	article, err := h.articleSvc.Transition(c.UserContext(), uint(id), status)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(article)
+}
+
+// toTags turns tag names from a request into tags for the service, keeping a
+// nil slice nil so that an omitted tags field leaves the tags unchanged.
+func toTags(names []string) []*domain.Tag {
+	if names == nil {
+		return nil
+	}
+
+	tags := make([]*domain.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, &domain.Tag{Name: name})
+	}
+	return tags
+}
+
+func tagNames(tags []*domain.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
@@ -2,17 +2,49 @@ package article
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/go-faker/faker/v4"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/attachment"
+	"go-clean-architecture/internal/config"
 	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/internal/middleware/editor"
+	"go-clean-architecture/internal/utilities"
 	"go-clean-architecture/mocks"
+	"go-clean-architecture/pkg/xcursor"
+	"go-clean-architecture/pkg/xdiff"
+	"go-clean-architecture/pkg/ximage"
+	"gorm.io/gorm/clause"
 	"io"
+	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
+)
+
+// tagsView is the view of details without fields and include.
+var tagsView = &domain.ArticleView{Tags: true}
+
+// pageListView, searchListView and cursorListView are the views of page, search
+// and cursor listings without fields and include.
+var (
+	pageListView = &domain.ArticleView{Columns: []string{
+		"id", "title", "slug", "excerpt", "word_count", "reading_time_minutes", "content_format", "author_id",
+		"status", "published_at", "publish_at", "unpublish_at", "version", "view_count", "created_at", "updated_at", "deleted_at",
+	}, Tags: true}
+	searchListView = &domain.ArticleView{Columns: append(slices.Clip(pageListView.Columns), "content"), Tags: true}
+	cursorListView = &domain.ArticleView{Columns: []string{
+		"id", "created_at", "title", "slug", "excerpt", "word_count", "reading_time_minutes", "content_format", "author_id",
+		"status", "published_at", "publish_at", "unpublish_at", "version", "view_count", "updated_at", "deleted_at",
+	}, Tags: true}
 )
 
 func TestHttpArticleHandler_Fetch(t *testing.T) {
@@ -29,12 +61,12 @@ func TestHttpArticleHandler_Fetch(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		size := uint(1)
 		page := uint(1)
-		mockService.On("Fetch", page, size, &domain.Article{}).
+		mockService.On("Fetch", mock.Anything, page, size, &domain.ArticleFilter{Status: domain.ArticleStatusPublished, View: pageListView}).
 			Return(mockListArticle, uint(2), nil).Once()
-		mockService.On("Count", &domain.Article{}).
+		mockService.On("Count", mock.Anything, &domain.ArticleFilter{Status: domain.ArticleStatusPublished, View: pageListView}).
 			Return(int64(2), nil).Once()
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
+		NewHttpHandler(app, mockService, config.Config{})
 		resp, err := app.Test(httptest.NewRequest("GET", "/?page=1&size=1", nil))
 		assert.NoError(t, err)
 		assert.Equal(t, 200, resp.StatusCode)
@@ -47,13 +79,13 @@ func TestHttpArticleHandler_Fetch(t *testing.T) {
 	t.Run("success with search", func(t *testing.T) {
 		size := uint(1)
 		page := uint(1)
-		mockService.On("Fetch", page, size, &domain.Article{Title: mockArticle.Title}).
+		mockService.On("Fetch", mock.Anything, page, size, &domain.ArticleFilter{Query: mockArticle.Title, Status: domain.ArticleStatusPublished, View: searchListView}).
 			Return(mockListArticle, uint(2), nil).Once()
-		mockService.On("Count", &domain.Article{Title: mockArticle.Title}).
+		mockService.On("Count", mock.Anything, &domain.ArticleFilter{Query: mockArticle.Title, Status: domain.ArticleStatusPublished, View: searchListView}).
 			Return(int64(2), nil).Once()
 
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
+		NewHttpHandler(app, mockService, config.Config{})
 		resp, err := app.Test(httptest.NewRequest("GET", "/?page=1&size=1&q="+mockArticle.Title, nil))
 		assert.NoError(t, err)
 		assert.Equal(t, 200, resp.StatusCode)
@@ -63,13 +95,28 @@ func TestHttpArticleHandler_Fetch(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("success with author filter", func(t *testing.T) {
+		mockNewService := new(mocks.ArticleService)
+		mockNewService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{AuthorID: 3, Status: domain.ArticleStatusPublished, View: pageListView}).
+			Return(mockListArticle, uint(2), nil).Once()
+		mockNewService.On("Count", mock.Anything, &domain.ArticleFilter{AuthorID: 3, Status: domain.ArticleStatusPublished, View: pageListView}).
+			Return(int64(2), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockNewService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?authorId=3", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockNewService.AssertExpectations(t)
+	})
+
 	t.Run("success with no data", func(t *testing.T) {
 		mockNewService := new(mocks.ArticleService)
-		mockNewService.On("Fetch", uint(1), uint(10), &domain.Article{}).
+		mockNewService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{Status: domain.ArticleStatusPublished, View: pageListView}).
 			Return(nil, uint(0), nil).Once()
 
 		app := fiber.New()
-		NewHttpHandler(app, mockNewService)
+		NewHttpHandler(app, mockNewService, config.Config{})
 		resp, err := app.Test(httptest.NewRequest("GET", "/?page=1&size=10", nil))
 		assert.NoError(t, err)
 		assert.Equal(t, 200, resp.StatusCode)
@@ -81,11 +128,11 @@ func TestHttpArticleHandler_Fetch(t *testing.T) {
 
 	t.Run("error", func(t *testing.T) {
 		mockNewService := new(mocks.ArticleService)
-		mockNewService.On("Fetch", uint(1), uint(10), &domain.Article{}).
+		mockNewService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{Status: domain.ArticleStatusPublished, View: pageListView}).
 			Return(nil, uint(0), errors.New("unexpected Error")).Once()
 
 		app := fiber.New()
-		NewHttpHandler(app, mockNewService)
+		NewHttpHandler(app, mockNewService, config.Config{})
 		resp, err := app.Test(httptest.NewRequest("GET", "/?page=1&size=10", nil))
 		assert.NoError(t, err)
 		assert.Equal(t, 500, resp.StatusCode)
@@ -94,13 +141,13 @@ func TestHttpArticleHandler_Fetch(t *testing.T) {
 
 	t.Run("error total item", func(t *testing.T) {
 		mockNewService := new(mocks.ArticleService)
-		mockNewService.On("Fetch", uint(1), uint(10), &domain.Article{}).
+		mockNewService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{Status: domain.ArticleStatusPublished, View: pageListView}).
 			Return(mockListArticle, uint(2), nil).Once()
-		mockNewService.On("Count", &domain.Article{}).
+		mockNewService.On("Count", mock.Anything, &domain.ArticleFilter{Status: domain.ArticleStatusPublished, View: pageListView}).
 			Return(int64(0), errors.New("unexpected Error")).Once()
 
 		app := fiber.New()
-		NewHttpHandler(app, mockNewService)
+		NewHttpHandler(app, mockNewService, config.Config{})
 		resp, err := app.Test(httptest.NewRequest("GET", "/?page=1&size=10", nil))
 		assert.NoError(t, err)
 		assert.Equal(t, 500, resp.StatusCode)
@@ -110,7 +157,7 @@ func TestHttpArticleHandler_Fetch(t *testing.T) {
 
 func TestHttpArticleHandler_Fetch_WithErrorSize(t *testing.T) {
 	app := fiber.New()
-	NewHttpHandler(app, nil)
+	NewHttpHandler(app, nil, config.Config{})
 	resp, err := app.Test(httptest.NewRequest("GET", "/?page=1&size=0", nil))
 	assert.NoError(t, err)
 	assert.Equal(t, 400, resp.StatusCode)
@@ -118,217 +165,2231 @@ func TestHttpArticleHandler_Fetch_WithErrorSize(t *testing.T) {
 
 func TestHttpArticleHandler_Fetch_WithErrorPage(t *testing.T) {
 	app := fiber.New()
-	NewHttpHandler(app, nil)
+	NewHttpHandler(app, nil, config.Config{})
 	resp, err := app.Test(httptest.NewRequest("GET", "/?page=0&size=1", nil))
 	assert.NoError(t, err)
 	assert.Equal(t, 400, resp.StatusCode)
 }
 
-func TestHttpArticleHandler_GetByID(t *testing.T) {
-	var mockArticle domain.Article
-	err := faker.FakeData(&mockArticle)
+func TestHttpArticleHandler_Fetch_WithErrorAuthorID(t *testing.T) {
+	app := fiber.New()
+	NewHttpHandler(app, nil, config.Config{})
+	resp, err := app.Test(httptest.NewRequest("GET", "/?authorId=-1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestHttpArticleHandler_Fetch_WithErrorMaxSize(t *testing.T) {
+	app := fiber.New()
+	NewHttpHandler(app, nil, config.Config{Pagination: config.Pagination{MaxSize: 50}})
+	resp, err := app.Test(httptest.NewRequest("GET", "/?size=51", nil))
 	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestHttpArticleHandler_Fetch_WithErrorSearch(t *testing.T) {
 	mockService := new(mocks.ArticleService)
 
-	t.Run("success", func(t *testing.T) {
-		mockService.On("GetByID", mockArticle.ID).
-			Return(&mockArticle, nil).Once()
+	app := fiber.New()
+	NewHttpHandler(app, mockService, config.Config{})
+	resp, err := app.Test(httptest.NewRequest("GET", "/?q="+url.QueryEscape("?! --"), nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	var body domain.Error
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "q must contain a letter or a digit", body.Message)
+	mockService.AssertExpectations(t)
+}
+
+func TestHttpArticleHandler_Fetch_WithStatus(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success-draft", func(t *testing.T) {
+		mockService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{Status: domain.ArticleStatusDraft, View: pageListView}).
+			Return(nil, uint(0), nil).Once()
 
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
-		id := strconv.Itoa(int(mockArticle.ID))
-		resp, err := app.Test(httptest.NewRequest("GET", "/"+id, nil))
+		app.Use(editor.New())
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("GET", "/?status=draft", nil)
+		req.Header.Set(editor.HeaderEditor, "jane")
+		resp, err := app.Test(req)
 		assert.NoError(t, err)
 		assert.Equal(t, 200, resp.StatusCode)
 		mockService.AssertExpectations(t)
 	})
 
-	t.Run("not found", func(t *testing.T) {
-		mockService.On("GetByID", mockArticle.ID).
-			Return(nil, fiber.ErrNotFound).Once()
+	t.Run("success-all", func(t *testing.T) {
+		mockService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{View: pageListView}).
+			Return(nil, uint(0), nil).Once()
 
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
-		id := strconv.Itoa(int(mockArticle.ID))
-		resp, err := app.Test(httptest.NewRequest("GET", "/"+id, nil))
+		app.Use(editor.New())
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("GET", "/?status=all", nil)
+		req.Header.Set(editor.HeaderEditor, "jane")
+		resp, err := app.Test(req)
 		assert.NoError(t, err)
-		assert.Equal(t, 404, resp.StatusCode)
+		assert.Equal(t, 200, resp.StatusCode)
 		mockService.AssertExpectations(t)
 	})
 
-	t.Run("error-parsing-id", func(t *testing.T) {
+	t.Run("error-invalid", func(t *testing.T) {
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
-		resp, err := app.Test(httptest.NewRequest("GET", "/abc", nil))
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?status=deleted", nil))
 		assert.NoError(t, err)
 		assert.Equal(t, 400, resp.StatusCode)
 		mockService.AssertExpectations(t)
 	})
 
-	t.Run("error", func(t *testing.T) {
-		mockService.On("GetByID", mockArticle.ID).
-			Return(nil, errors.New("unexpected Error")).Once()
-
+	t.Run("error-not-editor", func(t *testing.T) {
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
-		id := strconv.Itoa(int(mockArticle.ID))
-		resp, err := app.Test(httptest.NewRequest("GET", "/"+id, nil))
+		app.Use(editor.New())
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?status=draft", nil))
 		assert.NoError(t, err)
-		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, 403, resp.StatusCode)
 		mockService.AssertExpectations(t)
 	})
 }
 
-func TestHttpArticleHandler_Store(t *testing.T) {
-	var mockArticleStoreRequest domain.ArticleStoreRequest
-	err := faker.FakeData(&mockArticleStoreRequest)
-	assert.NoError(t, err)
-	mockArticle := &domain.Article{
-		Title:    mockArticleStoreRequest.Title,
-		Content:  mockArticleStoreRequest.Content,
-		AuthorID: mockArticleStoreRequest.AuthorID,
-	}
+func TestHttpArticleHandler_Fetch_WithTags(t *testing.T) {
 	mockService := new(mocks.ArticleService)
 
-	t.Run("success", func(t *testing.T) {
-		mockService.On("Store", mockArticle).
-			Return(nil).Once()
+	t.Run("success-any", func(t *testing.T) {
+		filter := &domain.ArticleFilter{Status: domain.ArticleStatusPublished, Tags: []string{"go", "web"}, View: pageListView}
+		mockService.On("Fetch", mock.Anything, uint(1), uint(10), filter).
+			Return(nil, uint(0), nil).Once()
 
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
-		bodyRequest, err := json.Marshal(mockArticleStoreRequest)
-		assert.NoError(t, err)
-		bodyRequestIO := io.NopCloser(bytes.NewReader(bodyRequest))
-		req := httptest.NewRequest("POST", "/", bodyRequestIO)
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Content-Length", strconv.Itoa(len(bodyRequest)))
-		resp, err := app.Test(req)
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?tag=Go&tags=web,go,", nil))
 		assert.NoError(t, err)
-		assert.Equal(t, 201, resp.StatusCode)
+		assert.Equal(t, 200, resp.StatusCode)
 		mockService.AssertExpectations(t)
 	})
 
-	t.Run("error", func(t *testing.T) {
-		mockService.On("Store", mockArticle).
-			Return(errors.New("unexpected Error")).Once()
+	t.Run("success-all", func(t *testing.T) {
+		filter := &domain.ArticleFilter{Status: domain.ArticleStatusPublished, Tags: []string{"go", "web"}, AllTags: true, View: pageListView}
+		mockService.On("Fetch", mock.Anything, uint(1), uint(10), filter).
+			Return(nil, uint(0), nil).Once()
 
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
-		bodyRequest, err := json.Marshal(mockArticleStoreRequest)
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?tags=go,web&tagMatch=all", nil))
 		assert.NoError(t, err)
-		bodyRequestIO := io.NopCloser(bytes.NewReader(bodyRequest))
-		req := httptest.NewRequest("POST", "/", bodyRequestIO)
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Content-Length", strconv.Itoa(len(bodyRequest)))
-		resp, err := app.Test(req)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-invalid-match", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?tags=go&tagMatch=some", nil))
 		assert.NoError(t, err)
-		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, 400, resp.StatusCode)
 		mockService.AssertExpectations(t)
 	})
 }
 
-func TestHttpArticleHandler_Update(t *testing.T) {
-	var mockArticleUpdateRequest domain.ArticleUpdateRequest
-	err := faker.FakeData(&mockArticleUpdateRequest)
-	assert.NoError(t, err)
+func TestHttpArticleHandler_Fetch_WithSort(t *testing.T) {
+	mockService := new(mocks.ArticleService)
 
-	mockArticle := domain.Article{
-		Title:   mockArticleUpdateRequest.Title,
-		Content: mockArticleUpdateRequest.Content,
-	}
+	t.Run("success", func(t *testing.T) {
+		filter := &domain.ArticleFilter{
+			Status: domain.ArticleStatusPublished,
+			Sort:   []domain.SortField{{Column: "title"}, {Column: "created_at", Desc: true}},
+			View:   pageListView,
+		}
+		mockService.On("Fetch", mock.Anything, uint(1), uint(10), filter).
+			Return(nil, uint(0), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?sort=title,-createdAt", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-unknown-field", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?sort=-content", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-cursor", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?sort=title&cursor=", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
 
+func TestHttpArticleHandler_Fetch_WithTimes(t *testing.T) {
 	mockService := new(mocks.ArticleService)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
 
 	t.Run("success", func(t *testing.T) {
-		mockService.On("Update", &mockArticle).
-			Return(nil).
-			Once()
+		filter := &domain.ArticleFilter{Status: domain.ArticleStatusPublished, CreatedFrom: &from, CreatedTo: &to, UpdatedSince: &from, View: pageListView}
+		mockService.On("Fetch", mock.Anything, uint(1), uint(10), filter).
+			Return([]*domain.Article{{ID: 1}}, uint(2), nil).Once()
+		mockService.On("Count", mock.Anything, filter).
+			Return(int64(1), nil).Once()
 
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
-		bodyRequest, err := json.Marshal(mockArticleUpdateRequest)
-		assert.NoError(t, err)
-		bodyRequestIO := io.NopCloser(bytes.NewReader(bodyRequest))
-		req := httptest.NewRequest("PUT", "/"+strconv.Itoa(int(mockArticle.ID)), bodyRequestIO)
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Content-Length", strconv.Itoa(len(bodyRequest)))
-		resp, err := app.Test(req)
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?createdFrom=2024-01-01T00:00:00Z&createdTo=2024-02-01T00:00:00Z&updatedSince=2024-01-01T00:00:00Z", nil))
 		assert.NoError(t, err)
 		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "1", resp.Header.Get("X-Total-Count"))
 		mockService.AssertExpectations(t)
 	})
 
-	t.Run("error-parsing-id", func(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		for _, query := range []string{
+			"createdFrom=yesterday",
+			"createdTo=2024-02-01",
+			"updatedSince=1",
+			"createdFrom=2024-02-01T00:00:00Z&createdTo=2024-01-01T00:00:00Z",
+		} {
+			app := fiber.New()
+			NewHttpHandler(app, mockService, config.Config{})
+			resp, err := app.Test(httptest.NewRequest("GET", "/?"+query, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, 400, resp.StatusCode, query)
+		}
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_Fetch_WithFilter(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success", func(t *testing.T) {
+		filter := &domain.ArticleFilter{
+			Status: domain.ArticleStatusPublished,
+			Expression: clause.Expr{
+				SQL: "((? LIKE ? ESCAPE '!' AND ? > ?) AND ? IN ?)",
+				Vars: []any{
+					clause.Column{Name: "title"}, "%go%",
+					clause.Column{Name: "created_at"}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					clause.Column{Name: "author_id"}, []any{int64(1), int64(2)},
+				},
+			},
+			View: pageListView,
+		}
+		mockService.On("Fetch", mock.Anything, uint(1), uint(10), filter).
+			Return([]*domain.Article{{ID: 1}}, uint(2), nil).Once()
+		mockService.On("Count", mock.Anything, filter).
+			Return(int64(1), nil).Once()
+
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
-		bodyRequest, err := json.Marshal(mockArticleUpdateRequest)
+		NewHttpHandler(app, mockService, config.Config{})
+		query := url.Values{"filter": {`title co "go" and createdAt gt 2024-01-01 and authorId in (1,2)`}}
+		resp, err := app.Test(httptest.NewRequest("GET", "/?"+query.Encode(), nil))
 		assert.NoError(t, err)
-		bodyRequestIO := io.NopCloser(bytes.NewReader(bodyRequest))
-		req := httptest.NewRequest("PUT", "/abc", bodyRequestIO)
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Content-Length", strconv.Itoa(len(bodyRequest)))
-		resp, err := app.Test(req)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		tests := []struct {
+			filter   string
+			expected domain.FilterError
+		}{
+			{
+				filter:   `title co "go" and`,
+				expected: domain.FilterError{Code: 400, Message: "expected a field name, found end of filter", Filter: `title co "go" and`, Offset: 17},
+			},
+			{
+				filter:   `authorId eq 1 or version gt 2`,
+				expected: domain.FilterError{Code: 400, Message: `field "version" must be one of authorId, content, contentFormat, createdAt, id, publishedAt, slug, status, title, updatedAt`, Filter: `authorId eq 1 or version gt 2`, Offset: 17},
+			},
+		}
+
+		for _, tt := range tests {
+			app := fiber.New()
+			NewHttpHandler(app, mockService, config.Config{})
+			query := url.Values{"filter": {tt.filter}}
+			resp, err := app.Test(httptest.NewRequest("GET", "/?"+query.Encode(), nil))
+			assert.NoError(t, err)
+			assert.Equal(t, 400, resp.StatusCode)
+
+			var body domain.FilterError
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.expected, body)
+		}
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_Fetch_WithView(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+
+	t.Run("fields and include", func(t *testing.T) {
+		filter := &domain.ArticleFilter{
+			Status: domain.ArticleStatusPublished,
+			View:   &domain.ArticleView{Columns: []string{"id", "title", "created_at", "author_id"}, Author: true},
+		}
+		articles := []*domain.Article{{ID: 1, Title: "title", Content: "content", AuthorID: 2, Author: &domain.Author{ID: 2, Name: "author"}}}
+		mockService.On("Fetch", mock.Anything, uint(1), uint(10), filter).
+			Return(articles, uint(2), nil).Once()
+		mockService.On("Count", mock.Anything, filter).
+			Return(int64(1), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?fields=id,title,createdAt,id2&include=author", nil))
 		assert.NoError(t, err)
 		assert.Equal(t, 400, resp.StatusCode)
+
+		resp, err = app.Test(httptest.NewRequest("GET", "/?fields=id,title,createdAt&include=author", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var body []map[string]any
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Len(t, body, 1)
+		assert.ElementsMatch(t, []string{"id", "title", "createdAt", "author"}, keysOf(body[0]))
+		assert.Equal(t, "author", body[0]["author"].(map[string]any)["name"])
 		mockService.AssertExpectations(t)
 	})
 
-	t.Run("error", func(t *testing.T) {
-		mockService.On("Update", &mockArticle).
-			Return(errors.New("unexpected Error")).
-			Once()
+	t.Run("default fields", func(t *testing.T) {
+		article := &domain.Article{ID: 1, Title: "title", Excerpt: "short", WordCount: 250, ReadingTimeMinutes: 2, Tags: []*domain.Tag{}}
+		mockService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{Status: domain.ArticleStatusPublished, View: pageListView}).
+			Return([]*domain.Article{article}, uint(2), nil).Once()
+		mockService.On("Count", mock.Anything, &domain.ArticleFilter{Status: domain.ArticleStatusPublished, View: pageListView}).
+			Return(int64(1), nil).Once()
 
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
-		bodyRequest, err := json.Marshal(mockArticleUpdateRequest)
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
 		assert.NoError(t, err)
-		bodyRequestIO := io.NopCloser(bytes.NewReader(bodyRequest))
-		req := httptest.NewRequest("PUT", "/"+strconv.Itoa(int(mockArticle.ID)), bodyRequestIO)
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Content-Length", strconv.Itoa(len(bodyRequest)))
-		resp, err := app.Test(req)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var body []map[string]any
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Len(t, body, 1)
+		assert.NotContains(t, body[0], "content")
+		assert.Equal(t, "short", body[0]["excerpt"])
+		assert.Equal(t, float64(250), body[0]["wordCount"])
+		assert.Equal(t, float64(2), body[0]["readingTimeMinutes"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("content", func(t *testing.T) {
+		filter := &domain.ArticleFilter{Status: domain.ArticleStatusPublished, View: &domain.ArticleView{Columns: []string{"id", "content"}}}
+		mockService.On("Fetch", mock.Anything, uint(1), uint(10), filter).
+			Return([]*domain.Article{{ID: 1, Content: "full content"}}, uint(2), nil).Once()
+		mockService.On("Count", mock.Anything, filter).
+			Return(int64(1), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?fields=content", nil))
 		assert.NoError(t, err)
-		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"content":"full content"}]`, string(body))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		filter := &domain.ArticleFilter{
+			Status: domain.ArticleStatusPublished,
+			View:   &domain.ArticleView{Columns: []string{"id", "created_at", "title", "content"}, Tags: true},
+		}
+		mockService.On("FetchByCursor", mock.Anything, (*domain.ArticleCursor)(nil), uint(10), filter).
+			Return([]*domain.Article{{ID: 1, Title: "title", Tags: []*domain.Tag{{ID: 1, Name: "go"}}}}, nil, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?cursor=&fields=highlight,tags", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var body []map[string]any
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, []map[string]any{{"tags": []any{map[string]any{"id": float64(1), "name": "go", "createdAt": "0001-01-01T00:00:00Z"}}}}, body)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		for _, query := range []string{"fields=id,secret", "fields=id,id", "include=comments"} {
+			app := fiber.New()
+			NewHttpHandler(app, mockService, config.Config{})
+			resp, err := app.Test(httptest.NewRequest("GET", "/?"+query, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, 400, resp.StatusCode, query)
+		}
 		mockService.AssertExpectations(t)
 	})
 }
 
-func TestHttpArticleHandler_Delete(t *testing.T) {
-	var mockArticle domain.Article
-	err := faker.FakeData(&mockArticle)
-	assert.NoError(t, err)
+// keysOf returns the keys of a decoded JSON object.
+func keysOf(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestHttpArticleHandler_GetByID_WithView(t *testing.T) {
 	mockService := new(mocks.ArticleService)
+	article := &domain.Article{ID: 1, Title: "title", Content: "content", Status: domain.ArticleStatusPublished, Version: 3, AuthorID: 2, Author: &domain.Author{ID: 2, Name: "author"}}
 
 	t.Run("success", func(t *testing.T) {
-		mockService.On("Delete", mockArticle.ID).
-			Return(nil).Once()
+		view := &domain.ArticleView{Columns: []string{"id", "status", "version", "title", "author_id"}, Author: true}
+		mockService.On("GetByID", mock.Anything, uint(1), view).
+			Return(article, nil).Once()
 
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
-		resp, err := app.Test(httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID)), nil))
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1?fields=title&include=author", nil))
 		assert.NoError(t, err)
 		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, utilities.FormatETag(3), resp.Header.Get("ETag"))
+
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.ElementsMatch(t, []string{"title", "author"}, keysOf(body))
 		mockService.AssertExpectations(t)
 	})
 
-	t.Run("error-parsing-id", func(t *testing.T) {
+	t.Run("attachments", func(t *testing.T) {
+		article := &domain.Article{ID: 1, Status: domain.ArticleStatusPublished, Version: 3, Attachments: []*domain.Attachment{
+			{ID: 2, ArticleID: 1, Filename: "photo.png", Width: 1600, Height: 1200},
+			{ID: 3, ArticleID: 1, Filename: "notes.txt"},
+		}}
+		view := &domain.ArticleView{Columns: []string{"id", "status", "version"}, Attachments: true}
+		mockService.On("GetByID", mock.Anything, uint(1), view).
+			Return(article, nil).Once()
+
+		cfg := config.Config{
+			Attachment: config.Attachment{URLSecret: "secret", URLExpiry: time.Minute},
+			Image:      config.Image{Variants: ximage.Variants{{Name: "medium", Width: 800, Height: 800}}},
+		}
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
-		resp, err := app.Test(httptest.NewRequest("DELETE", "/abc", nil))
+		NewHttpHandler(app, mockService, cfg)
+		attachment.NewHttpHandler(app, nil, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/1?fields=id&include=attachments", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var body domain.Article
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Len(t, body.Attachments, 2)
+		assert.Contains(t, body.Attachments[0].URL, "/attachments/2/download?token=")
+		assert.Len(t, body.Attachments[0].Variants, 1)
+		assert.Equal(t, 800, body.Attachments[0].Variants[0].Width)
+		assert.Equal(t, 600, body.Attachments[0].Variants[0].Height)
+		assert.Contains(t, body.Attachments[0].Variants[0].URL, "/attachments/2/variants/medium?token=")
+		assert.Empty(t, body.Attachments[1].Variants)
+
+		// attachments are only embedded in single articles
+		resp, err = app.Test(httptest.NewRequest("GET", "/?include=attachments", nil))
 		assert.NoError(t, err)
 		assert.Equal(t, 400, resp.StatusCode)
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("slug", func(t *testing.T) {
+		article := &domain.Article{ID: 1, Slug: "title", Status: domain.ArticleStatusPublished, Version: 3}
+		view := &domain.ArticleView{Columns: []string{"id", "status", "slug", "version"}}
+		mockService.On("GetBySlug", mock.Anything, "title", view).
+			Return(article, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/slug/title?fields=id", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"id":1}`, string(body))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("render", func(t *testing.T) {
+		article := &domain.Article{ID: 1, Title: "title", Content: "*hi*", ContentFormat: domain.ContentFormatMarkdown, ContentHTML: "<p><em>hi</em></p>\n", Status: domain.ArticleStatusPublished, Version: 3}
+		view := &domain.ArticleView{Columns: []string{"id", "status", "version", "title", "content", "content_format", "rendered_content", "render_key"}, HTML: true}
+		mockService.On("GetByID", mock.Anything, uint(1), view).
+			Return(article, nil).Once()
+		mockService.On("GetByID", mock.Anything, uint(1), &domain.ArticleView{Tags: true, HTML: true}).
+			Return(article, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1?fields=title&render=html", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"title":"title","contentHtml":"<p><em>hi</em></p>\n"}`, string(body))
+
+		resp, err = app.Test(httptest.NewRequest("GET", "/1?render=html", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var full map[string]any
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&full))
+		assert.Equal(t, "markdown", full["contentFormat"])
+		assert.Equal(t, "<p><em>hi</em></p>\n", full["contentHtml"])
+		mockService.AssertExpectations(t)
+	})
+
 	t.Run("error", func(t *testing.T) {
-		mockService.On("Delete", mockArticle.ID).
-			Return(errors.New("unexpected Error")).Once()
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		for _, target := range []string{"/1?fields=secret", "/slug/title?include=comments", "/1?render=text"} {
+			resp, err := app.Test(httptest.NewRequest("GET", target, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, 400, resp.StatusCode, target)
+		}
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_FetchByCursor(t *testing.T) {
+	var mockArticle domain.Article
+	err := faker.FakeData(&mockArticle)
+	assert.NoError(t, err)
+	mockListArticle := []*domain.Article{&mockArticle}
+	cfg := config.Config{Pagination: config.Pagination{CursorSecret: "secret"}}
+	signer := xcursor.New([]byte(cfg.Pagination.CursorSecret))
+	nextCursor := &domain.ArticleCursor{CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), ID: 5}
+
+	t.Run("success first page", func(t *testing.T) {
+		mockService := new(mocks.ArticleService)
+		mockService.On("FetchByCursor", mock.Anything, (*domain.ArticleCursor)(nil), uint(1), &domain.ArticleFilter{Status: domain.ArticleStatusPublished, View: cursorListView}).
+			Return(mockListArticle, nextCursor, nil).Once()
 
 		app := fiber.New()
-		NewHttpHandler(app, mockService)
-		resp, err := app.Test(httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID)), nil))
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/?cursor=&size=1", nil))
 		assert.NoError(t, err)
-		assert.Equal(t, 500, resp.StatusCode)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var decoded domain.ArticleCursor
+		assert.NoError(t, signer.Decode(resp.Header.Get("X-Cursor"), &decoded))
+		assert.Equal(t, nextCursor.ID, decoded.ID)
+		assert.Contains(t, resp.Header.Get("Link"), `rel="next"`)
+		assert.Empty(t, resp.Header.Get("X-Total-Count"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success next page", func(t *testing.T) {
+		token, err := signer.Encode(nextCursor)
+		assert.NoError(t, err)
+
+		mockService := new(mocks.ArticleService)
+		mockService.On("FetchByCursor", mock.Anything, mock.MatchedBy(func(c *domain.ArticleCursor) bool {
+			return c != nil && c.ID == nextCursor.ID && c.CreatedAt.Equal(nextCursor.CreatedAt)
+		}), uint(10), &domain.ArticleFilter{Status: domain.ArticleStatusPublished, View: cursorListView}).
+			Return(nil, (*domain.ArticleCursor)(nil), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/?cursor="+token, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("X-Cursor"))
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(bodyBytes), "[]")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-invalid-cursor", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, nil, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/?cursor=forged.token", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockService := new(mocks.ArticleService)
+		mockService.On("FetchByCursor", mock.Anything, (*domain.ArticleCursor)(nil), uint(10), &domain.ArticleFilter{Status: domain.ArticleStatusPublished, View: cursorListView}).
+			Return(nil, (*domain.ArticleCursor)(nil), errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/?cursor=", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_GetByID(t *testing.T) {
+	var mockArticle domain.Article
+	err := faker.FakeData(&mockArticle)
+	assert.NoError(t, err)
+	mockArticle.Status = domain.ArticleStatusPublished
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success", func(t *testing.T) {
+		// the author is only embedded when included
+		mockService.On("GetByID", mock.Anything, mockArticle.ID, tagsView).
+			Return(&mockArticle, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		id := strconv.Itoa(int(mockArticle.ID))
+		resp, err := app.Test(httptest.NewRequest("GET", "/"+id, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, utilities.FormatETag(mockArticle.Version), resp.Header.Get("ETag"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService.On("GetByID", mock.Anything, mockArticle.ID, mock.Anything).
+			Return(nil, fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		id := strconv.Itoa(int(mockArticle.ID))
+		resp, err := app.Test(httptest.NewRequest("GET", "/"+id, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not-published", func(t *testing.T) {
+		draft := mockArticle
+		draft.Status = domain.ArticleStatusDraft
+		mockService.On("GetByID", mock.Anything, mockArticle.ID, tagsView).
+			Return(&draft, nil).Twice()
+
+		app := fiber.New()
+		app.Use(editor.New())
+		NewHttpHandler(app, mockService, config.Config{})
+		id := strconv.Itoa(int(mockArticle.ID))
+		resp, err := app.Test(httptest.NewRequest("GET", "/"+id, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+
+		// editors see articles in every status
+		req := httptest.NewRequest("GET", "/"+id, nil)
+		req.Header.Set(editor.HeaderEditor, "jane")
+		resp, err = app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/abc", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockService.On("GetByID", mock.Anything, mockArticle.ID, mock.Anything).
+			Return(nil, errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		id := strconv.Itoa(int(mockArticle.ID))
+		resp, err := app.Test(httptest.NewRequest("GET", "/"+id, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_GetBySlug(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+	mockArticle := &domain.Article{ID: 1, Title: "Title", Slug: "title", Status: domain.ArticleStatusPublished}
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("GetBySlug", mock.Anything, "title", mock.Anything).
+			Return(mockArticle, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app.Group("/api/articles"), mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/articles/slug/title", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var article domain.Article
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&article))
+		assert.Equal(t, "title", article.Slug)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not-published", func(t *testing.T) {
+		// the current slug of an article that is not published is not revealed
+		mockService.On("GetBySlug", mock.Anything, "old-title", mock.Anything).
+			Return(&domain.Article{ID: 1, Title: "Title", Slug: "title", Status: domain.ArticleStatusReview}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app.Group("/api/articles"), mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/articles/slug/old-title", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-redirect", func(t *testing.T) {
+		mockService.On("GetBySlug", mock.Anything, "old-title", mock.Anything).
+			Return(mockArticle, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app.Group("/api/articles"), mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/articles/slug/old-title", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 301, resp.StatusCode)
+		assert.Equal(t, "/api/articles/slug/title", resp.Header.Get("Location"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-redirect-query", func(t *testing.T) {
+		mockService.On("GetBySlug", mock.Anything, "old-title", mock.Anything).
+			Return(mockArticle, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app.Group("/api/articles"), mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/articles/slug/old-title?fields=id,title&include=author", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 301, resp.StatusCode)
+		assert.Equal(t, "/api/articles/slug/title?fields=id,title&include=author", resp.Header.Get("Location"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("GetBySlug", mock.Anything, "title", mock.Anything).
+			Return(nil, fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/slug/title", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_Store(t *testing.T) {
+	var mockArticleStoreRequest domain.ArticleStoreRequest
+	err := faker.FakeData(&mockArticleStoreRequest)
+	assert.NoError(t, err)
+	// required fields are set, as faker may leave them empty
+	mockArticleStoreRequest.Title = "Hello"
+	mockArticleStoreRequest.Content = "World"
+	mockArticleStoreRequest.AuthorID = 1
+	mockArticleStoreRequest.Tags = []string{"go", "fiber"}
+	mockArticleStoreRequest.ContentFormat = domain.ContentFormatMarkdown
+	mockArticle := &domain.Article{
+		Title:         mockArticleStoreRequest.Title,
+		Content:       mockArticleStoreRequest.Content,
+		ContentFormat: mockArticleStoreRequest.ContentFormat,
+		AuthorID:      mockArticleStoreRequest.AuthorID,
+		Tags:          []*domain.Tag{{Name: "go"}, {Name: "fiber"}},
+	}
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Store", mock.Anything, mockArticle).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		bodyRequest, err := json.Marshal(mockArticleStoreRequest)
+		assert.NoError(t, err)
+		bodyRequestIO := io.NopCloser(bytes.NewReader(bodyRequest))
+		req := httptest.NewRequest("POST", "/", bodyRequestIO)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Length", strconv.Itoa(len(bodyRequest)))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 201, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockService.On("Store", mock.Anything, mockArticle).
+			Return(errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		bodyRequest, err := json.Marshal(mockArticleStoreRequest)
+		assert.NoError(t, err)
+		bodyRequestIO := io.NopCloser(bytes.NewReader(bodyRequest))
+		req := httptest.NewRequest("POST", "/", bodyRequestIO)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Length", strconv.Itoa(len(bodyRequest)))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_StoreBulk(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+	first := &domain.Article{Title: "First", Content: "content", AuthorID: 1}
+	second := &domain.Article{Title: "Second", Content: "content", AuthorID: 1}
+	body := `[{"title":"First","content":"content","authorId":1},{"title":"","content":"content","authorId":1},{"title":"Second","content":"content","authorId":1}]`
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("StoreBulk", mock.Anything, []*domain.Article{first, second}, true).
+			Return([]domain.BulkResult{{Index: 0, ID: 1, Status: 201}, {Index: 1, ID: 2, Status: 201}}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/bulk", strings.NewReader(`[{"title":"First","content":"content","authorId":1},{"title":"Second","content":"content","authorId":1}]`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 201, resp.StatusCode)
+
+		var results []domain.BulkResult
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&results))
+		assert.Equal(t, []domain.BulkResult{{Index: 0, ID: 1, Status: 201}, {Index: 1, ID: 2, Status: 201}}, results)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid-item-atomic", func(t *testing.T) {
+		mockService := new(mocks.ArticleService)
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/bulk", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 207, resp.StatusCode)
+
+		var results []domain.BulkResult
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&results))
+		assert.Equal(t, []domain.BulkResult{
+			{Index: 0, Status: 424, Message: domain.BulkSkippedMessage},
+			{Index: 1, Status: 400, Message: "validation error", Errors: []string{"Title is required"}},
+			{Index: 2, Status: 424, Message: domain.BulkSkippedMessage},
+		}, results)
+		mockService.AssertNotCalled(t, "StoreBulk", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid-item-best-effort", func(t *testing.T) {
+		mockService.On("StoreBulk", mock.Anything, []*domain.Article{first, second}, false).
+			Return([]domain.BulkResult{{Index: 0, ID: 1, Status: 201}, {Index: 1, ID: 2, Status: 201}}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/bulk?mode=best-effort", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 207, resp.StatusCode)
+
+		var results []domain.BulkResult
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&results))
+		assert.Equal(t, []domain.BulkResult{
+			{Index: 0, ID: 1, Status: 201},
+			{Index: 1, Status: 400, Message: "validation error", Errors: []string{"Title is required"}},
+			{Index: 2, ID: 2, Status: 201},
+		}, results)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-mode", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/bulk?mode=partial", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-empty", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/bulk", strings.NewReader(`[]`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-max-size", func(t *testing.T) {
+		cfg := config.Config{}
+		cfg.Article.BulkMaxSize = 2
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		req := httptest.NewRequest("POST", "/bulk", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockService.On("StoreBulk", mock.Anything, []*domain.Article{first}, true).
+			Return(nil, errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/bulk", strings.NewReader(`[{"title":"First","content":"content","authorId":1}]`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_DeleteBulk(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("DeleteBulk", mock.Anything, []uint{1, 2}, true).
+			Return([]domain.BulkResult{{Index: 0, ID: 1, Status: 200}, {Index: 1, ID: 2, Status: 200}}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/bulk/delete", strings.NewReader(`{"ids":[1,2]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		mockService.On("DeleteBulk", mock.Anything, []uint{1, 2}, false).
+			Return([]domain.BulkResult{{Index: 0, ID: 1, Status: 200}, {Index: 1, ID: 2, Status: 404, Message: "Not Found"}}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/bulk/delete?mode=best-effort", strings.NewReader(`{"ids":[1,2]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 207, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-validation", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/bulk/delete", strings.NewReader(`{"ids":[1,1]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+}
+
+func TestHttpArticleHandler_ReassignBulk(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("ReassignBulk", mock.Anything, []uint{1, 2}, uint(5), true).
+			Return([]domain.BulkResult{{Index: 0, ID: 1, Status: 200}, {Index: 1, ID: 2, Status: 200}}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/bulk/reassign", strings.NewReader(`{"ids":[1,2],"authorId":5}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("author-not-found", func(t *testing.T) {
+		mockService.On("ReassignBulk", mock.Anything, []uint{1}, uint(5), true).
+			Return(nil, fiber.NewError(fiber.StatusNotFound, "author not found")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/bulk/reassign", strings.NewReader(`{"ids":[1],"authorId":5}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-validation", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/bulk/reassign", strings.NewReader(`{"ids":[1]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+}
+
+func TestHttpArticleHandler_Export(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+	exportWith := func(filter *domain.ArticleFilter) {
+		mockService.On("Export", mock.Anything, filter, mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(2).(func([]*domain.Article) error)
+				assert.NoError(t, fn([]*domain.Article{{ID: 1, Title: "first"}}))
+				assert.NoError(t, fn([]*domain.Article{{ID: 2, Title: "second"}}))
+			}).
+			Return(nil).Once()
+	}
+
+	t.Run("ndjson", func(t *testing.T) {
+		exportWith(&domain.ArticleFilter{AuthorID: 3, Status: domain.ArticleStatusPublished})
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/export?authorId=3", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="articles.ndjson"`, resp.Header.Get("Content-Disposition"))
+
+		var titles []string
+		decoder := json.NewDecoder(resp.Body)
+		for decoder.More() {
+			var article domain.Article
+			assert.NoError(t, decoder.Decode(&article))
+			titles = append(titles, article.Title)
+		}
+		assert.Equal(t, []string{"first", "second"}, titles)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		exportWith(&domain.ArticleFilter{})
+
+		app := fiber.New()
+		app.Use(editor.New())
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("GET", "/export?format=csv&status=all", nil)
+		req.Header.Set(editor.HeaderEditor, "jane")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+		assert.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[2], "2,second,"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-format", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/export?format=xml", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-filter", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/export?filter="+url.QueryEscape("title eq"), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+
+		var filterErr domain.FilterError
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&filterErr))
+		assert.Equal(t, "title eq", filterErr.Filter)
+	})
+}
+
+func TestHttpArticleHandler_Import(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+	first := &domain.Article{Title: "First", Content: "a", AuthorID: 1, Tags: []*domain.Tag{{Name: "go"}}}
+	second := &domain.Article{Title: "Second", Content: "b", AuthorID: 9}
+
+	t.Run("ndjson", func(t *testing.T) {
+		mockService.On("StoreBulk", mock.Anything, []*domain.Article{first, second}, false).
+			Return([]domain.BulkResult{{Index: 0, ID: 1, Status: 201}, {Index: 1, Status: 404, Message: "author not found"}}, nil).Once()
+
+		body := `{"title":"First","content":"a","authorId":1,"tags":["go"]}
+{"title":"","content":"a","authorId":1}
+{"title":"Second","content":"b","authorId":9}
+not json
+`
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/import", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 207, resp.StatusCode)
+
+		var result domain.ArticleImportResult
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, 1, result.Imported)
+		assert.Equal(t, 3, result.Failed)
+		assert.Equal(t, domain.ArticleImportError{Line: 2, Status: 400, Message: "validation error", Errors: []string{"Title is required"}}, result.Errors[0])
+		assert.Equal(t, 400, result.Errors[1].Status)
+		assert.Equal(t, 4, result.Errors[1].Line)
+		assert.Equal(t, domain.ArticleImportError{Line: 3, Status: 404, Message: "author not found"}, result.Errors[2])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		mockService.On("StoreBulk", mock.Anything, []*domain.Article{first}, false).
+			Return([]domain.BulkResult{{Index: 0, ID: 1, Status: 201}}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/import?format=csv", strings.NewReader("title,content,authorId,tags\nFirst,a,1,go\n"))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 201, resp.StatusCode)
+
+		var result domain.ArticleImportResult
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, domain.ArticleImportResult{Imported: 1, Errors: []domain.ArticleImportError{}}, result)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("batches", func(t *testing.T) {
+		var body strings.Builder
+		for i := 0; i < importBatchSize+1; i++ {
+			body.WriteString(`{"title":"First","content":"a","authorId":1,"tags":["go"]}` + "\n")
+		}
+		mockService.On("StoreBulk", mock.Anything, mock.AnythingOfType("[]*domain.Article"), false).
+			Return(func(_ context.Context, articles []*domain.Article, _ bool) []domain.BulkResult {
+				results := make([]domain.BulkResult, len(articles))
+				for i := range results {
+					results[i] = domain.BulkResult{Index: i, ID: uint(i + 1), Status: 201}
+				}
+				return results
+			}, nil).Twice()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/import?format=ndjson", strings.NewReader(body.String()))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 201, resp.StatusCode)
+
+		var result domain.ArticleImportResult
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, importBatchSize+1, result.Imported)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		mockService.On("StoreBulk", mock.Anything, []*domain.Article{first}, false).
+			Return([]domain.BulkResult{{Index: 0, ID: 1, Status: 201}}, nil).Once()
+
+		// the body is larger than the server would accept at once
+		body := `{"title":"First","content":"a","authorId":1,"tags":["go"]}` + "\n" + strings.Repeat("\n", 64)
+		app := fiber.New(fiber.Config{StreamRequestBody: true, BodyLimit: 16})
+		NewHttpHandler(app, mockService, config.Config{Article: config.Article{ImportMaxSize: 1024}})
+		req := httptest.NewRequest("POST", "/import?format=ndjson", strings.NewReader(body))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 201, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-too-large", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{Article: config.Article{ImportMaxSize: 16}})
+		req := httptest.NewRequest("POST", "/import?format=csv", strings.NewReader("title,content,authorId,tags\nFirst,a,1,go\n"))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 413, resp.StatusCode)
+	})
+
+	t.Run("error-too-large-chunked", func(t *testing.T) {
+		mockService.On("StoreBulk", mock.Anything, []*domain.Article{first}, false).
+			Return([]domain.BulkResult{{Index: 0, ID: 1, Status: 201}}, nil).Once()
+
+		row := `{"title":"First","content":"a","authorId":1,"tags":["go"]}` + "\n"
+		app := fiber.New(fiber.Config{StreamRequestBody: true})
+		NewHttpHandler(app, mockService, config.Config{Article: config.Article{ImportMaxSize: int64(len(row) + 8)}})
+		req := httptest.NewRequest("POST", "/import?format=ndjson", strings.NewReader(row+row))
+		req.ContentLength = -1
+		req.TransferEncoding = []string{"chunked"}
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 207, resp.StatusCode)
+
+		var result domain.ArticleImportResult
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, 1, result.Imported)
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, 413, result.Errors[0].Status)
+		}
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-format", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/import", strings.NewReader("title\n"))
+		req.Header.Set("Content-Type", "text/plain")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-header", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/import", strings.NewReader("title,content\nA,b\n"))
+		req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-empty", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/import?format=ndjson", strings.NewReader("\n"))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockService.On("StoreBulk", mock.Anything, []*domain.Article{first}, false).
+			Return(nil, errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/import?format=csv", strings.NewReader("title,content,authorId,tags\nFirst,a,1,go\n"))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_Update(t *testing.T) {
+	var mockArticleUpdateRequest domain.ArticleUpdateRequest
+	err := faker.FakeData(&mockArticleUpdateRequest)
+	assert.NoError(t, err)
+	// required fields are set, as faker may leave them empty
+	mockArticleUpdateRequest.Title = "Hello"
+	mockArticleUpdateRequest.Content = "World"
+	mockArticleUpdateRequest.AuthorID = 1
+	mockArticleUpdateRequest.Tags = []string{"go", "fiber"}
+	mockArticleUpdateRequest.ContentFormat = domain.ContentFormatMarkdown
+
+	mockArticle := domain.Article{
+		Title:         mockArticleUpdateRequest.Title,
+		Content:       mockArticleUpdateRequest.Content,
+		ContentFormat: mockArticleUpdateRequest.ContentFormat,
+		AuthorID:      mockArticleUpdateRequest.AuthorID,
+		Tags:          []*domain.Tag{{Name: "go"}, {Name: "fiber"}},
+	}
+
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Update", mock.Anything, &mockArticle).
+			Return(nil).
+			Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		bodyRequest, err := json.Marshal(mockArticleUpdateRequest)
+		assert.NoError(t, err)
+		bodyRequestIO := io.NopCloser(bytes.NewReader(bodyRequest))
+		req := httptest.NewRequest("PUT", "/"+strconv.Itoa(int(mockArticle.ID)), bodyRequestIO)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Length", strconv.Itoa(len(bodyRequest)))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-if-match", func(t *testing.T) {
+		expected := mockArticle
+		expected.Version = 3
+		mockService.On("Update", mock.Anything, &expected).
+			Return(nil).
+			Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		bodyRequest, err := json.Marshal(mockArticleUpdateRequest)
+		assert.NoError(t, err)
+		req := httptest.NewRequest("PUT", "/"+strconv.Itoa(int(mockArticle.ID)), bytes.NewReader(bodyRequest))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"3"`)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, `"3"`, resp.Header.Get("ETag"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-if-match-weak", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		bodyRequest, err := json.Marshal(mockArticleUpdateRequest)
+		assert.NoError(t, err)
+		req := httptest.NewRequest("PUT", "/1", bytes.NewReader(bodyRequest))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `W/"3"`)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 412, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-if-match-required", func(t *testing.T) {
+		app := fiber.New()
+		cfg := config.Config{}
+		cfg.Article.RequireIfMatch = true
+		NewHttpHandler(app, mockService, cfg)
+		bodyRequest, err := json.Marshal(mockArticleUpdateRequest)
+		assert.NoError(t, err)
+		req := httptest.NewRequest("PUT", "/1", bytes.NewReader(bodyRequest))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 428, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		bodyRequest, err := json.Marshal(mockArticleUpdateRequest)
+		assert.NoError(t, err)
+		bodyRequestIO := io.NopCloser(bytes.NewReader(bodyRequest))
+		req := httptest.NewRequest("PUT", "/abc", bodyRequestIO)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Length", strconv.Itoa(len(bodyRequest)))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-missing-field", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		bodyRequest, err := json.Marshal(domain.ArticleUpdateRequest{Title: mockArticleUpdateRequest.Title})
+		assert.NoError(t, err)
+		req := httptest.NewRequest("PUT", "/1", bytes.NewReader(bodyRequest))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("Update", mock.Anything, &mockArticle).
+			Return(fiber.ErrNotFound).
+			Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		bodyRequest, err := json.Marshal(mockArticleUpdateRequest)
+		assert.NoError(t, err)
+		req := httptest.NewRequest("PUT", "/"+strconv.Itoa(int(mockArticle.ID)), bytes.NewReader(bodyRequest))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockService.On("Update", mock.Anything, &mockArticle).
+			Return(errors.New("unexpected Error")).
+			Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		bodyRequest, err := json.Marshal(mockArticleUpdateRequest)
+		assert.NoError(t, err)
+		bodyRequestIO := io.NopCloser(bytes.NewReader(bodyRequest))
+		req := httptest.NewRequest("PUT", "/"+strconv.Itoa(int(mockArticle.ID)), bodyRequestIO)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Length", strconv.Itoa(len(bodyRequest)))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_Patch(t *testing.T) {
+	current := &domain.Article{
+		ID:       1,
+		Title:    "Title",
+		Content:  "Content",
+		AuthorID: 2,
+		Tags:     []*domain.Tag{{ID: 1, Name: "go"}},
+	}
+	mockService := new(mocks.ArticleService)
+
+	newRequest := func(contentType string, body string) *http.Request {
+		req := httptest.NewRequest("PATCH", "/1", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		return req
+	}
+
+	t.Run("success-merge-patch", func(t *testing.T) {
+		expected := &domain.Article{ID: 1, Title: "New Title", Content: "Content", AuthorID: 2, Tags: []*domain.Tag{{Name: "go"}}}
+		mockService.On("GetByID", mock.Anything, uint(1), mock.Anything).
+			Return(current, nil).Once()
+		mockService.On("Update", mock.Anything, expected).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(newRequest("application/merge-patch+json; charset=utf-8", `{"title":"New Title"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var article domain.Article
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&article))
+		assert.Equal(t, "New Title", article.Title)
+		assert.Equal(t, "Content", article.Content)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-json-patch", func(t *testing.T) {
+		expected := &domain.Article{ID: 1, Title: "Title", Content: "New Content", AuthorID: 3, Tags: []*domain.Tag{{Name: "go"}, {Name: "web"}}}
+		mockService.On("GetByID", mock.Anything, uint(1), mock.Anything).
+			Return(current, nil).Once()
+		mockService.On("Update", mock.Anything, expected).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		body := `[{"op":"test","path":"/title","value":"Title"},` +
+			`{"op":"replace","path":"/content","value":"New Content"},` +
+			`{"op":"replace","path":"/authorId","value":3},` +
+			`{"op":"add","path":"/tags/-","value":"web"}]`
+		resp, err := app.Test(newRequest("application/json-patch+json", body))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("PATCH", "/abc", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-unsupported-media-type", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(newRequest("application/json", `{"title":"New Title"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 415, resp.StatusCode)
+		assert.Equal(t, "application/merge-patch+json, application/json-patch+json", resp.Header.Get("Accept-Patch"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("GetByID", mock.Anything, uint(1), mock.Anything).
+			Return(nil, fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(newRequest("application/merge-patch+json", `{"title":"New Title"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-invalid-merge-patch", func(t *testing.T) {
+		mockService.On("GetByID", mock.Anything, uint(1), mock.Anything).
+			Return(current, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(newRequest("application/merge-patch+json", `{"title":`))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-invalid-json-patch", func(t *testing.T) {
+		mockService.On("GetByID", mock.Anything, uint(1), mock.Anything).
+			Return(current, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(newRequest("application/json-patch+json", `{"op":"replace"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-failed-test-operation", func(t *testing.T) {
+		mockService.On("GetByID", mock.Anything, uint(1), mock.Anything).
+			Return(current, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		body := `[{"op":"test","path":"/title","value":"Other"},{"op":"replace","path":"/title","value":"New"}]`
+		resp, err := app.Test(newRequest("application/json-patch+json", body))
+		assert.NoError(t, err)
+		assert.Equal(t, 409, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-unknown-field", func(t *testing.T) {
+		mockService.On("GetByID", mock.Anything, uint(1), mock.Anything).
+			Return(current, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(newRequest("application/merge-patch+json", `{"unknown":"value"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-validation", func(t *testing.T) {
+		mockService.On("GetByID", mock.Anything, uint(1), mock.Anything).
+			Return(current, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(newRequest("application/merge-patch+json", `{"title":null}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+
+		var body domain.Error
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, []string{"Title is required"}, body.Errors)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-update", func(t *testing.T) {
+		mockService.On("GetByID", mock.Anything, uint(1), mock.Anything).
+			Return(current, nil).Once()
+		mockService.On("Update", mock.Anything, mock.Anything).
+			Return(errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(newRequest("application/merge-patch+json", `{"content":"New Content"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_Delete(t *testing.T) {
+	var mockArticle domain.Article
+	err := faker.FakeData(&mockArticle)
+	assert.NoError(t, err)
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Delete", mock.Anything, mockArticle.ID, uint(0)).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID)), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-if-match", func(t *testing.T) {
+		mockService.On("Delete", mock.Anything, mockArticle.ID, uint(2)).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID)), nil)
+		req.Header.Set("If-Match", `"2"`)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-if-match-any", func(t *testing.T) {
+		mockService.On("Delete", mock.Anything, mockArticle.ID, uint(0)).
+			Return(nil).Once()
+
+		app := fiber.New()
+		cfg := config.Config{}
+		cfg.Article.RequireIfMatch = true
+		NewHttpHandler(app, mockService, cfg)
+		req := httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID)), nil)
+		req.Header.Set("If-Match", "*")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-if-match-list", func(t *testing.T) {
+		view := &domain.ArticleView{Columns: []string{"id", "version"}, Deleted: true}
+		mockService.On("GetByID", mock.Anything, mockArticle.ID, view).
+			Return(&domain.Article{ID: mockArticle.ID, Version: 3}, nil).Once()
+		mockService.On("Delete", mock.Anything, mockArticle.ID, uint(3)).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID)), nil)
+		req.Header.Set("If-Match", `"2", W/"4", "3"`)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-if-match-list", func(t *testing.T) {
+		view := &domain.ArticleView{Columns: []string{"id", "version"}, Deleted: true}
+		mockService.On("GetByID", mock.Anything, mockArticle.ID, view).
+			Return(&domain.Article{ID: mockArticle.ID, Version: 5}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID)), nil)
+		req.Header.Set("If-Match", `"2", "3"`)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 412, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-if-match-weak-in-list", func(t *testing.T) {
+		mockService.On("Delete", mock.Anything, mockArticle.ID, uint(2)).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID)), nil)
+		req.Header.Set("If-Match", `W/"2", "2"`)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-if-match-required", func(t *testing.T) {
+		app := fiber.New()
+		cfg := config.Config{}
+		cfg.Article.RequireIfMatch = true
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID)), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 428, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-version-mismatch", func(t *testing.T) {
+		mockService.On("Delete", mock.Anything, mockArticle.ID, uint(2)).
+			Return(fiber.NewError(fiber.StatusPreconditionFailed, "article was changed by another request")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID)), nil)
+		req.Header.Set("If-Match", `"2"`)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 412, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-permanent", func(t *testing.T) {
+		mockService.On("DeletePermanent", mock.Anything, mockArticle.ID, uint(0)).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID))+"?permanent=true", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-permanent-if-match", func(t *testing.T) {
+		mockService.On("DeletePermanent", mock.Anything, mockArticle.ID, uint(2)).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID))+"?permanent=true", nil)
+		req.Header.Set("If-Match", `"2"`)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-permanent-if-match-list", func(t *testing.T) {
+		// articles in the trash are compared too
+		view := &domain.ArticleView{Columns: []string{"id", "version"}, Deleted: true}
+		mockService.On("GetByID", mock.Anything, mockArticle.ID, view).
+			Return(&domain.Article{ID: mockArticle.ID, Version: 5}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID))+"?permanent=true", nil)
+		req.Header.Set("If-Match", `"2", "3"`)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 412, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-permanent-if-match-required", func(t *testing.T) {
+		app := fiber.New()
+		cfg := config.Config{}
+		cfg.Article.RequireIfMatch = true
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID))+"?permanent=true", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 428, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/abc", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("Delete", mock.Anything, mockArticle.ID, uint(0)).
+			Return(fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID)), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockService.On("Delete", mock.Anything, mockArticle.ID, uint(0)).
+			Return(errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/"+strconv.Itoa(int(mockArticle.ID)), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_FetchTrash(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+	mockArticles := []*domain.Article{{ID: 1, Title: "Title 1"}, {ID: 2, Title: "Title 2"}}
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("FetchTrash", mock.Anything, uint(1), uint(1)).
+			Return(mockArticles[:1], uint(2), nil).Once()
+		mockService.On("CountTrash", mock.Anything).
+			Return(int64(2), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/trash?page=1&size=1", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("X-Cursor"))
+		assert.Equal(t, "2", resp.Header.Get("X-Total-Count"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-empty", func(t *testing.T) {
+		mockService.On("FetchTrash", mock.Anything, uint(1), uint(10)).
+			Return(nil, uint(0), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/trash", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "[]", string(body))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-page", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/trash?page=0", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-size", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/trash?size=0", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-max-size", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{Pagination: config.Pagination{MaxSize: 50}})
+		resp, err := app.Test(httptest.NewRequest("GET", "/trash?size=51", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-fetch", func(t *testing.T) {
+		mockService.On("FetchTrash", mock.Anything, uint(1), uint(10)).
+			Return(nil, uint(0), errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/trash", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-count", func(t *testing.T) {
+		mockService.On("FetchTrash", mock.Anything, uint(1), uint(10)).
+			Return(mockArticles, uint(2), nil).Once()
+		mockService.On("CountTrash", mock.Anything).
+			Return(int64(0), errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/trash", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_Restore(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Restore", mock.Anything, uint(1)).
+			Return(&domain.Article{ID: 1, Title: "Title 1"}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("POST", "/1/restore", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var article domain.Article
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&article))
+		assert.Equal(t, uint(1), article.ID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("POST", "/abc/restore", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("Restore", mock.Anything, uint(1)).
+			Return(nil, fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("POST", "/1/restore", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_Transition(t *testing.T) {
+	tests := []struct {
+		path   string
+		status domain.ArticleStatus
+	}{
+		{path: "submit", status: domain.ArticleStatusReview},
+		{path: "publish", status: domain.ArticleStatusPublished},
+		{path: "unpublish", status: domain.ArticleStatusDraft},
+		{path: "archive", status: domain.ArticleStatusArchived},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			mockService := new(mocks.ArticleService)
+			mockService.On("Transition", mock.Anything, uint(1), tt.status).
+				Return(&domain.Article{ID: 1, Status: tt.status}, nil).Once()
+
+			app := fiber.New()
+			NewHttpHandler(app, mockService, config.Config{})
+			resp, err := app.Test(httptest.NewRequest("POST", "/1/"+tt.path, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, 200, resp.StatusCode)
+
+			var article domain.Article
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&article))
+			assert.Equal(t, tt.status, article.Status)
+			mockService.AssertExpectations(t)
+		})
+	}
+
+	t.Run("error-parsing-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, new(mocks.ArticleService), config.Config{})
+		resp, err := app.Test(httptest.NewRequest("POST", "/abc/publish", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-conflict", func(t *testing.T) {
+		mockService := new(mocks.ArticleService)
+		mockService.On("Transition", mock.Anything, uint(1), domain.ArticleStatusPublished).
+			Return(nil, fiber.NewError(fiber.StatusConflict, "cannot change status from archived to published")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("POST", "/1/publish", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 409, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_RecordView(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("RecordView", mock.Anything, uint(1), "0.0.0.0 Firefox").
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/1/views", nil)
+		req.Header.Set("User-Agent", "Firefox")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 202, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-published", func(t *testing.T) {
+		mockService.On("RecordView", mock.Anything, uint(2), mock.Anything).
+			Return(fiber.NewError(fiber.StatusConflict, "views are only recorded for published articles")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("POST", "/2/views", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 409, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("POST", "/abc/views", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+}
+
+func TestHttpArticleHandler_Schedule(t *testing.T) {
+	publishAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mockService := new(mocks.ArticleService)
+		mockService.On("Schedule", mock.Anything, uint(1), &publishAt, (*time.Time)(nil)).
+			Return(&domain.Article{ID: 1, PublishAt: &publishAt}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("PUT", "/1/schedule", strings.NewReader(`{"publishAt":"2030-01-01T09:00:00Z","unpublishAt":null}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, new(mocks.ArticleService), config.Config{})
+		req := httptest.NewRequest("PUT", "/abc/schedule", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockService := new(mocks.ArticleService)
+		mockService.On("Schedule", mock.Anything, uint(1), (*time.Time)(nil), (*time.Time)(nil)).
+			Return(nil, fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("PUT", "/1/schedule", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_FetchRevisions(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+	mockRevisions := []*domain.ArticleRevision{
+		{ID: 2, ArticleID: 1, Revision: 2, Title: "Title", Editor: "jane"},
+		{ID: 1, ArticleID: 1, Revision: 1, Title: "Title"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("FetchRevisions", mock.Anything, uint(1), uint(1), uint(2)).
+			Return(mockRevisions, uint(2), nil).Once()
+		mockService.On("CountRevisions", mock.Anything, uint(1)).
+			Return(int64(4), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/revisions?size=2", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "4", resp.Header.Get("X-Total-Count"))
+		assert.Equal(t, "2", resp.Header.Get("X-Cursor"))
+
+		var revisions []domain.ArticleRevision
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&revisions))
+		assert.Len(t, revisions, 2)
+		assert.Equal(t, "jane", revisions[0].Editor)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-empty", func(t *testing.T) {
+		mockService.On("FetchRevisions", mock.Anything, uint(1), uint(3), uint(10)).
+			Return(nil, uint(0), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/revisions?page=3", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "[]", string(body))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-invalid-query", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{Pagination: config.Pagination{MaxSize: 50}})
+		for _, target := range []string{"/abc/revisions", "/1/revisions?page=0", "/1/revisions?size=0", "/1/revisions?size=51"} {
+			resp, err := app.Test(httptest.NewRequest("GET", target, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, 400, resp.StatusCode, target)
+		}
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("FetchRevisions", mock.Anything, uint(9), uint(1), uint(10)).
+			Return(nil, uint(0), fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/9/revisions", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-count", func(t *testing.T) {
+		mockService.On("FetchRevisions", mock.Anything, uint(1), uint(1), uint(10)).
+			Return(mockRevisions, uint(2), nil).Once()
+		mockService.On("CountRevisions", mock.Anything, uint(1)).
+			Return(int64(0), assert.AnError).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/revisions", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_GetRevision(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("GetRevision", mock.Anything, uint(1), uint(2)).
+			Return(&domain.ArticleRevision{ArticleID: 1, Revision: 2, Title: "Title"}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/revisions/2", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var revision domain.ArticleRevision
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&revision))
+		assert.Equal(t, uint(2), revision.Revision)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-params", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		for _, target := range []string{"/abc/revisions/1", "/1/revisions/abc"} {
+			resp, err := app.Test(httptest.NewRequest("GET", target, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, 400, resp.StatusCode, target)
+		}
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("GetRevision", mock.Anything, uint(1), uint(9)).
+			Return(nil, fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/revisions/9", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_DiffRevisions(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success", func(t *testing.T) {
+		mockDiff := &domain.ArticleRevisionDiff{
+			ArticleID: 1,
+			From:      1,
+			To:        2,
+			Content:   []xdiff.Change{{Operation: xdiff.Insert, Text: "new"}},
+		}
+		mockService.On("DiffRevisions", mock.Anything, uint(1), uint(1), uint(2)).
+			Return(mockDiff, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/revisions/diff?from=1&to=2", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), `"content":[{"op":"insert","text":"new"}]`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-invalid-query", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		for _, target := range []string{"/abc/revisions/diff?from=1&to=2", "/1/revisions/diff?to=2", "/1/revisions/diff?from=1&to=-1"} {
+			resp, err := app.Test(httptest.NewRequest("GET", target, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, 400, resp.StatusCode, target)
+		}
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("DiffRevisions", mock.Anything, uint(1), uint(1), uint(9)).
+			Return(nil, fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/revisions/diff?from=1&to=9", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpArticleHandler_Revert(t *testing.T) {
+	mockService := new(mocks.ArticleService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Revert", mock.Anything, uint(1), uint(2)).
+			Return(&domain.Article{ID: 1, Title: "Old Title"}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("POST", "/1/revisions/2/revert", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var article domain.Article
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&article))
+		assert.Equal(t, "Old Title", article.Title)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-params", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		for _, target := range []string{"/abc/revisions/1/revert", "/1/revisions/abc/revert"} {
+			resp, err := app.Test(httptest.NewRequest("POST", target, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, 400, resp.StatusCode, target)
+		}
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("Revert", mock.Anything, uint(1), uint(9)).
+			Return(nil, fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("POST", "/1/revisions/9/revert", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
 		mockService.AssertExpectations(t)
 	})
 }
@@ -1,29 +1,38 @@
 package article
 
 import (
+	"context"
 	"go-clean-architecture/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
+	"time"
 )
 
 type mysqlArticleRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	index domain.ArticleSearchIndex
 }
 
-func NewMysqlArticleRepository(db *gorm.DB) domain.ArticleRepository {
-	return &mysqlArticleRepository{db: db}
+func NewMysqlArticleRepository(db *gorm.DB, index domain.ArticleSearchIndex) domain.ArticleRepository {
+	return &mysqlArticleRepository{db: db, index: index}
 }
 
-func (r *mysqlArticleRepository) Fetch(page uint, size uint, filter *domain.Article) ([]*domain.Article, uint, error) {
+func (r *mysqlArticleRepository) Fetch(ctx context.Context, page uint, size uint, filter *domain.ArticleFilter) ([]*domain.Article, uint, error) {
 	var articles []*domain.Article
 
 	offset := (page - 1) * size
-	query := r.db
-
-	if filter.Title != "" {
-		query = query.Where("title LIKE ?", "%"+filter.Title+"%")
+	query := applyView(r.applyFilter(r.db.WithContext(ctx), filter), filter.View, listView)
+	if len(filter.Sort) > 0 {
+		query = applySort(query, filter.Sort)
+	} else {
+		if filter.Query != "" {
+			query = r.index.Rank(query, filter.Query)
+		}
+		query = query.Order("created_at DESC")
 	}
 
-	if err := query.Order("created_at DESC").Offset(int(offset)).Limit(int(size)).Find(&articles).Error; err != nil {
+	if err := query.Offset(int(offset)).Limit(int(size)).Find(&articles).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -35,21 +44,87 @@ func (r *mysqlArticleRepository) Fetch(page uint, size uint, filter *domain.Arti
 	return articles, nextCursor, nil
 }
 
-func (r *mysqlArticleRepository) GetByID(id uint) (*domain.Article, error) {
+// exportBatchSize is the number of articles loaded at a time by Export.
+const exportBatchSize = 500
+
+func (r *mysqlArticleRepository) Export(ctx context.Context, filter *domain.ArticleFilter, fn func(articles []*domain.Article) error) error {
+	var articles []*domain.Article
+
+	// batches are read by ID, so the order is stable whatever is written
+	// meanwhile and search results are not ranked
+	query := applyView(r.applyFilter(r.db.WithContext(ctx), filter), filter.View, listView)
+	return query.FindInBatches(&articles, exportBatchSize, func(tx *gorm.DB, batch int) error {
+		return fn(articles)
+	}).Error
+}
+
+func (r *mysqlArticleRepository) FetchByCursor(ctx context.Context, cursor *domain.ArticleCursor, size uint, filter *domain.ArticleFilter) ([]*domain.Article, *domain.ArticleCursor, error) {
+	var articles []*domain.Article
+
+	// keyset pagination needs a stable order, so search results are not ranked
+	query := applyView(r.applyFilter(r.db.WithContext(ctx), filter), filter.View, listView)
+
+	if cursor != nil {
+		query = query.Where(r.db.Where("created_at < ?", cursor.CreatedAt).Or("created_at = ? AND id < ?", cursor.CreatedAt, cursor.ID))
+	}
+
+	// one extra row tells whether there is a next page without a count query
+	if err := query.Order("created_at DESC").Order("id DESC").Limit(int(size) + 1).Find(&articles).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var nextCursor *domain.ArticleCursor
+	if len(articles) > int(size) {
+		articles = articles[:size]
+		last := articles[len(articles)-1]
+		nextCursor = &domain.ArticleCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return articles, nextCursor, nil
+}
+
+func (r *mysqlArticleRepository) GetByID(ctx context.Context, id uint, view *domain.ArticleView) (*domain.Article, error) {
 	var article *domain.Article
-	if err := r.db.Preload("Author").First(&article, id).Error; err != nil {
+	if err := applyView(r.db.WithContext(ctx), view, detailView).First(&article, id).Error; err != nil {
 		return nil, err
 	}
 	return article, nil
 }
 
-func (r *mysqlArticleRepository) Count(filter *domain.Article) (int64, error) {
-	var count int64
-	query := r.db.Model(&domain.Article{})
+func (r *mysqlArticleRepository) GetBySlug(ctx context.Context, slug string, view *domain.ArticleView) (*domain.Article, error) {
+	var article *domain.Article
+	db := r.db.WithContext(ctx)
+	previous := db.Model(&domain.ArticleSlug{}).Select("article_id").Where("slug = ?", slug)
+	if err := applyView(db, view, detailView).Where("slug = ?", slug).Or("id = (?)", previous).First(&article).Error; err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+// SlugOwner returns the ID of the article that uses or used slug, including
+// deleted articles, or 0 when the slug is free.
+func (r *mysqlArticleRepository) SlugOwner(ctx context.Context, slug string) (uint, error) {
+	var ids []uint
+	if err := r.db.WithContext(ctx).Unscoped().Model(&domain.Article{}).Where("slug = ?", slug).Limit(1).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) > 0 {
+		return ids[0], nil
+	}
 
-	if filter.Title != "" {
-		query = query.Where("title LIKE ?", "%"+filter.Title+"%")
+	if err := r.db.WithContext(ctx).Model(&domain.ArticleSlug{}).Where("slug = ?", slug).Limit(1).Pluck("article_id", &ids).Error; err != nil {
+		return 0, err
 	}
+	if len(ids) > 0 {
+		return ids[0], nil
+	}
+
+	return 0, nil
+}
+
+func (r *mysqlArticleRepository) Count(ctx context.Context, filter *domain.ArticleFilter) (int64, error) {
+	var count int64
+	query := r.applyFilter(r.db.WithContext(ctx).Model(&domain.Article{}), filter)
 
 	if err := query.Count(&count).Error; err != nil {
 		return 0, err
@@ -58,30 +133,526 @@ func (r *mysqlArticleRepository) Count(filter *domain.Article) (int64, error) {
 	return count, nil
 }
 
-func (r *mysqlArticleRepository) Store(article *domain.Article) error {
-	return r.db.Create(article).Error
+func (r *mysqlArticleRepository) Store(ctx context.Context, article *domain.Article) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the tags are already stored, only the join rows have to be written
+		if err := tx.Omit("Tags.*").Create(article).Error; err != nil {
+			return err
+		}
+
+		return storeRevision(ctx, tx, article)
+	})
+}
+
+func (r *mysqlArticleRepository) StoreMany(ctx context.Context, articles []*domain.Article) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, article := range articles {
+			if err := tx.Omit("Tags.*").Create(article).Error; err != nil {
+				return &domain.BulkItemError{Index: i, Err: err}
+			}
+			if err := storeRevision(ctx, tx, article); err != nil {
+				return &domain.BulkItemError{Index: i, Err: err}
+			}
+		}
+		return nil
+	})
+}
+
+func (r *mysqlArticleRepository) Update(ctx context.Context, article *domain.Article) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// lock the row so that concurrent updates see each other's version and
+		// get consecutive revisions
+		var previous domain.Article
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("slug", "version").First(&previous, article.ID).Error; err != nil {
+			return err
+		}
+		if previous.Version != article.Version {
+			return domain.ErrVersionMismatch
+		}
+		article.Version++
+
+		if previous.Slug != article.Slug {
+			// the article may be taking back one of its own previous slugs
+			if err := tx.Where("article_id = ? AND slug = ?", article.ID, article.Slug).Delete(&domain.ArticleSlug{}).Error; err != nil {
+				return err
+			}
+			if previous.Slug != "" {
+				if err := tx.Create(&domain.ArticleSlug{ArticleID: article.ID, Slug: previous.Slug}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if article.Tags != nil {
+			if err := replaceTags(tx, article); err != nil {
+				return err
+			}
+		}
+
+		// select the columns explicitly so zero values are written as well
+		if err := tx.Model(article).Select("title", "slug", "content", "content_format", "rendered_content", "render_key",
+			"excerpt", "word_count", "reading_time_minutes", "author_id", "version").Updates(article).Error; err != nil {
+			return err
+		}
+
+		return storeRevision(ctx, tx, article)
+	})
+}
+
+// Delete moves an article to the trash. A non-zero version makes the delete
+// conditional on the stored version.
+func (r *mysqlArticleRepository) Delete(ctx context.Context, id uint, version uint) error {
+	query := r.db.WithContext(ctx)
+	if version != 0 {
+		query = query.Where("version = ?", version)
+	}
+
+	result := query.Delete(&domain.Article{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+	if version == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&domain.Article{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return domain.ErrVersionMismatch
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (r *mysqlArticleRepository) DeleteMany(ctx context.Context, ids []uint, atomic bool) ([]uint, error) {
+	return r.changeMany(ctx, ids, atomic, func(tx *gorm.DB, found []uint) error {
+		return tx.Delete(&domain.Article{}, found).Error
+	})
+}
+
+func (r *mysqlArticleRepository) ReassignMany(ctx context.Context, ids []uint, authorID uint, atomic bool) ([]uint, error) {
+	return r.changeMany(ctx, ids, atomic, func(tx *gorm.DB, found []uint) error {
+		return tx.Model(&domain.Article{}).Where("id IN ?", found).Updates(map[string]any{
+			"author_id": authorID,
+			"version":   gorm.Expr("version + 1"),
+		}).Error
+	})
+}
+
+// changeMany locks the articles with the given IDs and applies change to the
+// ones that exist with one statement. It returns the IDs that do not exist.
+func (r *mysqlArticleRepository) changeMany(ctx context.Context, ids []uint, atomic bool, change func(tx *gorm.DB, found []uint) error) ([]uint, error) {
+	var missing []uint
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var found []uint
+		if err := tx.Model(&domain.Article{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", ids).Pluck("id", &found).Error; err != nil {
+			return err
+		}
+
+		exists := make(map[uint]bool, len(found))
+		for _, id := range found {
+			exists[id] = true
+		}
+		for _, id := range ids {
+			if !exists[id] {
+				missing = append(missing, id)
+			}
+		}
+
+		if len(found) == 0 || (atomic && len(missing) > 0) {
+			return nil
+		}
+		return change(tx, found)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
+
+func (r *mysqlArticleRepository) FetchTrash(ctx context.Context, page uint, size uint) ([]*domain.Article, uint, error) {
+	var articles []*domain.Article
+
+	offset := (page - 1) * size
+	query := r.db.WithContext(ctx).Unscoped().Preload("Tags").Where("deleted_at IS NOT NULL")
+
+	if err := query.Order("deleted_at DESC").Offset(int(offset)).Limit(int(size)).Find(&articles).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var nextCursor uint
+	if len(articles) > 0 {
+		nextCursor = page + 1 // next page
+	}
+
+	return articles, nextCursor, nil
+}
+
+func (r *mysqlArticleRepository) CountTrash(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Unscoped().Model(&domain.Article{}).Where("deleted_at IS NOT NULL").Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *mysqlArticleRepository) Restore(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&domain.Article{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
-func (r *mysqlArticleRepository) Update(article *domain.Article) error {
-	return r.db.Updates(article).Error
+func (r *mysqlArticleRepository) DeletePermanent(ctx context.Context, id uint, version uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("article_id = ?", id).Delete(&domain.ArticleSlug{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM article_tags WHERE article_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("article_id = ?", id).Delete(&domain.ArticleRevision{}).Error; err != nil {
+			return err
+		}
+
+		query := tx.Unscoped()
+		if version != 0 {
+			query = query.Where("version = ?", version)
+		}
+		result := query.Delete(&domain.Article{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return nil
+		}
+		if version == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// the rows deleted above are rolled back with the error
+		var count int64
+		if err := tx.Unscoped().Model(&domain.Article{}).Where("id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return domain.ErrVersionMismatch
+		}
+		return gorm.ErrRecordNotFound
+	})
 }
 
-func (r *mysqlArticleRepository) Delete(id uint) error {
-	return r.db.Delete(&domain.Article{}, id).Error
+func (r *mysqlArticleRepository) Purge(ctx context.Context, deletedBefore time.Time) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the articles are locked, so that none is restored while purging
+		err := tx.Unscoped().Model(&domain.Article{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at < ?", deletedBefore).Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		if err := tx.Where("article_id IN ?", ids).Delete(&domain.ArticleSlug{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM article_tags WHERE article_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("article_id IN ?", ids).Delete(&domain.ArticleRevision{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&domain.Article{}, ids).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
 }
 
-func (r *mysqlArticleRepository) GetByAuthorID(authorID uint) ([]*domain.Article, error) {
+func (r *mysqlArticleRepository) GetByAuthorID(ctx context.Context, authorID uint) ([]*domain.Article, error) {
 	var articles []*domain.Article
-	if err := r.db.Where("author_id = ?", authorID).Find(&articles).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("author_id = ?", authorID).Find(&articles).Error; err != nil {
 		return nil, err
 	}
 	return articles, nil
 }
 
-func (r *mysqlArticleRepository) GetByTitle(title string) ([]*domain.Article, error) {
+// GetByTitle returns the articles matching title in the search index, which
+// covers the content as well, most relevant first.
+func (r *mysqlArticleRepository) GetByTitle(ctx context.Context, title string) ([]*domain.Article, error) {
 	var articles []*domain.Article
-	if err := r.db.Where("title LIKE ?", "%"+title+"%").Find(&articles).Error; err != nil {
+	query := r.index.Rank(r.index.Match(r.db.WithContext(ctx), title), title)
+	if err := query.Find(&articles).Error; err != nil {
 		return nil, err
 	}
 	return articles, nil
 }
+
+// UpdateStatus writes the status and publication time of article, but only
+// while the stored status is still from. It returns gorm.ErrRecordNotFound
+// when the article was changed or deleted in the meantime.
+func (r *mysqlArticleRepository) UpdateStatus(ctx context.Context, article *domain.Article, from domain.ArticleStatus) error {
+	result := r.db.WithContext(ctx).Model(article).Where("status = ?", from).Updates(map[string]any{
+		"status":       article.Status,
+		"published_at": article.PublishedAt,
+		"version":      gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	article.Version++
+	return nil
+}
+
+func (r *mysqlArticleRepository) UpdateSchedule(ctx context.Context, article *domain.Article) error {
+	result := r.db.WithContext(ctx).Model(article).Updates(map[string]any{
+		"publish_at":   article.PublishAt,
+		"unpublish_at": article.UnpublishAt,
+		"version":      gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	article.Version++
+	return nil
+}
+
+// SaveRendered stores the rendered content of an article. The update time
+// and version are left alone, as the content itself does not change.
+func (r *mysqlArticleRepository) SaveRendered(ctx context.Context, article *domain.Article) error {
+	return r.db.WithContext(ctx).Model(article).UpdateColumns(map[string]any{
+		"rendered_content": article.RenderedContent,
+		"render_key":       article.RenderKey,
+	}).Error
+}
+
+func (r *mysqlArticleRepository) AddViews(ctx context.Context, views map[uint]uint64, at time.Time) error {
+	hour := at.UTC().Truncate(time.Hour)
+	ids := make([]uint, 0, len(views))
+	for id := range views {
+		ids = append(ids, id)
+	}
+	// a fixed order keeps concurrent flushes from locking rows in turn
+	slices.Sort(ids)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			// the view count is read only to the model, and trashed articles
+			// keep their views in case they are restored
+			if err := tx.Exec("UPDATE articles SET view_count = view_count + ? WHERE id = ?", views[id], id).Error; err != nil {
+				return err
+			}
+
+			bucket := &domain.ArticleViewBucket{ArticleID: id, Hour: hour, Views: views[id]}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "article_id"}, {Name: "hour"}},
+				DoUpdates: clause.Set{{Column: clause.Column{Name: "views"}, Value: gorm.Expr("views + ?", views[id])}},
+			}).Create(bucket).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PublishDue publishes the draft and in review articles whose publish time has
+// passed. The status check in the same statement keeps it safe to run from
+// several instances at once: every article is flipped by exactly one of them.
+func (r *mysqlArticleRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&domain.Article{}).
+		Where("status IN ? AND publish_at <= ?", []domain.ArticleStatus{domain.ArticleStatusDraft, domain.ArticleStatusReview}, now).
+		Updates(map[string]any{
+			"status":       domain.ArticleStatusPublished,
+			"published_at": gorm.Expr("COALESCE(published_at, publish_at)"),
+			"publish_at":   nil,
+			"version":      gorm.Expr("version + 1"),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// UnpublishDue moves the published articles whose unpublish time has passed
+// back to draft.
+func (r *mysqlArticleRepository) UnpublishDue(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&domain.Article{}).
+		Where("status = ? AND unpublish_at <= ?", domain.ArticleStatusPublished, now).
+		Updates(map[string]any{
+			"status":       domain.ArticleStatusDraft,
+			"unpublish_at": nil,
+			"version":      gorm.Expr("version + 1"),
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *mysqlArticleRepository) FetchRevisions(ctx context.Context, articleID uint, page uint, size uint) ([]*domain.ArticleRevision, uint, error) {
+	var revisions []*domain.ArticleRevision
+
+	offset := (page - 1) * size
+	query := r.db.WithContext(ctx).Where("article_id = ?", articleID)
+
+	if err := query.Order("revision DESC").Offset(int(offset)).Limit(int(size)).Find(&revisions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var nextCursor uint
+	if len(revisions) > 0 {
+		nextCursor = page + 1 // next page
+	}
+
+	return revisions, nextCursor, nil
+}
+
+func (r *mysqlArticleRepository) CountRevisions(ctx context.Context, articleID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&domain.ArticleRevision{}).Where("article_id = ?", articleID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *mysqlArticleRepository) GetRevision(ctx context.Context, articleID uint, revision uint) (*domain.ArticleRevision, error) {
+	var articleRevision *domain.ArticleRevision
+	if err := r.db.WithContext(ctx).Where("article_id = ? AND revision = ?", articleID, revision).First(&articleRevision).Error; err != nil {
+		return nil, err
+	}
+	return articleRevision, nil
+}
+
+// storeRevision records the title and content of article as its next
+// revision, together with the editor found in ctx.
+func storeRevision(ctx context.Context, tx *gorm.DB, article *domain.Article) error {
+	var last uint
+	if err := tx.Model(&domain.ArticleRevision{}).Select("COALESCE(MAX(revision), 0)").Where("article_id = ?", article.ID).Scan(&last).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&domain.ArticleRevision{
+		ArticleID: article.ID,
+		Revision:  last + 1,
+		Title:     article.Title,
+		Content:   article.Content,
+		Editor:    domain.EditorFromContext(ctx),
+	}).Error
+}
+
+// replaceTags rewrites the join rows of article so that it has exactly its
+// Tags, which must already be stored.
+func replaceTags(tx *gorm.DB, article *domain.Article) error {
+	if err := tx.Exec("DELETE FROM article_tags WHERE article_id = ?", article.ID).Error; err != nil {
+		return err
+	}
+	if len(article.Tags) == 0 {
+		return nil
+	}
+
+	rows := make([]map[string]any, 0, len(article.Tags))
+	for _, tag := range article.Tags {
+		rows = append(rows, map[string]any{"article_id": article.ID, "tag_id": tag.ID})
+	}
+	return tx.Table("article_tags").Create(&rows).Error
+}
+
+func (r *mysqlArticleRepository) applyFilter(query *gorm.DB, filter *domain.ArticleFilter) *gorm.DB {
+	if filter.Query != "" {
+		query = r.index.Match(query, filter.Query)
+	}
+
+	if filter.AuthorID != 0 {
+		query = query.Where("author_id = ?", filter.AuthorID)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at <= ?", filter.CreatedTo)
+	}
+
+	if filter.UpdatedSince != nil {
+		query = query.Where("updated_at >= ?", filter.UpdatedSince)
+	}
+
+	if filter.Expression != nil {
+		query = query.Where(filter.Expression)
+	}
+
+	if len(filter.Tags) > 0 {
+		tagged := query.Session(&gorm.Session{NewDB: true}).Table("article_tags").
+			Select("article_tags.article_id").
+			Joins("JOIN tags ON tags.id = article_tags.tag_id").
+			Where("tags.name IN ?", filter.Tags)
+		if filter.AllTags {
+			tagged = tagged.Group("article_tags.article_id").Having("COUNT(DISTINCT tags.id) = ?", len(filter.Tags))
+		}
+		query = query.Where("id IN (?)", tagged)
+	}
+
+	return query
+}
+
+var (
+	// listView and detailView are loaded when no view is given.
+	listView   = &domain.ArticleView{Tags: true}
+	detailView = &domain.ArticleView{Author: true, Tags: true}
+)
+
+// applyView selects the columns and preloads the relations of view, or of
+// fallback when view is nil. Relations are preloaded with one query each for
+// all the articles.
+func applyView(query *gorm.DB, view *domain.ArticleView, fallback *domain.ArticleView) *gorm.DB {
+	if view == nil {
+		view = fallback
+	}
+
+	if view.Deleted {
+		query = query.Unscoped()
+	}
+	if len(view.Columns) > 0 {
+		columns := make([]string, len(view.Columns))
+		for i, column := range view.Columns {
+			columns[i] = "articles." + column
+		}
+		query = query.Select(columns)
+	}
+	if view.Author {
+		query = query.Preload("Author")
+	}
+	if view.Tags {
+		query = query.Preload("Tags")
+	}
+	if view.Attachments {
+		query = query.Preload("Attachments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		})
+	}
+	return query
+}
+
+func applySort(query *gorm.DB, sort []domain.SortField) *gorm.DB {
+	for _, field := range sort {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: field.Column}, Desc: field.Desc})
+	}
+
+	// the id breaks ties, so that rows do not move between pages
+	return query.Order("id")
+}
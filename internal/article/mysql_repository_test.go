@@ -1,11 +1,14 @@
 package article
 
 import (
+	"context"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/internal/search"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	gormLogger "gorm.io/gorm/logger"
 	"regexp"
 	"testing"
@@ -32,11 +35,27 @@ func mockDBConnection() (*gorm.DB, sqlmock.Sqlmock, error) {
 	return gdb, mock, nil
 }
 
+// expectRevision expects the next revision of an article to be recorded.
+func expectRevision(mock sqlmock.Sqlmock, articleID uint, last uint) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(revision), 0) FROM `article_revisions` WHERE article_id = ?")).
+		WithArgs(articleID).
+		WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(last))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `article_revisions` (`article_id`,`revision`,`title`,`content`,`editor`,`created_at`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(articleID, last+1, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+// expectNoTags expects the preload of article tags to find none.
+func expectNoTags(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_tags` WHERE `article_tags`.`article_id`")).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id"}))
+}
+
 func TestMysqlArticleRepository_Fetch(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "SELECT * FROM `articles` WHERE title LIKE ? ORDER BY created_at DESC LIMIT ?"
+	query := "SELECT articles.*, MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AS relevance FROM `articles` WHERE MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AND `articles`.`deleted_at` IS NULL ORDER BY relevance DESC,created_at DESC LIMIT ?"
 
 	expectedTitle := "title"
 	expectedContent := "content"
@@ -47,40 +66,243 @@ func TestMysqlArticleRepository_Fetch(t *testing.T) {
 		AddRow(1, expectedTitle, expectedContent, expectedAuthorID, expectedCreatedAt, expectedUpdatedAt)
 
 	mock.ExpectQuery(regexp.QuoteMeta(query)).
-		WithArgs("%"+expectedTitle+"%", 10).
+		WithArgs(expectedTitle, expectedTitle, 10).
 		WillReturnRows(rows)
+	expectNoTags(mock)
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	articles, _, err := repo.Fetch(1, 10, &domain.Article{Title: expectedTitle})
+	articles, _, err := repo.Fetch(context.Background(), 1, 10, &domain.ArticleFilter{Query: expectedTitle})
 	assert.NoError(t, err)
 	assert.NotNil(t, articles)
 }
 
+func TestMysqlArticleRepository_Fetch_WithAuthorID(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE author_id = ? AND `articles`.`deleted_at` IS NULL ORDER BY created_at DESC LIMIT ?"
+
+	expectedAuthorID := uint(1)
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "created_at", "updated_at"}).
+		AddRow(1, "title", "content", expectedAuthorID, time.Now(), time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(expectedAuthorID, 10).
+		WillReturnRows(rows)
+	expectNoTags(mock)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	articles, _, err := repo.Fetch(context.Background(), 1, 10, &domain.ArticleFilter{AuthorID: expectedAuthorID})
+	assert.NoError(t, err)
+	assert.Len(t, articles, 1)
+}
+
 func TestMysqlArticleRepository_Fetch_Error(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "SELECT * FROM `articles` WHERE title LIKE ? ORDER BY created_at DESC LIMIT 10"
+	query := "SELECT articles.*, MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AS relevance FROM `articles` WHERE MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AND `articles`.`deleted_at` IS NULL ORDER BY relevance DESC,created_at DESC LIMIT 10"
 
 	expectedTitle := "title"
 
 	mock.ExpectQuery(regexp.QuoteMeta(query)).
-		WithArgs("%" + expectedTitle + "%").
+		WithArgs(expectedTitle, expectedTitle).
+		WillReturnError(assert.AnError)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	articles, _, err := repo.Fetch(context.Background(), 1, 10, &domain.ArticleFilter{Query: expectedTitle})
+	assert.Error(t, err)
+	assert.Nil(t, articles)
+}
+
+func TestMysqlArticleRepository_Fetch_WithSort(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AND `articles`.`deleted_at` IS NULL " +
+		"ORDER BY `title`,`created_at` DESC,id LIMIT ? OFFSET ?"
+
+	rows := sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "title")
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("go", 10, 10).
+		WillReturnRows(rows)
+	expectNoTags(mock)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	filter := &domain.ArticleFilter{Query: "go", Sort: []domain.SortField{{Column: "title"}, {Column: "created_at", Desc: true}}}
+	articles, _, err := repo.Fetch(context.Background(), 2, 10, filter)
+	assert.NoError(t, err)
+	assert.Len(t, articles, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Count_WithTimes(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT count(*) FROM `articles` WHERE created_at >= ? AND created_at <= ? AND updated_at >= ? AND `articles`.`deleted_at` IS NULL"
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(from, to, since).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	count, err := repo.Count(context.Background(), &domain.ArticleFilter{CreatedFrom: &from, CreatedTo: &to, UpdatedSince: &since})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Count_WithExpression(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT count(*) FROM `articles` WHERE status = ? AND ((`author_id` = ? OR `status` IN (?,?))) AND `articles`.`deleted_at` IS NULL"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("published", int64(1), "draft", "review").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	expression := clause.Expr{
+		SQL:  "(? = ? OR ? IN ?)",
+		Vars: []any{clause.Column{Name: "author_id"}, int64(1), clause.Column{Name: "status"}, []any{"draft", "review"}},
+	}
+	count, err := repo.Count(context.Background(), &domain.ArticleFilter{Status: domain.ArticleStatusPublished, Expression: expression})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_FetchByCursor(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE author_id = ? AND (created_at < ? OR (created_at = ? AND id < ?)) AND `articles`.`deleted_at` IS NULL ORDER BY created_at DESC,id DESC LIMIT ?"
+
+	cursor := &domain.ArticleCursor{CreatedAt: time.Now(), ID: 10}
+	first := time.Now().Add(-time.Minute)
+	second := time.Now().Add(-2 * time.Minute)
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "created_at", "updated_at"}).
+		AddRow(9, "title 9", "content", 1, first, first).
+		AddRow(8, "title 8", "content", 1, second, second).
+		AddRow(7, "title 7", "content", 1, second, second)
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(uint(1), cursor.CreatedAt, cursor.CreatedAt, cursor.ID, 3).
+		WillReturnRows(rows)
+	expectNoTags(mock)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	articles, nextCursor, err := repo.FetchByCursor(context.Background(), cursor, 2, &domain.ArticleFilter{AuthorID: 1})
+	assert.NoError(t, err)
+	assert.Len(t, articles, 2)
+	assert.NotNil(t, nextCursor)
+	assert.Equal(t, uint(8), nextCursor.ID)
+	assert.True(t, second.Equal(nextCursor.CreatedAt))
+}
+
+func TestMysqlArticleRepository_FetchByCursor_LastPage(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AND `articles`.`deleted_at` IS NULL ORDER BY created_at DESC,id DESC LIMIT ?"
+
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "created_at", "updated_at"}).
+		AddRow(1, "title", "content", 1, time.Now(), time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("title", 11).
+		WillReturnRows(rows)
+	expectNoTags(mock)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	articles, nextCursor, err := repo.FetchByCursor(context.Background(), nil, 10, &domain.ArticleFilter{Query: "title"})
+	assert.NoError(t, err)
+	assert.Len(t, articles, 1)
+	assert.Nil(t, nextCursor)
+}
+
+func TestMysqlArticleRepository_FetchByCursor_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE `articles`.`deleted_at` IS NULL ORDER BY created_at DESC,id DESC LIMIT ?"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(11).
 		WillReturnError(assert.AnError)
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	articles, _, err := repo.Fetch(1, 10, &domain.Article{Title: expectedTitle})
+	articles, nextCursor, err := repo.FetchByCursor(context.Background(), nil, 10, &domain.ArticleFilter{})
 	assert.Error(t, err)
 	assert.Nil(t, articles)
+	assert.Nil(t, nextCursor)
+}
+
+func TestMysqlArticleRepository_Export(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE status = ? AND `articles`.`deleted_at` IS NULL ORDER BY `articles`.`id` LIMIT ?"
+	rows := sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "first").AddRow(2, "second")
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(domain.ArticleStatusPublished, exportBatchSize).
+		WillReturnRows(rows)
+	expectNoTags(mock)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	var ids []uint
+	err = repo.Export(context.Background(), &domain.ArticleFilter{Status: domain.ArticleStatusPublished}, func(articles []*domain.Article) error {
+		for _, article := range articles {
+			ids = append(ids, article.ID)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1, 2}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Export_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE `articles`.`deleted_at` IS NULL ORDER BY `articles`.`id` LIMIT ?"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	expectNoTags(mock)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Export(context.Background(), &domain.ArticleFilter{}, func(articles []*domain.Article) error {
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestMysqlArticleRepository_GetByID(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	queryArticle := "SELECT * FROM `articles` WHERE `articles`.`id` = ? ORDER BY `articles`.`id` LIMIT ?"
+	queryArticle := "SELECT * FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL ORDER BY `articles`.`id` LIMIT ?"
 
 	expectedArticleID := 1
 	expectedTitle := "title"
@@ -103,28 +325,111 @@ func TestMysqlArticleRepository_GetByID(t *testing.T) {
 	mock.ExpectQuery(regexp.QuoteMeta(queryAuthor)).
 		WithArgs(expectedAuthorID).
 		WillReturnRows(rowsAuthor)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_tags` WHERE `article_tags`.`article_id` = ?")).
+		WithArgs(expectedArticleID).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "tag_id"}).AddRow(expectedArticleID, 3))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `tags` WHERE `tags`.`id` = ?")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "go"))
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	article, err := repo.GetByID(uint(expectedArticleID))
+	article, err := repo.GetByID(context.Background(), uint(expectedArticleID), nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, article)
+	assert.Len(t, article.Tags, 1)
+	assert.Equal(t, "go", article.Tags[0].Name)
+}
+
+func TestMysqlArticleRepository_Fetch_WithView(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT articles.id,articles.title,articles.author_id FROM `articles` WHERE `articles`.`deleted_at` IS NULL ORDER BY created_at DESC LIMIT ?"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id"}).
+			AddRow(1, "first", 1).
+			AddRow(2, "second", 2).
+			AddRow(3, "third", 1))
+	// one query loads the authors of every article
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `authors` WHERE `authors`.`id` IN (?,?)")).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "one").AddRow(2, "two"))
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	view := &domain.ArticleView{Columns: []string{"id", "title", "author_id"}, Author: true}
+	articles, _, err := repo.Fetch(context.Background(), 1, 10, &domain.ArticleFilter{View: view})
+	assert.NoError(t, err)
+	assert.Len(t, articles, 3)
+	assert.Equal(t, "one", articles[0].Author.Name)
+	assert.Equal(t, "two", articles[1].Author.Name)
+	assert.Equal(t, "one", articles[2].Author.Name)
+	assert.Nil(t, articles[0].Tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_GetByID_WithView(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT articles.id,articles.version FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL ORDER BY `articles`.`id` LIMIT ?"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(1, 4))
+	expectNoTags(mock)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	article, err := repo.GetByID(context.Background(), 1, &domain.ArticleView{Columns: []string{"id", "version"}, Tags: true})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), article.Version)
+	assert.Nil(t, article.Author)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_GetByID_WithAttachments(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT articles.id,articles.version FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL ORDER BY `articles`.`id` LIMIT ?"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(1, 4))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `attachments` WHERE `attachments`.`article_id` = ? ORDER BY id")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "article_id", "filename", "width", "height"}).
+			AddRow(2, 1, "photo.png", 40, 20).
+			AddRow(3, 1, "notes.txt", 0, 0))
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	article, err := repo.GetByID(context.Background(), 1, &domain.ArticleView{Columns: []string{"id", "version"}, Attachments: true})
+	assert.NoError(t, err)
+	assert.Len(t, article.Attachments, 2)
+	assert.Equal(t, 40, article.Attachments[0].Width)
+	assert.Equal(t, "notes.txt", article.Attachments[1].Filename)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestMysqlArticleRepository_GetByID_NotFound(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	queryArticle := "SELECT * FROM `articles` WHERE `articles`.`id` = ? ORDER BY `articles`.`id` LIMIT 1"
+	queryArticle := "SELECT * FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL ORDER BY `articles`.`id` LIMIT 1"
 
 	expectedArticleID := 1
 	mock.ExpectQuery(regexp.QuoteMeta(queryArticle)).
 		WithArgs(expectedArticleID).
 		WillReturnError(gorm.ErrRecordNotFound)
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	article, err := repo.GetByID(uint(expectedArticleID))
+	article, err := repo.GetByID(context.Background(), uint(expectedArticleID), nil)
 	assert.Error(t, err)
 	assert.Nil(t, article)
 }
@@ -133,7 +438,7 @@ func TestMysqlArticleRepository_Count(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "SELECT count(*) FROM `articles` WHERE title LIKE ?"
+	query := "SELECT count(*) FROM `articles` WHERE MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AND `articles`.`deleted_at` IS NULL"
 
 	expectedTitle := "title"
 	expectedCount := int64(1)
@@ -141,12 +446,12 @@ func TestMysqlArticleRepository_Count(t *testing.T) {
 		AddRow(expectedCount)
 
 	mock.ExpectQuery(regexp.QuoteMeta(query)).
-		WithArgs("%" + expectedTitle + "%").
+		WithArgs(expectedTitle).
 		WillReturnRows(rows)
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	count, err := repo.Count(&domain.Article{Title: expectedTitle})
+	count, err := repo.Count(context.Background(), &domain.ArticleFilter{Query: expectedTitle})
 	assert.NoError(t, err)
 	assert.Equal(t, expectedCount, count)
 }
@@ -155,7 +460,7 @@ func TestMysqlArticleRepository_Count_WithFilter(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "SELECT count(*) FROM `articles` WHERE title LIKE ?"
+	query := "SELECT count(*) FROM `articles` WHERE MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AND `articles`.`deleted_at` IS NULL"
 
 	expectedTitle := "title"
 	expectedCount := int64(1)
@@ -163,31 +468,53 @@ func TestMysqlArticleRepository_Count_WithFilter(t *testing.T) {
 		AddRow(expectedCount)
 
 	mock.ExpectQuery(regexp.QuoteMeta(query)).
-		WithArgs("%" + expectedTitle + "%").
+		WithArgs(expectedTitle).
 		WillReturnRows(rows)
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	count, err := repo.Count(&domain.Article{Title: expectedTitle})
+	count, err := repo.Count(context.Background(), &domain.ArticleFilter{Query: expectedTitle})
 	assert.NoError(t, err)
 	assert.Equal(t, expectedCount, count)
 }
 
+func TestMysqlArticleRepository_Count_WithAuthorID(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT count(*) FROM `articles` WHERE MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AND author_id = ? AND `articles`.`deleted_at` IS NULL"
+
+	expectedTitle := "title"
+	expectedAuthorID := uint(1)
+	rows := sqlmock.NewRows([]string{"count"}).
+		AddRow(2)
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(expectedTitle, expectedAuthorID).
+		WillReturnRows(rows)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	count, err := repo.Count(context.Background(), &domain.ArticleFilter{Query: expectedTitle, AuthorID: expectedAuthorID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
 func TestMysqlArticleRepository_Count_Error(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "SELECT count(*) FROM `articles` WHERE title LIKE ?"
+	query := "SELECT count(*) FROM `articles` WHERE MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AND `articles`.`deleted_at` IS NULL"
 
 	expectedTitle := "title"
 
 	mock.ExpectQuery(regexp.QuoteMeta(query)).
-		WithArgs("%" + expectedTitle + "%").
+		WithArgs(expectedTitle).
 		WillReturnError(assert.AnError)
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	count, err := repo.Count(&domain.Article{Title: expectedTitle})
+	count, err := repo.Count(context.Background(), &domain.ArticleFilter{Query: expectedTitle})
 	assert.Error(t, err)
 	assert.Equal(t, int64(0), count)
 }
@@ -196,86 +523,67 @@ func TestMysqlArticleRepository_Store(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "INSERT INTO `articles` (`title`,`content`,`author_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?)"
+	query := "INSERT INTO `articles` (`title`,`slug`,`content`,`content_format`,`rendered_content`,`render_key`,`excerpt`,`word_count`,`reading_time_minutes`,`author_id`,`status`,`published_at`,`publish_at`,`unpublish_at`,`version`,`created_at`,`updated_at`,`deleted_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
 
 	article := &domain.Article{
 		Title:    "title",
+		Slug:     "title",
 		Content:  "content",
 		AuthorID: 1,
+		Status:   domain.ArticleStatusDraft,
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta(query)).
-		WithArgs(article.Title, article.Content, article.AuthorID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(article.Title, article.Slug, article.Content, "plain", "", "", "", 0, 0, article.AuthorID, article.Status, nil, nil, nil, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectRevision(mock, 1, 0)
 	mock.ExpectCommit()
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	err = repo.Store(article)
-	assert.NoError(t, err)
-}
-
-func TestMysqlArticleRepository_Store_Error(t *testing.T) {
-	db, mock, err := mockDBConnection()
+	err = repo.Store(context.Background(), article)
 	assert.NoError(t, err)
-
-	query := "INSERT INTO `articles` (`title`,`content`,`author_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?)"
-
-	expectedTitle := "title"
-	expectedContent := "content"
-	expectedAuthorID := uint(1)
-	expectedCreatedAt := time.Now()
-	expectedUpdatedAt := time.Now()
-
-	mock.ExpectExec(regexp.QuoteMeta(query)).
-		WithArgs(expectedTitle, expectedContent, expectedAuthorID, expectedCreatedAt, expectedUpdatedAt).
-		WillReturnError(assert.AnError)
-
-	repo := NewMysqlArticleRepository(db)
-
-	err = repo.Store(&domain.Article{
-		Title:     expectedTitle,
-		Content:   expectedContent,
-		AuthorID:  expectedAuthorID,
-		CreatedAt: expectedCreatedAt,
-		UpdatedAt: expectedUpdatedAt,
-	})
-	assert.Error(t, err)
 }
 
-func TestMysqlArticleRepository_Update(t *testing.T) {
+func TestMysqlArticleRepository_Store_WithTags(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "UPDATE `articles` SET `title`=?,`content`=?,`author_id`=?,`updated_at`=? WHERE `id` = ?"
+	query := "INSERT INTO `articles` (`title`,`slug`,`content`,`content_format`,`rendered_content`,`render_key`,`excerpt`,`word_count`,`reading_time_minutes`,`author_id`,`status`,`published_at`,`publish_at`,`unpublish_at`,`version`,`created_at`,`updated_at`,`deleted_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+	tagQuery := "INSERT INTO `article_tags` (`article_id`,`tag_id`) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE `article_id`=`article_id`"
 
 	article := &domain.Article{
-		ID:       1,
 		Title:    "title",
+		Slug:     "title",
 		Content:  "content",
 		AuthorID: 1,
+		Status:   domain.ArticleStatusDraft,
+		Tags:     []*domain.Tag{{ID: 3, Name: "go"}, {ID: 4, Name: "web"}},
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta(query)).
-		WithArgs(article.Title, article.Content, article.AuthorID, sqlmock.AnyArg(), article.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(tagQuery)).
+		WithArgs(1, 3, 1, 4).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	expectRevision(mock, 1, 0)
 	mock.ExpectCommit()
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	err = repo.Update(article)
+	err = repo.Store(context.Background(), article)
 	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestMysqlArticleRepository_Update_Error(t *testing.T) {
+func TestMysqlArticleRepository_Store_Error(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "UPDATE `articles` SET `title`=?,`content`=?,`author_id`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?"
+	query := "INSERT INTO `articles` (`title`,`slug`,`content`,`content_format`,`rendered_content`,`render_key`,`excerpt`,`word_count`,`reading_time_minutes`,`author_id`,`status`,`published_at`,`publish_at`,`unpublish_at`,`version`,`created_at`,`updated_at`,`deleted_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
 
-	expectedID := uint(1)
 	expectedTitle := "title"
 	expectedContent := "content"
 	expectedAuthorID := uint(1)
@@ -283,13 +591,12 @@ func TestMysqlArticleRepository_Update_Error(t *testing.T) {
 	expectedUpdatedAt := time.Now()
 
 	mock.ExpectExec(regexp.QuoteMeta(query)).
-		WithArgs(expectedTitle, expectedContent, expectedAuthorID, expectedCreatedAt, expectedUpdatedAt, expectedID).
+		WithArgs(expectedTitle, "", expectedContent, "plain", "", "", "", 0, 0, expectedAuthorID, sqlmock.AnyArg(), nil, nil, nil, 1, expectedCreatedAt, expectedUpdatedAt, nil).
 		WillReturnError(assert.AnError)
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	err = repo.Update(&domain.Article{
-		ID:        expectedID,
+	err = repo.Store(context.Background(), &domain.Article{
 		Title:     expectedTitle,
 		Content:   expectedContent,
 		AuthorID:  expectedAuthorID,
@@ -299,128 +606,1531 @@ func TestMysqlArticleRepository_Update_Error(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestMysqlArticleRepository_Delete(t *testing.T) {
+func TestMysqlArticleRepository_StoreMany(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "DELETE FROM `articles` WHERE `articles`.`id` = ?"
+	query := "INSERT INTO `articles` (`title`,`slug`,`content`,`content_format`,`rendered_content`,`render_key`,`excerpt`,`word_count`,`reading_time_minutes`,`author_id`,`status`,`published_at`,`publish_at`,`unpublish_at`,`version`,`created_at`,`updated_at`,`deleted_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
 
-	expectedID := uint(1)
+	articles := []*domain.Article{
+		{Title: "first", Slug: "first", Content: "content", AuthorID: 1, Status: domain.ArticleStatusDraft},
+		{Title: "second", Slug: "second", Content: "content", AuthorID: 1, Status: domain.ArticleStatusDraft},
+	}
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta(query)).
-		WithArgs(expectedID).
+		WithArgs("first", "first", "content", "plain", "", "", "", 0, 0, 1, domain.ArticleStatusDraft, nil, nil, nil, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectRevision(mock, 1, 0)
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs("second", "second", "content", "plain", "", "", "", 0, 0, 1, domain.ArticleStatusDraft, nil, nil, nil, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	expectRevision(mock, 2, 0)
 	mock.ExpectCommit()
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	err = repo.Delete(expectedID)
+	err = repo.StoreMany(context.Background(), articles)
 	assert.NoError(t, err)
+	assert.Equal(t, uint(1), articles[0].ID)
+	assert.Equal(t, uint(2), articles[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestMysqlArticleRepository_Delete_Error(t *testing.T) {
+func TestMysqlArticleRepository_StoreMany_Error(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "DELETE FROM `articles` WHERE `id` = ?"
+	query := "INSERT INTO `articles` (`title`,`slug`,`content`,`content_format`,`rendered_content`,`render_key`,`excerpt`,`word_count`,`reading_time_minutes`,`author_id`,`status`,`published_at`,`publish_at`,`unpublish_at`,`version`,`created_at`,`updated_at`,`deleted_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
 
-	expectedID := uint(1)
+	articles := []*domain.Article{
+		{Title: "first", Slug: "first", Content: "content", AuthorID: 1, Status: domain.ArticleStatusDraft},
+		{Title: "second", Slug: "second", Content: "content", AuthorID: 1, Status: domain.ArticleStatusDraft},
+	}
 
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectRevision(mock, 1, 0)
 	mock.ExpectExec(regexp.QuoteMeta(query)).
-		WithArgs(expectedID).
 		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	err = repo.Delete(expectedID)
-	assert.Error(t, err)
+	err = repo.StoreMany(context.Background(), articles)
+
+	var itemErr *domain.BulkItemError
+	assert.ErrorAs(t, err, &itemErr)
+	assert.Equal(t, 1, itemErr.Index)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestMysqlArticleRepository_GetByAuthorID(t *testing.T) {
+func TestMysqlArticleRepository_Update(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "SELECT * FROM `articles` WHERE author_id = ?"
+	selectQuery := "SELECT `slug`,`version` FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
+	query := "UPDATE `articles` SET `title`=?,`slug`=?,`content`=?,`content_format`=?,`rendered_content`=?,`render_key`=?,`excerpt`=?,`word_count`=?,`reading_time_minutes`=?,`author_id`=?,`version`=?,`updated_at`=? WHERE `articles`.`deleted_at` IS NULL AND `id` = ?"
 
-	expectedAuthorID := uint(1)
-	expectedTitle := "title"
-	expectedContent := "content"
-	expectedCreatedAt := time.Now()
-	expectedUpdatedAt := time.Now()
-	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "created_at", "updated_at"}).
-		AddRow(1, expectedTitle, expectedContent, expectedAuthorID, expectedCreatedAt, expectedUpdatedAt)
+	article := &domain.Article{
+		ID:       1,
+		Title:    "title",
+		Slug:     "title",
+		Content:  "content",
+		AuthorID: 1,
+		Version:  1,
+	}
 
-	mock.ExpectQuery(regexp.QuoteMeta(query)).
-		WithArgs(expectedAuthorID).
-		WillReturnRows(rows)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+		WithArgs(article.ID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"slug", "version"}).AddRow("title", 1))
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(article.Title, article.Slug, article.Content, article.ContentFormat, article.RenderedContent, article.RenderKey, article.Excerpt, article.WordCount, article.ReadingTimeMinutes, article.AuthorID, 2, sqlmock.AnyArg(), article.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectRevision(mock, 1, 2)
+	mock.ExpectCommit()
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	articles, err := repo.GetByAuthorID(expectedAuthorID)
+	err = repo.Update(context.Background(), article)
 	assert.NoError(t, err)
-	assert.NotNil(t, articles)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestMysqlArticleRepository_GetByAuthorID_Error(t *testing.T) {
+func TestMysqlArticleRepository_Update_WithTags(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "SELECT * FROM `articles` WHERE author_id = ?"
+	selectQuery := "SELECT `slug`,`version` FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
+	clearQuery := "DELETE FROM article_tags WHERE article_id = ?"
+	tagQuery := "INSERT INTO `article_tags` (`article_id`,`tag_id`) VALUES (?,?)"
+	query := "UPDATE `articles` SET `title`=?,`slug`=?,`content`=?,`content_format`=?,`rendered_content`=?,`render_key`=?,`excerpt`=?,`word_count`=?,`reading_time_minutes`=?,`author_id`=?,`version`=?,`updated_at`=? WHERE `articles`.`deleted_at` IS NULL AND `id` = ?"
 
-	expectedAuthorID := uint(1)
+	article := &domain.Article{
+		ID:       1,
+		Title:    "title",
+		Slug:     "title",
+		Content:  "content",
+		AuthorID: 1,
+		Version:  1,
+		Tags:     []*domain.Tag{{ID: 3, Name: "go"}},
+	}
 
-	mock.ExpectQuery(regexp.QuoteMeta(query)).
-		WithArgs(expectedAuthorID).
-		WillReturnError(assert.AnError)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+		WithArgs(article.ID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"slug", "version"}).AddRow("title", 1))
+	mock.ExpectExec(regexp.QuoteMeta(clearQuery)).
+		WithArgs(article.ID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(tagQuery)).
+		WithArgs(article.ID, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(article.Title, article.Slug, article.Content, article.ContentFormat, article.RenderedContent, article.RenderKey, article.Excerpt, article.WordCount, article.ReadingTimeMinutes, article.AuthorID, 2, sqlmock.AnyArg(), article.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectRevision(mock, 1, 2)
+	mock.ExpectCommit()
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	articles, err := repo.GetByAuthorID(expectedAuthorID)
-	assert.Error(t, err)
-	assert.Nil(t, articles)
+	err = repo.Update(context.Background(), article)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestMysqlArticleRepository_GetByTitle(t *testing.T) {
+func TestMysqlArticleRepository_Update_TagsError(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "SELECT * FROM `articles` WHERE title LIKE ?"
+	selectQuery := "SELECT `slug`,`version` FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
 
-	expectedTitle := "title"
-	expectedContent := "content"
-	expectedAuthorID := 1
-	expectedCreatedAt := time.Now()
-	expectedUpdatedAt := time.Now()
-	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "created_at", "updated_at"}).
-		AddRow(1, expectedTitle, expectedContent, expectedAuthorID, expectedCreatedAt, expectedUpdatedAt)
+	article := &domain.Article{ID: 1, Slug: "title", Version: 1, Tags: []*domain.Tag{{ID: 3, Name: "go"}}}
 
-	mock.ExpectQuery(regexp.QuoteMeta(query)).
-		WithArgs("%" + expectedTitle + "%").
-		WillReturnRows(rows)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+		WithArgs(article.ID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"slug", "version"}).AddRow("title", 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM article_tags WHERE article_id = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `article_tags`")).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	articles, err := repo.GetByTitle(expectedTitle)
-	assert.NoError(t, err)
-	assert.NotNil(t, articles)
+	err = repo.Update(context.Background(), article)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestMysqlArticleRepository_GetByTitle_Error(t *testing.T) {
+func TestMysqlArticleRepository_Update_SlugChanged(t *testing.T) {
 	db, mock, err := mockDBConnection()
 	assert.NoError(t, err)
 
-	query := "SELECT * FROM `articles` WHERE title LIKE ?"
-
-	expectedTitle := "title"
+	selectQuery := "SELECT `slug`,`version` FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
+	deleteQuery := "DELETE FROM `article_slugs` WHERE article_id = ? AND slug = ?"
+	insertQuery := "INSERT INTO `article_slugs` (`article_id`,`slug`,`created_at`) VALUES (?,?,?)"
+	query := "UPDATE `articles` SET `title`=?,`slug`=?,`content`=?,`content_format`=?,`rendered_content`=?,`render_key`=?,`excerpt`=?,`word_count`=?,`reading_time_minutes`=?,`author_id`=?,`version`=?,`updated_at`=? WHERE `articles`.`deleted_at` IS NULL AND `id` = ?"
+
+	article := &domain.Article{
+		ID:       1,
+		Title:    "new title",
+		Slug:     "new-title",
+		Content:  "content",
+		AuthorID: 1,
+		Version:  1,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+		WithArgs(article.ID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"slug", "version"}).AddRow("title", 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+		WithArgs(article.ID, article.Slug).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+		WithArgs(article.ID, "title", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(article.Title, article.Slug, article.Content, article.ContentFormat, article.RenderedContent, article.RenderKey, article.Excerpt, article.WordCount, article.ReadingTimeMinutes, article.AuthorID, 2, sqlmock.AnyArg(), article.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectRevision(mock, 1, 2)
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Update(context.Background(), article)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Update_SlugHistoryError(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	selectQuery := "SELECT `slug`,`version` FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
+	deleteQuery := "DELETE FROM `article_slugs` WHERE article_id = ? AND slug = ?"
+	insertQuery := "INSERT INTO `article_slugs` (`article_id`,`slug`,`created_at`) VALUES (?,?,?)"
+
+	article := &domain.Article{ID: 1, Title: "new title", Slug: "new-title", Version: 1}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"slug", "version"}).AddRow("title", 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Update(context.Background(), article)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Update_SlugReclaimError(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	selectQuery := "SELECT `slug`,`version` FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
+	deleteQuery := "DELETE FROM `article_slugs` WHERE article_id = ? AND slug = ?"
+
+	article := &domain.Article{ID: 1, Title: "new title", Slug: "new-title", Version: 1}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"slug", "version"}).AddRow("title", 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Update(context.Background(), article)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Update_NotFound(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	selectQuery := "SELECT `slug`,`version` FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"slug", "version"}))
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Update(context.Background(), &domain.Article{ID: 1})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Update_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	selectQuery := "SELECT `slug`,`version` FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
+	query := "UPDATE `articles` SET `title`=?,`slug`=?,`content`=?,`content_format`=?,`rendered_content`=?,`render_key`=?,`excerpt`=?,`word_count`=?,`reading_time_minutes`=?,`author_id`=?,`version`=?,`updated_at`=? WHERE `articles`.`deleted_at` IS NULL AND `id` = ?"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"slug", "version"}).AddRow("title", 1))
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Update(context.Background(), &domain.Article{ID: 1, Title: "title", Slug: "title", Version: 1})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Update_VersionMismatch(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	selectQuery := "SELECT `slug`,`version` FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"slug", "version"}).AddRow("title", 3))
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	article := &domain.Article{ID: 1, Title: "title", Slug: "title", Version: 2}
+	err = repo.Update(context.Background(), article)
+	assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+	assert.Equal(t, uint(2), article.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Delete(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `deleted_at`=? WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
+
+	expectedID := uint(1)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(sqlmock.AnyArg(), expectedID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Delete(context.Background(), expectedID, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Delete_NotFound(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `deleted_at`=? WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
+
+	expectedID := uint(1)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(sqlmock.AnyArg(), expectedID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Delete(context.Background(), expectedID, 0)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestMysqlArticleRepository_Delete_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `deleted_at`=? WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
+
+	expectedID := uint(1)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(sqlmock.AnyArg(), expectedID).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Delete(context.Background(), expectedID, 0)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMysqlArticleRepository_Delete_WithVersion(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `deleted_at`=? WHERE version = ? AND `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(sqlmock.AnyArg(), 2, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Delete(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Delete_VersionMismatch(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	countQuery := "SELECT count(*) FROM `articles` WHERE id = ? AND `articles`.`deleted_at` IS NULL"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles` SET `deleted_at`=? WHERE version = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta(countQuery)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Delete(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Delete_WithVersionNotFound(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles` SET `deleted_at`=? WHERE version = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Delete(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestMysqlArticleRepository_Delete_CountError(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles` SET `deleted_at`=? WHERE version = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles`")).
+		WillReturnError(assert.AnError)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Delete(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMysqlArticleRepository_DeleteMany(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	lockQuery := "SELECT `id` FROM `articles` WHERE id IN (?,?,?) AND `articles`.`deleted_at` IS NULL FOR UPDATE"
+	query := "UPDATE `articles` SET `deleted_at`=? WHERE `articles`.`id` IN (?,?) AND `articles`.`deleted_at` IS NULL"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+		WithArgs(1, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(3))
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(sqlmock.AnyArg(), 1, 3).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	missing, err := repo.DeleteMany(context.Background(), []uint{1, 2, 3}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{2}, missing)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_DeleteMany_AtomicMissing(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	lockQuery := "SELECT `id` FROM `articles` WHERE id IN (?,?) AND `articles`.`deleted_at` IS NULL FOR UPDATE"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	missing, err := repo.DeleteMany(context.Background(), []uint{1, 2}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{2}, missing)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_DeleteMany_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	lockQuery := "SELECT `id` FROM `articles` WHERE id IN (?,?) AND `articles`.`deleted_at` IS NULL FOR UPDATE"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+		WithArgs(1, 2).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	_, err = repo.DeleteMany(context.Background(), []uint{1, 2}, true)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_ReassignMany(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	lockQuery := "SELECT `id` FROM `articles` WHERE id IN (?,?) AND `articles`.`deleted_at` IS NULL FOR UPDATE"
+	query := "UPDATE `articles` SET `author_id`=?,`version`=version + 1,`updated_at`=? WHERE id IN (?,?) AND `articles`.`deleted_at` IS NULL"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockQuery)).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(5, sqlmock.AnyArg(), 1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	missing, err := repo.ReassignMany(context.Background(), []uint{1, 2}, 5, true)
+	assert.NoError(t, err)
+	assert.Empty(t, missing)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_FetchTrash(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC LIMIT ? OFFSET ?"
+
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "created_at", "updated_at", "deleted_at"}).
+		AddRow(1, "title", "content", 1, time.Now(), time.Now(), time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(10, 10).
+		WillReturnRows(rows)
+	expectNoTags(mock)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	articles, nextCursor, err := repo.FetchTrash(context.Background(), 2, 10)
+	assert.NoError(t, err)
+	assert.Len(t, articles, 1)
+	assert.True(t, articles[0].DeletedAt.Valid)
+	assert.Equal(t, uint(3), nextCursor)
+}
+
+func TestMysqlArticleRepository_FetchTrash_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC LIMIT ?"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WillReturnError(assert.AnError)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	articles, nextCursor, err := repo.FetchTrash(context.Background(), 1, 10)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, articles)
+	assert.Equal(t, uint(0), nextCursor)
+}
+
+func TestMysqlArticleRepository_CountTrash(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT count(*) FROM `articles` WHERE deleted_at IS NOT NULL"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	count, err := repo.CountTrash(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestMysqlArticleRepository_CountTrash_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT count(*) FROM `articles` WHERE deleted_at IS NOT NULL"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WillReturnError(assert.AnError)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	count, err := repo.CountTrash(context.Background())
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestMysqlArticleRepository_Restore(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `deleted_at`=?,`updated_at`=? WHERE id = ? AND deleted_at IS NOT NULL"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(nil, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Restore(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Restore_NotFound(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `deleted_at`=?,`updated_at`=? WHERE id = ? AND deleted_at IS NOT NULL"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(nil, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Restore(context.Background(), 1)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestMysqlArticleRepository_Restore_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `deleted_at`=?,`updated_at`=? WHERE id = ? AND deleted_at IS NOT NULL"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(nil, sqlmock.AnyArg(), 1).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Restore(context.Background(), 1)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMysqlArticleRepository_DeletePermanent(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "DELETE FROM `articles` WHERE `articles`.`id` = ?"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_slugs` WHERE article_id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM article_tags WHERE article_id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_revisions` WHERE article_id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.DeletePermanent(context.Background(), 1, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_DeletePermanent_NotFound(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "DELETE FROM `articles` WHERE `articles`.`id` = ?"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_slugs` WHERE article_id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM article_tags WHERE article_id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_revisions` WHERE article_id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.DeletePermanent(context.Background(), 1, 0)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_DeletePermanent_VersionMismatch(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	for _, table := range []string{"`article_slugs`", "article_tags", "`article_revisions`"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE article_id = ?")).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `articles` WHERE version = ? AND `articles`.`id` = ?")).
+		WithArgs(2, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `articles` WHERE id = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.DeletePermanent(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_DeletePermanent_SlugError(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_slugs` WHERE article_id = ?")).
+		WithArgs(1).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.DeletePermanent(context.Background(), 1, 0)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_DeletePermanent_TagError(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_slugs` WHERE article_id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM article_tags WHERE article_id = ?")).
+		WithArgs(1).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.DeletePermanent(context.Background(), 1, 0)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_DeletePermanent_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "DELETE FROM `articles` WHERE `articles`.`id` = ?"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_slugs` WHERE article_id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM article_tags WHERE article_id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_revisions` WHERE article_id = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(1).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.DeletePermanent(context.Background(), 1, 0)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMysqlArticleRepository_Purge(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	idQuery := "SELECT `id` FROM `articles` WHERE deleted_at < ? FOR UPDATE"
+	deletedBefore := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(idQuery)).
+		WithArgs(deletedBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_slugs` WHERE article_id IN (?,?)")).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM article_tags WHERE article_id IN (?,?)")).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_revisions` WHERE article_id IN (?,?)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `articles` WHERE `articles`.`id` IN (?,?)")).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	ids, err := repo.Purge(context.Background(), deletedBefore)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1, 2}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Purge_Empty(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `articles` WHERE deleted_at < ? FOR UPDATE")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	ids, err := repo.Purge(context.Background(), time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Purge_SlugError(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `articles` WHERE deleted_at < ? FOR UPDATE")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_slugs` WHERE article_id IN (?)")).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	ids, err := repo.Purge(context.Background(), time.Now())
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Empty(t, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Purge_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `articles` WHERE deleted_at < ? FOR UPDATE")).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	ids, err := repo.Purge(context.Background(), time.Now())
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Empty(t, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_GetBySlug(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE (slug = ? OR id = (SELECT `article_id` FROM `article_slugs` WHERE slug = ?)) AND `articles`.`deleted_at` IS NULL ORDER BY `articles`.`id` LIMIT ?"
+
+	rows := sqlmock.NewRows([]string{"id", "title", "slug", "content", "author_id"}).
+		AddRow(1, "Title", "title", "content", 2)
+	authorRows := sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "author")
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("old-title", "old-title", 1).
+		WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `authors` WHERE `authors`.`id` = ?")).
+		WithArgs(2).
+		WillReturnRows(authorRows)
+	expectNoTags(mock)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	article, err := repo.GetBySlug(context.Background(), "old-title", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "title", article.Slug)
+	assert.Equal(t, "author", article.Author.Name)
+}
+
+func TestMysqlArticleRepository_GetBySlug_NotFound(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	article, err := repo.GetBySlug(context.Background(), "title", nil)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, article)
+}
+
+func TestMysqlArticleRepository_SlugOwner(t *testing.T) {
+	articleQuery := "SELECT `id` FROM `articles` WHERE slug = ? LIMIT ?"
+	historyQuery := "SELECT `article_id` FROM `article_slugs` WHERE slug = ? LIMIT ?"
+
+	t.Run("current", func(t *testing.T) {
+		db, mock, err := mockDBConnection()
+		assert.NoError(t, err)
+
+		mock.ExpectQuery(regexp.QuoteMeta(articleQuery)).
+			WithArgs("title", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+		owner, err := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db)).SlugOwner(context.Background(), "title")
+		assert.NoError(t, err)
+		assert.Equal(t, uint(3), owner)
+	})
+
+	t.Run("previous", func(t *testing.T) {
+		db, mock, err := mockDBConnection()
+		assert.NoError(t, err)
+
+		mock.ExpectQuery(regexp.QuoteMeta(articleQuery)).
+			WithArgs("title", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(historyQuery)).
+			WithArgs("title", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"article_id"}).AddRow(4))
+
+		owner, err := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db)).SlugOwner(context.Background(), "title")
+		assert.NoError(t, err)
+		assert.Equal(t, uint(4), owner)
+	})
+
+	t.Run("free", func(t *testing.T) {
+		db, mock, err := mockDBConnection()
+		assert.NoError(t, err)
+
+		mock.ExpectQuery(regexp.QuoteMeta(articleQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(historyQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"article_id"}))
+
+		owner, err := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db)).SlugOwner(context.Background(), "title")
+		assert.NoError(t, err)
+		assert.Equal(t, uint(0), owner)
+	})
+
+	t.Run("error-article", func(t *testing.T) {
+		db, mock, err := mockDBConnection()
+		assert.NoError(t, err)
+
+		mock.ExpectQuery(regexp.QuoteMeta(articleQuery)).
+			WillReturnError(assert.AnError)
+
+		_, err = NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db)).SlugOwner(context.Background(), "title")
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("error-history", func(t *testing.T) {
+		db, mock, err := mockDBConnection()
+		assert.NoError(t, err)
+
+		mock.ExpectQuery(regexp.QuoteMeta(articleQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(historyQuery)).
+			WillReturnError(assert.AnError)
+
+		_, err = NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db)).SlugOwner(context.Background(), "title")
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestMysqlArticleRepository_Count_WithStatus(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT count(*) FROM `articles` WHERE status = ? AND `articles`.`deleted_at` IS NULL"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(domain.ArticleStatusPublished).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	count, err := repo.Count(context.Background(), &domain.ArticleFilter{Status: domain.ArticleStatusPublished})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestMysqlArticleRepository_Fetch_WithTags(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE id IN (SELECT article_tags.article_id FROM `article_tags` JOIN tags ON tags.id = article_tags.tag_id WHERE tags.name IN (?,?)) AND `articles`.`deleted_at` IS NULL ORDER BY created_at DESC LIMIT ?"
+
+	rows := sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "title")
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("go", "web", 10).
+		WillReturnRows(rows)
+	expectNoTags(mock)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	articles, _, err := repo.Fetch(context.Background(), 1, 10, &domain.ArticleFilter{Tags: []string{"go", "web"}})
+	assert.NoError(t, err)
+	assert.Len(t, articles, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Count_WithAllTags(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT count(*) FROM `articles` WHERE id IN (SELECT article_tags.article_id FROM `article_tags` JOIN tags ON tags.id = article_tags.tag_id WHERE tags.name IN (?,?) GROUP BY `article_tags`.`article_id` HAVING COUNT(DISTINCT tags.id) = ?) AND `articles`.`deleted_at` IS NULL"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("go", "web", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	count, err := repo.Count(context.Background(), &domain.ArticleFilter{Tags: []string{"go", "web"}, AllTags: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestMysqlArticleRepository_UpdateStatus(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `published_at`=?,`status`=?,`version`=version + 1,`updated_at`=? WHERE status = ? AND `articles`.`deleted_at` IS NULL AND `id` = ?"
+	publishedAt := time.Now()
+	article := &domain.Article{ID: 1, Status: domain.ArticleStatusPublished, PublishedAt: &publishedAt}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(publishedAt, domain.ArticleStatusPublished, sqlmock.AnyArg(), domain.ArticleStatusReview, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.UpdateStatus(context.Background(), article, domain.ArticleStatusReview)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), article.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_UpdateStatus_Changed(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `published_at`=?,`status`=?,`version`=version + 1,`updated_at`=? WHERE status = ?"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.UpdateStatus(context.Background(), &domain.Article{ID: 1, Status: domain.ArticleStatusArchived}, domain.ArticleStatusPublished)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestMysqlArticleRepository_UpdateStatus_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `published_at`=?,`status`=?,`version`=version + 1,`updated_at`=? WHERE status = ?"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.UpdateStatus(context.Background(), &domain.Article{ID: 1, Status: domain.ArticleStatusArchived}, domain.ArticleStatusPublished)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMysqlArticleRepository_UpdateSchedule(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `publish_at`=?,`unpublish_at`=?,`version`=version + 1,`updated_at`=? WHERE `articles`.`deleted_at` IS NULL AND `id` = ?"
+	publishAt := time.Now()
+	article := &domain.Article{ID: 1, PublishAt: &publishAt}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(publishAt, nil, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.UpdateSchedule(context.Background(), article)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_UpdateSchedule_NotFound(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `publish_at`=?,`unpublish_at`=?,`version`=version + 1,`updated_at`=?"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.UpdateSchedule(context.Background(), &domain.Article{ID: 1})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestMysqlArticleRepository_UpdateSchedule_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `publish_at`=?,`unpublish_at`=?,`version`=version + 1,`updated_at`=?"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.UpdateSchedule(context.Background(), &domain.Article{ID: 1})
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMysqlArticleRepository_SaveRendered(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `render_key`=?,`rendered_content`=? WHERE `articles`.`deleted_at` IS NULL AND `id` = ?"
+	article := &domain.Article{ID: 1, RenderedContent: "<p>content</p>", RenderKey: "1-abc"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs("1-abc", "<p>content</p>", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.SaveRendered(context.Background(), article)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_SaveRendered_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles` SET `render_key`=?,`rendered_content`=?")).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.SaveRendered(context.Background(), &domain.Article{ID: 1})
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMysqlArticleRepository_AddViews(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE articles SET view_count = view_count + ? WHERE id = ?"
+	bucketQuery := "INSERT INTO `article_view_buckets` (`article_id`,`hour`,`views`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `views`=views + ?"
+	at := time.Date(2024, 5, 1, 10, 42, 0, 0, time.UTC)
+	hour := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(3, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(bucketQuery)).
+		WithArgs(1, hour, 3, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(5, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(bucketQuery)).
+		WithArgs(2, hour, 5, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.AddViews(context.Background(), map[uint]uint64{2: 5, 1: 3}, at)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_PublishDue(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `publish_at`=?,`published_at`=COALESCE(published_at, publish_at),`status`=?,`version`=version + 1,`updated_at`=? " +
+		"WHERE (status IN (?,?) AND publish_at <= ?) AND `articles`.`deleted_at` IS NULL"
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(nil, domain.ArticleStatusPublished, sqlmock.AnyArg(), domain.ArticleStatusDraft, domain.ArticleStatusReview, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	count, err := repo.PublishDue(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_PublishDue_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `articles`")).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	_, err = repo.PublishDue(context.Background(), time.Now())
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMysqlArticleRepository_UnpublishDue(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `articles` SET `status`=?,`unpublish_at`=?,`version`=version + 1,`updated_at`=? " +
+		"WHERE (status = ? AND unpublish_at <= ?) AND `articles`.`deleted_at` IS NULL"
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(domain.ArticleStatusDraft, nil, sqlmock.AnyArg(), domain.ArticleStatusPublished, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	count, err := repo.UnpublishDue(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_GetByAuthorID(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE author_id = ? AND `articles`.`deleted_at` IS NULL"
+
+	expectedAuthorID := uint(1)
+	expectedTitle := "title"
+	expectedContent := "content"
+	expectedCreatedAt := time.Now()
+	expectedUpdatedAt := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "created_at", "updated_at"}).
+		AddRow(1, expectedTitle, expectedContent, expectedAuthorID, expectedCreatedAt, expectedUpdatedAt)
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(expectedAuthorID).
+		WillReturnRows(rows)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	articles, err := repo.GetByAuthorID(context.Background(), expectedAuthorID)
+	assert.NoError(t, err)
+	assert.NotNil(t, articles)
+}
+
+func TestMysqlArticleRepository_GetByAuthorID_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE author_id = ? AND `articles`.`deleted_at` IS NULL"
+
+	expectedAuthorID := uint(1)
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(expectedAuthorID).
+		WillReturnError(assert.AnError)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	articles, err := repo.GetByAuthorID(context.Background(), expectedAuthorID)
+	assert.Error(t, err)
+	assert.Nil(t, articles)
+}
+
+func TestMysqlArticleRepository_GetByTitle(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT articles.*, MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AS relevance FROM `articles` WHERE MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AND `articles`.`deleted_at` IS NULL ORDER BY relevance DESC"
+
+	expectedTitle := "title"
+	expectedContent := "content"
+	expectedAuthorID := 1
+	expectedCreatedAt := time.Now()
+	expectedUpdatedAt := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "title", "content", "author_id", "created_at", "updated_at"}).
+		AddRow(1, expectedTitle, expectedContent, expectedAuthorID, expectedCreatedAt, expectedUpdatedAt)
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(expectedTitle, expectedTitle).
+		WillReturnRows(rows)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	articles, err := repo.GetByTitle(context.Background(), expectedTitle)
+	assert.NoError(t, err)
+	assert.NotNil(t, articles)
+}
+
+func TestMysqlArticleRepository_GetByTitle_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT articles.*, MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AS relevance FROM `articles` WHERE MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AND `articles`.`deleted_at` IS NULL ORDER BY relevance DESC"
+
+	expectedTitle := "title"
 
 	mock.ExpectQuery(regexp.QuoteMeta(query)).
-		WithArgs("%" + expectedTitle + "%").
+		WithArgs(expectedTitle).
 		WillReturnError(assert.AnError)
 
-	repo := NewMysqlArticleRepository(db)
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
 
-	articles, err := repo.GetByTitle(expectedTitle)
+	articles, err := repo.GetByTitle(context.Background(), expectedTitle)
 	assert.Error(t, err)
 	assert.Nil(t, articles)
 }
+
+func TestMysqlArticleRepository_Store_RecordsEditor(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	article := &domain.Article{Title: "title", Slug: "title", Content: "content", AuthorID: 1}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `articles`")).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(revision), 0) FROM `article_revisions` WHERE article_id = ?")).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `article_revisions`")).
+		WithArgs(4, 1, "title", "content", "jane", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Store(domain.ContextWithEditor(context.Background(), "jane"), article)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_Store_RevisionError(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	article := &domain.Article{Title: "title", Slug: "title", Content: "content", AuthorID: 1}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `articles`")).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(revision), 0) FROM `article_revisions`")).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	err = repo.Store(context.Background(), article)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleRepository_FetchRevisions(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `article_revisions` WHERE article_id = ? ORDER BY revision DESC LIMIT ?"
+
+	rows := sqlmock.NewRows([]string{"id", "article_id", "revision", "title", "content", "editor", "created_at"}).
+		AddRow(2, 1, 2, "title 2", "content", "jane", time.Now()).
+		AddRow(1, 1, 1, "title", "content", "", time.Now())
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1, 10).
+		WillReturnRows(rows)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	revisions, nextCursor, err := repo.FetchRevisions(context.Background(), 1, 1, 10)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, uint(2), revisions[0].Revision)
+	assert.Equal(t, "jane", revisions[0].Editor)
+	assert.Equal(t, uint(2), nextCursor)
+}
+
+func TestMysqlArticleRepository_FetchRevisions_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_revisions`")).
+		WillReturnError(assert.AnError)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	revisions, nextCursor, err := repo.FetchRevisions(context.Background(), 1, 1, 10)
+	assert.Error(t, err)
+	assert.Nil(t, revisions)
+	assert.Equal(t, uint(0), nextCursor)
+}
+
+func TestMysqlArticleRepository_CountRevisions(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT count(*) FROM `article_revisions` WHERE article_id = ?"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	count, err := repo.CountRevisions(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestMysqlArticleRepository_CountRevisions_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `article_revisions`")).
+		WillReturnError(assert.AnError)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	count, err := repo.CountRevisions(context.Background(), 1)
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestMysqlArticleRepository_GetRevision(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `article_revisions` WHERE article_id = ? AND revision = ? ORDER BY `article_revisions`.`id` LIMIT ?"
+
+	rows := sqlmock.NewRows([]string{"id", "article_id", "revision", "title", "content"}).
+		AddRow(5, 1, 2, "title", "content")
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1, 2, 1).
+		WillReturnRows(rows)
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	revision, err := repo.GetRevision(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), revision.Revision)
+	assert.Equal(t, "title", revision.Title)
+}
+
+func TestMysqlArticleRepository_GetRevision_NotFound(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `article_revisions`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := NewMysqlArticleRepository(db, search.NewMysqlArticleSearchIndex(db))
+
+	revision, err := repo.GetRevision(context.Background(), 1, 9)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, revision)
+}
@@ -0,0 +1,35 @@
+package article
+
+import (
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	mimeMergePatch = "application/merge-patch+json"
+	mimeJSONPatch  = "application/json-patch+json"
+)
+
+// applyPatch applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902)
+// to document. Malformed patches are reported as 400 and patches that cannot
+// be applied to the current document, e.g. a failing "test" operation, as 409.
+func applyPatch(mediaType string, document []byte, patch []byte) ([]byte, error) {
+	if mediaType == mimeMergePatch {
+		patched, err := jsonpatch.MergePatch(document, patch)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return patched, nil
+	}
+
+	operations, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	patched, err := operations.Apply(document)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, err.Error())
+	}
+	return patched, nil
+}
@@ -1,35 +1,100 @@
 package article
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/config"
 	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/internal/utilities"
+	"go-clean-architecture/pkg/xdiff"
+	"go-clean-architecture/pkg/xmarkup"
+	"go-clean-architecture/pkg/xsearch"
 	"gorm.io/gorm"
+	"slices"
+	"time"
+)
+
+// articleTransitions lists, for every status, the statuses an article may
+// move to next.
+var articleTransitions = map[domain.ArticleStatus][]domain.ArticleStatus{
+	domain.ArticleStatusDraft:     {domain.ArticleStatusReview, domain.ArticleStatusPublished, domain.ArticleStatusArchived},
+	domain.ArticleStatusReview:    {domain.ArticleStatusDraft, domain.ArticleStatusPublished, domain.ArticleStatusArchived},
+	domain.ArticleStatusPublished: {domain.ArticleStatusDraft, domain.ArticleStatusArchived},
+	domain.ArticleStatusArchived:  {domain.ArticleStatusDraft},
+}
+
+// snippetWords is the number of words of content shown around a search match.
+const snippetWords = 30
+
+const (
+	// excerptLength is the maximum number of characters of an excerpt.
+	excerptLength = 200
+	// wordsPerMinute is the reading speed the reading time is based on.
+	wordsPerMinute = 200
 )
 
 type articleService struct {
 	articleRepo domain.ArticleRepository
 	authorRepo  domain.AuthorRepository
+	tagRepo     domain.TagRepository
+	cfg         config.Config
+	renderer    *xmarkup.Renderer
+	views       *viewBuffer
+	// deleteHooks are called with the articles deleted permanently.
+	deleteHooks []domain.ArticleDeleteHook
 }
 
-func NewArticleService(article domain.ArticleRepository, author domain.AuthorRepository) domain.ArticleService {
+func NewArticleService(article domain.ArticleRepository, author domain.AuthorRepository, tag domain.TagRepository, cfg config.Config, deleteHooks ...domain.ArticleDeleteHook) domain.ArticleService {
+	allowlist := cfg.Article.HTMLAllowlist
+	if allowlist == nil {
+		allowlist = xmarkup.DefaultAllowlist
+	}
+
 	return &articleService{
 		articleRepo: article,
 		authorRepo:  author,
+		tagRepo:     tag,
+		cfg:         cfg,
+		renderer:    xmarkup.NewRenderer(xmarkup.NewSanitizer(allowlist)),
+		views:       newViewBuffer(cfg.Views.Window, cfg.Views.MaxVisitors),
+		deleteHooks: deleteHooks,
 	}
 }
 
-func (a *articleService) Fetch(page uint, size uint, filter *domain.Article) ([]*domain.Article, uint, error) {
-	articles, nextCursor, err := a.articleRepo.Fetch(page, size, filter)
+func (a *articleService) Fetch(ctx context.Context, page uint, size uint, filter *domain.ArticleFilter) ([]*domain.Article, uint, error) {
+	articles, nextCursor, err := a.articleRepo.Fetch(ctx, page, size, filter)
 	if err != nil {
 		return nil, 0, err
 	}
 
+	if err := a.ensureHTML(ctx, filter.View, articles...); err != nil {
+		return nil, 0, err
+	}
+	highlight(articles, filter.Query)
+	return articles, nextCursor, nil
+}
+
+func (a *articleService) FetchByCursor(ctx context.Context, cursor *domain.ArticleCursor, size uint, filter *domain.ArticleFilter) ([]*domain.Article, *domain.ArticleCursor, error) {
+	articles, nextCursor, err := a.articleRepo.FetchByCursor(ctx, cursor, size, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := a.ensureHTML(ctx, filter.View, articles...); err != nil {
+		return nil, nil, err
+	}
+	highlight(articles, filter.Query)
 	return articles, nextCursor, nil
 }
 
-func (a *articleService) GetByID(id uint) (*domain.Article, error) {
-	article, err := a.articleRepo.GetByID(id)
+func (a *articleService) Export(ctx context.Context, filter *domain.ArticleFilter, fn func(articles []*domain.Article) error) error {
+	return a.articleRepo.Export(ctx, filter, fn)
+}
+
+func (a *articleService) GetByID(ctx context.Context, id uint, view *domain.ArticleView) (*domain.Article, error) {
+	article, err := a.articleRepo.GetByID(ctx, id, view)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fiber.ErrNotFound
@@ -37,49 +102,655 @@ func (a *articleService) GetByID(id uint) (*domain.Article, error) {
 		return nil, err
 	}
 
+	if err := a.ensureHTML(ctx, view, article); err != nil {
+		return nil, err
+	}
 	return article, nil
 }
 
-func (a *articleService) Count(filter *domain.Article) (int64, error) {
-	count, err := a.articleRepo.Count(filter)
+func (a *articleService) GetBySlug(ctx context.Context, slug string, view *domain.ArticleView) (*domain.Article, error) {
+	article, err := a.articleRepo.GetBySlug(ctx, slug, view)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if err := a.ensureHTML(ctx, view, article); err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+// ensureHTML sets the rendered content of articles when view asks for it.
+// Content rendered by other renderer settings is rendered again and saved,
+// so that it is only rendered once after the settings change.
+func (a *articleService) ensureHTML(ctx context.Context, view *domain.ArticleView, articles ...*domain.Article) error {
+	if view == nil || !view.HTML {
+		return nil
+	}
+
+	for _, article := range articles {
+		if article.RenderKey != a.renderer.Key() {
+			if err := a.render(article); err != nil {
+				return err
+			}
+			if err := a.articleRepo.SaveRendered(ctx, article); err != nil {
+				return err
+			}
+		}
+		article.ContentHTML = article.RenderedContent
+	}
+	return nil
+}
+
+// render renders the content of article in its format, which defaults to
+// plain text.
+func (a *articleService) render(article *domain.Article) error {
+	switch article.ContentFormat {
+	case domain.ContentFormatMarkdown:
+		html, err := a.renderer.Markdown(article.Content)
+		if err != nil {
+			return err
+		}
+		article.RenderedContent = html
+	case domain.ContentFormatHTML:
+		article.RenderedContent = a.renderer.HTML(article.Content)
+	default:
+		article.ContentFormat = domain.ContentFormatPlain
+		article.RenderedContent = a.renderer.Plain(article.Content)
+	}
+	article.RenderKey = a.renderer.Key()
+	return nil
+}
+
+func (a *articleService) Count(ctx context.Context, filter *domain.ArticleFilter) (int64, error) {
+	count, err := a.articleRepo.Count(ctx, filter)
 	return count, err
 }
 
-func (a *articleService) GetByTitle(title string) ([]*domain.Article, error) {
-	articles, err := a.articleRepo.GetByTitle(title)
+func (a *articleService) GetByTitle(ctx context.Context, title string) ([]*domain.Article, error) {
+	articles, err := a.articleRepo.GetByTitle(ctx, title)
 	if err != nil {
 		return nil, err
 	}
 
+	highlight(articles, title)
 	return articles, nil
 }
 
-func (a *articleService) Store(article *domain.Article) error {
-	author, err := a.authorRepo.GetByID(article.AuthorID)
+func (a *articleService) Store(ctx context.Context, article *domain.Article) error {
+	author, err := a.author(ctx, article.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.prepareStore(ctx, article, author, nil); err != nil {
+		return err
+	}
+	return a.articleRepo.Store(ctx, article)
+}
+
+func (a *articleService) StoreBulk(ctx context.Context, articles []*domain.Article, atomic bool) ([]domain.BulkResult, error) {
+	results := make([]domain.BulkResult, len(articles))
+	for i := range results {
+		results[i].Index = i
+	}
+
+	// articles often share authors, so each author is looked up once
+	type lookup struct {
+		author *domain.Author
+		err    error
+	}
+	authors := make(map[uint]lookup)
+	prepare := func(article *domain.Article, reserved map[string]bool) error {
+		found, ok := authors[article.AuthorID]
+		if !ok {
+			found.author, found.err = a.author(ctx, article.AuthorID)
+			authors[article.AuthorID] = found
+		}
+		if found.err != nil {
+			return found.err
+		}
+		return a.prepareStore(ctx, article, found.author, reserved)
+	}
+
+	if !atomic {
+		for i, article := range articles {
+			err := prepare(article, nil)
+			if err == nil {
+				err = a.articleRepo.Store(ctx, article)
+			}
+			if err != nil {
+				results[i] = bulkResult(i, 0, err, fiber.StatusCreated)
+				continue
+			}
+			results[i] = bulkResult(i, article.ID, nil, fiber.StatusCreated)
+		}
+		return results, nil
+	}
+
+	// the articles are not stored yet, so their slugs have to be kept apart
+	// by hand
+	reserved := make(map[string]bool, len(articles))
+	failed := false
+	for i, article := range articles {
+		if err := prepare(article, reserved); err != nil {
+			results[i] = bulkResult(i, 0, err, fiber.StatusCreated)
+			failed = true
+			continue
+		}
+		reserved[article.Slug] = true
+	}
+	if failed {
+		domain.SkipPending(results)
+		return results, nil
+	}
+
+	if err := a.articleRepo.StoreMany(ctx, articles); err != nil {
+		var itemErr *domain.BulkItemError
+		if !errors.As(err, &itemErr) {
+			return nil, err
+		}
+		results[itemErr.Index] = bulkResult(itemErr.Index, 0, itemErr.Err, fiber.StatusCreated)
+		domain.SkipPending(results)
+		return results, nil
+	}
+
+	for i, article := range articles {
+		results[i] = bulkResult(i, article.ID, nil, fiber.StatusCreated)
+	}
+	return results, nil
+}
+
+// author returns the author of an article, or a not found error that tells
+// it apart from a missing article.
+func (a *articleService) author(ctx context.Context, id uint) (*domain.Author, error) {
+	author, err := a.authorRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return fiber.ErrNotFound
+			return nil, fiber.NewError(fiber.StatusNotFound, "author not found")
 		}
+		return nil, err
+	}
+	return author, nil
+}
+
+// prepareStore fills in the fields of a new article that are not sent by
+// clients. The slug is unique among the stored articles and is not one of
+// reserved.
+func (a *articleService) prepareStore(ctx context.Context, article *domain.Article, author *domain.Author, reserved map[string]bool) error {
+	slug, err := a.uniqueSlug(ctx, article.Title, 0, reserved)
+	if err != nil {
 		return err
 	}
 
+	if err := a.resolveTags(ctx, article); err != nil {
+		return err
+	}
+
+	if err := a.render(article); err != nil {
+		return err
+	}
+	describe(article)
+
 	article.Author = author
-	return a.articleRepo.Store(article)
+	article.Slug = slug
+	article.Status = domain.ArticleStatusDraft
+	article.Version = 1
+	return nil
+}
+
+func (a *articleService) Update(ctx context.Context, article *domain.Article) error {
+	current, err := a.GetByID(ctx, article.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.author(ctx, article.AuthorID); err != nil {
+		return err
+	}
+
+	// without an expected version the update is still guarded against
+	// writes made since current was read
+	if article.Version == 0 {
+		article.Version = current.Version
+	}
+
+	article.Slug = current.Slug
+	if a.cfg.Article.RegenerateSlug && article.Title != current.Title {
+		if article.Slug, err = a.uniqueSlug(ctx, article.Title, article.ID, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := a.resolveTags(ctx, article); err != nil {
+		return err
+	}
+
+	if article.ContentFormat == "" {
+		article.ContentFormat = current.ContentFormat
+	}
+	if err := a.render(article); err != nil {
+		return err
+	}
+	describe(article)
+
+	if err := a.articleRepo.Update(ctx, article); err != nil {
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return fiber.NewError(fiber.StatusPreconditionFailed, "article was changed by another request")
+		}
+		return err
+	}
+
+	stored, err := a.GetByID(ctx, article.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	*article = *stored
+	return nil
+}
+
+// Delete moves an article to the trash. A non-zero version must match the
+// stored one.
+func (a *articleService) Delete(ctx context.Context, id uint, version uint) error {
+	if err := a.articleRepo.Delete(ctx, id, version); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.ErrNotFound
+		}
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return fiber.NewError(fiber.StatusPreconditionFailed, "article was changed by another request")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (a *articleService) DeleteBulk(ctx context.Context, ids []uint, atomic bool) ([]domain.BulkResult, error) {
+	missing, err := a.articleRepo.DeleteMany(ctx, ids, atomic)
+	if err != nil {
+		return nil, err
+	}
+	return bulkResults(ids, missing, atomic), nil
 }
 
-func (a *articleService) Update(article *domain.Article) error {
-	return a.articleRepo.Update(article)
+func (a *articleService) ReassignBulk(ctx context.Context, ids []uint, authorID uint, atomic bool) ([]domain.BulkResult, error) {
+	if _, err := a.author(ctx, authorID); err != nil {
+		return nil, err
+	}
+
+	missing, err := a.articleRepo.ReassignMany(ctx, ids, authorID, atomic)
+	if err != nil {
+		return nil, err
+	}
+	return bulkResults(ids, missing, atomic), nil
+}
+
+// bulkResults reports the IDs of a bulk change that were missing as not
+// found, and the others as changed or, when an atomic change was not applied,
+// as skipped.
+func bulkResults(ids []uint, missing []uint, atomic bool) []domain.BulkResult {
+	notFound := make(map[uint]bool, len(missing))
+	for _, id := range missing {
+		notFound[id] = true
+	}
+
+	results := make([]domain.BulkResult, len(ids))
+	for i, id := range ids {
+		switch {
+		case notFound[id]:
+			results[i] = bulkResult(i, id, fiber.ErrNotFound, fiber.StatusOK)
+		case atomic && len(missing) > 0:
+			results[i] = domain.BulkResult{Index: i, ID: id}
+		default:
+			results[i] = bulkResult(i, id, nil, fiber.StatusOK)
+		}
+	}
+	domain.SkipPending(results)
+	return results
 }
 
-func (a *articleService) Delete(id uint) error {
-	return a.articleRepo.Delete(id)
+// bulkResult reports the outcome of one item of a bulk request. Errors other
+// than *fiber.Error are not shown to clients.
+func bulkResult(index int, id uint, err error, success int) domain.BulkResult {
+	result := domain.BulkResult{Index: index, ID: id, Status: success}
+	if err != nil {
+		result.Status = fiber.StatusInternalServerError
+		result.Message = fiber.ErrInternalServerError.Message
+
+		var e *fiber.Error
+		if errors.As(err, &e) {
+			result.Status = e.Code
+			result.Message = e.Message
+		}
+	}
+	return result
 }
 
-func (a *articleService) GetByAuthorID(authorID uint) ([]*domain.Article, error) {
-	articles, err := a.articleRepo.GetByAuthorID(authorID)
+func (a *articleService) FetchTrash(ctx context.Context, page uint, size uint) ([]*domain.Article, uint, error) {
+	articles, nextCursor, err := a.articleRepo.FetchTrash(ctx, page, size)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return articles, nextCursor, nil
+}
+
+func (a *articleService) CountTrash(ctx context.Context) (int64, error) {
+	count, err := a.articleRepo.CountTrash(ctx)
+	return count, err
+}
+
+func (a *articleService) Restore(ctx context.Context, id uint) (*domain.Article, error) {
+	if err := a.articleRepo.Restore(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return a.GetByID(ctx, id, nil)
+}
+
+func (a *articleService) DeletePermanent(ctx context.Context, id uint, version uint) error {
+	if err := a.articleRepo.DeletePermanent(ctx, id, version); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.ErrNotFound
+		}
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return fiber.NewError(fiber.StatusPreconditionFailed, "article was changed by another request")
+		}
+		return err
+	}
+
+	return a.deleted(ctx, []uint{id})
+}
+
+// PurgeTrash permanently deletes articles that have been in the trash for
+// longer than retention and returns how many were removed.
+func (a *articleService) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	ids, err := a.articleRepo.Purge(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	return int64(len(ids)), a.deleted(ctx, ids)
+}
+
+// deleted calls the delete hooks with the IDs of articles deleted
+// permanently. The articles are gone by then, so a failed hook is reported
+// but does not stop the others.
+func (a *articleService) deleted(ctx context.Context, ids []uint) error {
+	var errs []error
+	for _, hook := range a.deleteHooks {
+		if err := hook(ctx, ids); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (a *articleService) GetByAuthorID(ctx context.Context, authorID uint) ([]*domain.Article, error) {
+	articles, err := a.articleRepo.GetByAuthorID(ctx, authorID)
 	if err != nil {
 		return nil, err
 	}
 
 	return articles, nil
 }
+
+func (a *articleService) Transition(ctx context.Context, id uint, status domain.ArticleStatus) (*domain.Article, error) {
+	article, err := a.GetByID(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	from := article.Status
+	if !slices.Contains(articleTransitions[from], status) {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("cannot change status from %s to %s", from, status))
+	}
+
+	article.Status = status
+	if status == domain.ArticleStatusPublished && article.PublishedAt == nil {
+		now := time.Now()
+		article.PublishedAt = &now
+	}
+
+	if err := a.articleRepo.UpdateStatus(ctx, article, from); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusConflict, "article was changed by another request")
+		}
+		return nil, err
+	}
+
+	return article, nil
+}
+
+func (a *articleService) Schedule(ctx context.Context, id uint, publishAt *time.Time, unpublishAt *time.Time) (*domain.Article, error) {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "unpublishAt must be after publishAt")
+	}
+
+	article, err := a.GetByID(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	article.PublishAt = publishAt
+	article.UnpublishAt = unpublishAt
+	if err := a.articleRepo.UpdateSchedule(ctx, article); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return article, nil
+}
+
+// RunSchedule publishes and unpublishes the articles that are due at now and
+// returns how many of each were changed.
+func (a *articleService) RunSchedule(ctx context.Context, now time.Time) (int64, int64, error) {
+	published, err := a.articleRepo.PublishDue(ctx, now)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	unpublished, err := a.articleRepo.UnpublishDue(ctx, now)
+	if err != nil {
+		return published, 0, err
+	}
+
+	return published, unpublished, nil
+}
+
+func (a *articleService) RecordView(ctx context.Context, id uint, visitor string) error {
+	article, err := a.articleRepo.GetByID(ctx, id, &domain.ArticleView{Columns: []string{"id", "status"}})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.ErrNotFound
+		}
+		return err
+	}
+	if article.Status != domain.ArticleStatusPublished {
+		return fiber.NewError(fiber.StatusConflict, "views are only recorded for published articles")
+	}
+
+	a.views.add(id, visitor, time.Now())
+	return nil
+}
+
+// FlushViews writes the views recorded since the last flush. Views that
+// cannot be written are kept for the next flush.
+func (a *articleService) FlushViews(ctx context.Context) (int64, error) {
+	now := time.Now()
+	views := a.views.take(now)
+	if len(views) == 0 {
+		return 0, nil
+	}
+
+	if err := a.articleRepo.AddViews(ctx, views, now); err != nil {
+		a.views.restore(views)
+		return 0, err
+	}
+
+	var count int64
+	for _, n := range views {
+		count += int64(n)
+	}
+	return count, nil
+}
+
+func (a *articleService) FetchRevisions(ctx context.Context, articleID uint, page uint, size uint) ([]*domain.ArticleRevision, uint, error) {
+	if _, err := a.GetByID(ctx, articleID, nil); err != nil {
+		return nil, 0, err
+	}
+
+	revisions, nextCursor, err := a.articleRepo.FetchRevisions(ctx, articleID, page, size)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return revisions, nextCursor, nil
+}
+
+func (a *articleService) CountRevisions(ctx context.Context, articleID uint) (int64, error) {
+	count, err := a.articleRepo.CountRevisions(ctx, articleID)
+	return count, err
+}
+
+func (a *articleService) GetRevision(ctx context.Context, articleID uint, revision uint) (*domain.ArticleRevision, error) {
+	articleRevision, err := a.articleRepo.GetRevision(ctx, articleID, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return articleRevision, nil
+}
+
+// DiffRevisions compares the title and content of two revisions of an
+// article word by word.
+func (a *articleService) DiffRevisions(ctx context.Context, articleID uint, from uint, to uint) (*domain.ArticleRevisionDiff, error) {
+	fromRevision, err := a.GetRevision(ctx, articleID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toRevision, err := a.GetRevision(ctx, articleID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ArticleRevisionDiff{
+		ArticleID: articleID,
+		From:      from,
+		To:        to,
+		Title:     xdiff.Words(fromRevision.Title, toRevision.Title),
+		Content:   xdiff.Words(fromRevision.Content, toRevision.Content),
+	}, nil
+}
+
+// Revert restores the title and content of an article from one of its
+// revisions. The revert is an update of its own, so it is recorded as a new
+// revision and the history stays linear.
+func (a *articleService) Revert(ctx context.Context, articleID uint, revision uint) (*domain.Article, error) {
+	articleRevision, err := a.GetRevision(ctx, articleID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := a.GetByID(ctx, articleID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	article := &domain.Article{
+		ID:       articleID,
+		Title:    articleRevision.Title,
+		Content:  articleRevision.Content,
+		AuthorID: current.AuthorID,
+	}
+	if err := a.Update(ctx, article); err != nil {
+		return nil, err
+	}
+
+	return article, nil
+}
+
+// uniqueSlug derives a slug from title that is not used, now or in the past,
+// by any article other than articleID. Taken slugs get a numeric suffix.
+func (a *articleService) uniqueSlug(ctx context.Context, title string, articleID uint, reserved map[string]bool) (string, error) {
+	base := utilities.Slugify(title)
+	if base == "" {
+		base = "article"
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		owner, err := a.articleRepo.SlugOwner(ctx, slug)
+		if err != nil {
+			return "", err
+		}
+		if (owner == 0 || owner == articleID) && !reserved[slug] {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// resolveTags replaces the tags of article, which only carry a name, with the
+// stored tags, creating the ones that do not exist yet. A nil Tags is left
+// untouched so that an update keeps the current tags.
+func (a *articleService) resolveTags(ctx context.Context, article *domain.Article) error {
+	if article.Tags == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(article.Tags))
+	for _, tag := range article.Tags {
+		names = append(names, tag.Name)
+	}
+
+	tags, err := a.tagRepo.FirstOrCreate(ctx, utilities.NormalizeTags(names))
+	if err != nil {
+		return err
+	}
+
+	article.Tags = make([]*domain.Tag, 0, len(tags))
+	article.Tags = append(article.Tags, tags...)
+	return nil
+}
+
+// describe sets the excerpt, word count and reading time of a rendered
+// article from the text of its content, so that Markdown and HTML markup is
+// not counted.
+func describe(article *domain.Article) {
+	text := xmarkup.Text(article.RenderedContent)
+	article.Excerpt = xmarkup.Excerpt(text, excerptLength)
+	article.WordCount = uint(xmarkup.CountWords(text))
+	article.ReadingTimeMinutes = (article.WordCount + wordsPerMinute - 1) / wordsPerMinute
+}
+
+// highlight sets the parts of articles that match query, when there is one.
+func highlight(articles []*domain.Article, query string) {
+	terms := xsearch.Terms(query)
+	if len(terms) == 0 {
+		return
+	}
+
+	for _, article := range articles {
+		article.Highlight = &domain.ArticleHighlight{
+			Title:   xsearch.Snippet(article.Title, terms, 0),
+			Content: xsearch.Snippet(article.Content, terms, snippetWords),
+		}
+	}
+}
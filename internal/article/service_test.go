@@ -1,11 +1,19 @@
 package article
 
 import (
+	"context"
+	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/config"
 	"go-clean-architecture/internal/domain"
 	"go-clean-architecture/mocks"
+	"go-clean-architecture/pkg/xdiff"
+	"go-clean-architecture/pkg/xmarkup"
 	"gorm.io/gorm"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestArticleService_Fetch(t *testing.T) {
@@ -18,11 +26,11 @@ func TestArticleService_Fetch(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		mockArticleRepository.On("Fetch", uint(1), uint(10), &domain.Article{}).
+		mockArticleRepository.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{}).
 			Return(mocksArticleList, uint(2), nil).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		articles, nextCursor, err := articleSvc.Fetch(uint(1), uint(10), &domain.Article{})
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		articles, nextCursor, err := articleSvc.Fetch(context.Background(), uint(1), uint(10), &domain.ArticleFilter{})
 		assert.NoError(t, err)
 		assert.NotNil(t, articles)
 		assert.Equal(t, uint(2), nextCursor)
@@ -31,11 +39,11 @@ func TestArticleService_Fetch(t *testing.T) {
 	})
 
 	t.Run("success-zero-size", func(t *testing.T) {
-		mockArticleRepository.On("Fetch", uint(1), uint(10), &domain.Article{}).
+		mockArticleRepository.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{}).
 			Return(mocksArticleList, uint(2), nil).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		articles, nextCursor, err := articleSvc.Fetch(uint(1), uint(10), &domain.Article{})
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		articles, nextCursor, err := articleSvc.Fetch(context.Background(), uint(1), uint(10), &domain.ArticleFilter{})
 		assert.NoError(t, err)
 		assert.NotNil(t, articles)
 		assert.Equal(t, uint(2), nextCursor)
@@ -43,18 +51,92 @@ func TestArticleService_Fetch(t *testing.T) {
 		mockArticleRepository.AssertExpectations(t)
 	})
 
+	t.Run("success-query", func(t *testing.T) {
+		filter := &domain.ArticleFilter{Query: "content"}
+		found := []*domain.Article{{ID: 1, Title: "Title 1", Content: "Some <b>content</b> here"}}
+		mockArticleRepository.On("Fetch", mock.Anything, uint(1), uint(10), filter).
+			Return(found, uint(2), nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		articles, _, err := articleSvc.Fetch(context.Background(), uint(1), uint(10), filter)
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.ArticleHighlight{
+			Title:   "Title 1",
+			Content: "Some &lt;b&gt;<mark>content</mark>&lt;/b&gt; here",
+		}, articles[0].Highlight)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
 	t.Run("error-failed", func(t *testing.T) {
-		mockArticleRepository.On("Fetch", uint(1), uint(10), &domain.Article{}).
+		mockArticleRepository.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{}).
 			Return(nil, uint(0), assert.AnError).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		articles, nextCursor, err := articleSvc.Fetch(uint(1), uint(10), &domain.Article{})
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		articles, nextCursor, err := articleSvc.Fetch(context.Background(), uint(1), uint(10), &domain.ArticleFilter{})
 		assert.Error(t, err)
 		assert.Nil(t, articles)
 		assert.Equal(t, uint(0), nextCursor)
 	})
 }
 
+func TestArticleService_FetchByCursor(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mocksArticleList := []*domain.Article{{ID: 1, Title: "Title 1"}}
+	cursor := &domain.ArticleCursor{CreatedAt: time.Now(), ID: 2}
+	nextCursor := &domain.ArticleCursor{CreatedAt: time.Now(), ID: 1}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("FetchByCursor", mock.Anything, cursor, uint(10), &domain.ArticleFilter{}).
+			Return(mocksArticleList, nextCursor, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		articles, next, err := articleSvc.FetchByCursor(context.Background(), cursor, uint(10), &domain.ArticleFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, mocksArticleList, articles)
+		assert.Equal(t, nextCursor, next)
+		assert.Nil(t, articles[0].Highlight)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("FetchByCursor", mock.Anything, cursor, uint(10), &domain.ArticleFilter{}).
+			Return(nil, (*domain.ArticleCursor)(nil), assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		articles, next, err := articleSvc.FetchByCursor(context.Background(), cursor, uint(10), &domain.ArticleFilter{})
+		assert.Error(t, err)
+		assert.Nil(t, articles)
+		assert.Nil(t, next)
+	})
+}
+
+func TestArticleService_Export(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	filter := &domain.ArticleFilter{Status: domain.ArticleStatusPublished}
+
+	mockArticleRepository.On("Export", mock.Anything, filter, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func([]*domain.Article) error)
+			assert.NoError(t, fn([]*domain.Article{{ID: 1}}))
+		}).
+		Return(nil).Once()
+
+	var ids []uint
+	articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+	err := articleSvc.Export(context.Background(), filter, func(articles []*domain.Article) error {
+		for _, article := range articles {
+			ids = append(ids, article.ID)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1}, ids)
+
+	mockArticleRepository.AssertExpectations(t)
+}
+
 func TestArticleService_GetByID(t *testing.T) {
 	mockArticleRepository := new(mocks.ArticleRepository)
 	mockArticle := &domain.Article{
@@ -64,11 +146,11 @@ func TestArticleService_GetByID(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockArticleRepository.On("GetByID", uint(1)).
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
 			Return(mockArticle, nil).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		article, err := articleSvc.GetByID(uint(1))
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.GetByID(context.Background(), uint(1), nil)
 		assert.NoError(t, err)
 		assert.NotNil(t, article)
 
@@ -76,35 +158,103 @@ func TestArticleService_GetByID(t *testing.T) {
 	})
 
 	t.Run("error-not-found", func(t *testing.T) {
-		mockArticleRepository.On("GetByID", uint(1)).
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
 			Return(nil, gorm.ErrRecordNotFound).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		article, err := articleSvc.GetByID(uint(1))
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.GetByID(context.Background(), uint(1), nil)
 		assert.Error(t, err)
 		assert.Nil(t, article)
 	})
 
 	t.Run("error-failed", func(t *testing.T) {
-		mockArticleRepository.On("GetByID", uint(1)).
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
 			Return(nil, assert.AnError).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		article, err := articleSvc.GetByID(uint(1))
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.GetByID(context.Background(), uint(1), nil)
 		assert.Error(t, err)
 		assert.Nil(t, article)
 	})
 }
 
+func TestArticleService_GetByID_HTML(t *testing.T) {
+	view := &domain.ArticleView{HTML: true}
+	articleSvc := NewArticleService(nil, nil, nil, config.Config{})
+	key := articleSvc.(*articleService).renderer.Key()
+
+	t.Run("success-cached", func(t *testing.T) {
+		mockArticleRepository := new(mocks.ArticleRepository)
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), view).
+			Return(&domain.Article{ID: 1, Content: "# Title", ContentFormat: domain.ContentFormatMarkdown, RenderedContent: "<h1>cached</h1>", RenderKey: key}, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.GetByID(context.Background(), uint(1), view)
+		assert.NoError(t, err)
+		assert.Equal(t, "<h1>cached</h1>", article.ContentHTML)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-stale", func(t *testing.T) {
+		mockArticleRepository := new(mocks.ArticleRepository)
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), view).
+			Return(&domain.Article{ID: 1, Content: "# Title", ContentFormat: domain.ContentFormatMarkdown, RenderedContent: "<h1>old</h1>", RenderKey: "0-old"}, nil).Once()
+		mockArticleRepository.On("SaveRendered", mock.Anything, mock.MatchedBy(func(article *domain.Article) bool {
+			return article.RenderedContent == "<h1>Title</h1>\n" && article.RenderKey == key
+		})).Return(nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.GetByID(context.Background(), uint(1), view)
+		assert.NoError(t, err)
+		assert.Equal(t, "<h1>Title</h1>\n", article.ContentHTML)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-allowlist", func(t *testing.T) {
+		cfg := config.Config{}
+		cfg.Article.HTMLAllowlist = xmarkup.Allowlist{"p": nil}
+		mockArticleRepository := new(mocks.ArticleRepository)
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), view).
+			Return(&domain.Article{ID: 1, Content: "<p>Hi <b>there</b></p>", ContentFormat: domain.ContentFormatHTML}, nil).Once()
+		mockArticleRepository.On("SaveRendered", mock.Anything, mock.Anything).
+			Return(nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, cfg)
+		article, err := articleSvc.GetByID(context.Background(), uint(1), view)
+		assert.NoError(t, err)
+		assert.Equal(t, "<p>Hi there</p>", article.ContentHTML)
+		assert.NotEqual(t, key, article.RenderKey)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-save-failed", func(t *testing.T) {
+		mockArticleRepository := new(mocks.ArticleRepository)
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), view).
+			Return(&domain.Article{ID: 1, Content: "text"}, nil).Once()
+		mockArticleRepository.On("SaveRendered", mock.Anything, mock.Anything).
+			Return(assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.GetByID(context.Background(), uint(1), view)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
 func TestArticleService_Count(t *testing.T) {
 	mockArticleRepository := new(mocks.ArticleRepository)
 
 	t.Run("success", func(t *testing.T) {
-		mockArticleRepository.On("Count", &domain.Article{}).
+		mockArticleRepository.On("Count", mock.Anything, &domain.ArticleFilter{}).
 			Return(int64(10), nil).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		count, err := articleSvc.Count(&domain.Article{})
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		count, err := articleSvc.Count(context.Background(), &domain.ArticleFilter{})
 		assert.NoError(t, err)
 		assert.Equal(t, int64(10), count)
 
@@ -112,11 +262,11 @@ func TestArticleService_Count(t *testing.T) {
 	})
 
 	t.Run("error-failed", func(t *testing.T) {
-		mockArticleRepository.On("Count", &domain.Article{}).
+		mockArticleRepository.On("Count", mock.Anything, &domain.ArticleFilter{}).
 			Return(int64(0), assert.AnError).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		count, err := articleSvc.Count(&domain.Article{})
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		count, err := articleSvc.Count(context.Background(), &domain.ArticleFilter{})
 		assert.Error(t, err)
 		assert.Equal(t, int64(0), count)
 	})
@@ -132,23 +282,23 @@ func TestArticleService_GetByTitle(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		mockArticleRepository.On("GetByTitle", "Title").
+		mockArticleRepository.On("GetByTitle", mock.Anything, "Title").
 			Return(mocksArticleList, nil).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		articles, err := articleSvc.GetByTitle("Title")
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		articles, err := articleSvc.GetByTitle(context.Background(), "Title")
 		assert.NoError(t, err)
-		assert.NotNil(t, articles)
+		assert.Equal(t, "<mark>Title</mark> 1", articles[0].Highlight.Title)
 
 		mockArticleRepository.AssertExpectations(t)
 	})
 
 	t.Run("error-failed", func(t *testing.T) {
-		mockArticleRepository.On("GetByTitle", "Title").
+		mockArticleRepository.On("GetByTitle", mock.Anything, "Title").
 			Return(nil, assert.AnError).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		articles, err := articleSvc.GetByTitle("Title")
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		articles, err := articleSvc.GetByTitle(context.Background(), "Title")
 		assert.Error(t, err)
 		assert.Nil(t, articles)
 	})
@@ -165,25 +315,114 @@ func TestArticleService_Store(t *testing.T) {
 	}
 
 	t.Run("success", func(t *testing.T) {
-		mockAuthorRepository.On("GetByID", uint(1)).
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "title-1").
+			Return(uint(0), nil).Once()
+		mockArticleRepository.On("Store", mock.Anything, mockArticle).
+			Return(nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Store(context.Background(), mockArticle)
+		assert.NoError(t, err)
+		assert.Equal(t, "title-1", mockArticle.Slug)
+		assert.Equal(t, domain.ArticleStatusDraft, mockArticle.Status)
+		assert.Equal(t, domain.ContentFormatPlain, mockArticle.ContentFormat)
+		assert.Equal(t, "<p>Content 1</p>\n", mockArticle.RenderedContent)
+		assert.Equal(t, "Content 1", mockArticle.Excerpt)
+		assert.Equal(t, uint(2), mockArticle.WordCount)
+		assert.Equal(t, uint(1), mockArticle.ReadingTimeMinutes)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-markdown", func(t *testing.T) {
+		article := &domain.Article{
+			Title:         "Title 1",
+			Content:       "# Heading\n\nSome **bold** [link](https://example.com) " + strings.Repeat("word ", 400),
+			ContentFormat: domain.ContentFormatMarkdown,
+			AuthorID:      1,
+		}
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "title-1").
+			Return(uint(0), nil).Once()
+		mockArticleRepository.On("Store", mock.Anything, article).
+			Return(nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Store(context.Background(), article)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(article.Excerpt, "Heading Some bold link word word"))
+		assert.True(t, strings.HasSuffix(article.Excerpt, "word…"))
+		assert.Equal(t, uint(404), article.WordCount)
+		assert.Equal(t, uint(3), article.ReadingTimeMinutes)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-slug-taken", func(t *testing.T) {
+		article := &domain.Article{Title: "Title 1", AuthorID: 1}
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "title-1").
+			Return(uint(7), nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "title-1-2").
+			Return(uint(8), nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "title-1-3").
+			Return(uint(0), nil).Once()
+		mockArticleRepository.On("Store", mock.Anything, article).
+			Return(nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Store(context.Background(), article)
+		assert.NoError(t, err)
+		assert.Equal(t, "title-1-3", article.Slug)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-title-without-slug", func(t *testing.T) {
+		article := &domain.Article{Title: "日本語", AuthorID: 1}
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
 			Return(&domain.Author{}, nil).Once()
-		mockArticleRepository.On("Store", mockArticle).
+		mockArticleRepository.On("SlugOwner", mock.Anything, "article").
+			Return(uint(0), nil).Once()
+		mockArticleRepository.On("Store", mock.Anything, article).
 			Return(nil).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository)
-		err := articleSvc.Store(mockArticle)
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Store(context.Background(), article)
 		assert.NoError(t, err)
+		assert.Equal(t, "article", article.Slug)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-slug-owner", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "title-1").
+			Return(uint(0), assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Store(context.Background(), mockArticle)
+		assert.ErrorIs(t, err, assert.AnError)
 
 		mockArticleRepository.AssertExpectations(t)
 		mockAuthorRepository.AssertExpectations(t)
 	})
 
 	t.Run("error-author-not-found", func(t *testing.T) {
-		mockAuthorRepository.On("GetByID", uint(1)).
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
 			Return(nil, gorm.ErrRecordNotFound).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository)
-		err := articleSvc.Store(mockArticle)
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Store(context.Background(), mockArticle)
 		assert.Error(t, err)
 
 		mockArticleRepository.AssertExpectations(t)
@@ -191,11 +430,11 @@ func TestArticleService_Store(t *testing.T) {
 	})
 
 	t.Run("error-author-failed", func(t *testing.T) {
-		mockAuthorRepository.On("GetByID", uint(1)).
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
 			Return(nil, assert.AnError).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository)
-		err := articleSvc.Store(mockArticle)
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Store(context.Background(), mockArticle)
 		assert.Error(t, err)
 
 		mockArticleRepository.AssertExpectations(t)
@@ -203,13 +442,15 @@ func TestArticleService_Store(t *testing.T) {
 	})
 
 	t.Run("error-failed", func(t *testing.T) {
-		mockAuthorRepository.On("GetByID", uint(1)).
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
 			Return(&domain.Author{}, nil).Once()
-		mockArticleRepository.On("Store", mockArticle).
+		mockArticleRepository.On("SlugOwner", mock.Anything, "title-1").
+			Return(uint(0), nil).Once()
+		mockArticleRepository.On("Store", mock.Anything, mockArticle).
 			Return(assert.AnError).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository)
-		err := articleSvc.Store(mockArticle)
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Store(context.Background(), mockArticle)
 		assert.Error(t, err)
 
 		mockArticleRepository.AssertExpectations(t)
@@ -217,91 +458,1416 @@ func TestArticleService_Store(t *testing.T) {
 	})
 }
 
-func TestArticleService_Update(t *testing.T) {
+func TestArticleService_Store_Tags(t *testing.T) {
 	mockArticleRepository := new(mocks.ArticleRepository)
-	mockArticle := &domain.Article{
-		ID:      1,
-		Title:   "Title 1",
-		Content: "Content 1",
-	}
+	mockAuthorRepository := new(mocks.AuthorRepository)
+	mockTagRepository := new(mocks.TagRepository)
 
 	t.Run("success", func(t *testing.T) {
-		mockArticleRepository.On("Update", mockArticle).
+		article := &domain.Article{Title: "Title", AuthorID: 1, Tags: []*domain.Tag{{Name: " Go"}, {Name: "web"}, {Name: "go"}}}
+		tags := []*domain.Tag{{ID: 3, Name: "go"}, {ID: 4, Name: "web"}}
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "title").
+			Return(uint(0), nil).Once()
+		mockTagRepository.On("FirstOrCreate", mock.Anything, []string{"go", "web"}).
+			Return(tags, nil).Once()
+		mockArticleRepository.On("Store", mock.Anything, article).
 			Return(nil).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		err := articleSvc.Update(mockArticle)
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, mockTagRepository, config.Config{})
+		err := articleSvc.Store(context.Background(), article)
 		assert.NoError(t, err)
+		assert.Equal(t, tags, article.Tags)
 
 		mockArticleRepository.AssertExpectations(t)
+		mockTagRepository.AssertExpectations(t)
 	})
 
-	t.Run("error-failed", func(t *testing.T) {
-		mockArticleRepository.On("Update", mockArticle).
+	t.Run("error", func(t *testing.T) {
+		article := &domain.Article{Title: "Title", AuthorID: 1, Tags: []*domain.Tag{{Name: "go"}}}
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "title").
+			Return(uint(0), nil).Once()
+		mockTagRepository.On("FirstOrCreate", mock.Anything, []string{"go"}).
+			Return(nil, assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, mockTagRepository, config.Config{})
+		err := articleSvc.Store(context.Background(), article)
+		assert.ErrorIs(t, err, assert.AnError)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockTagRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_StoreBulk(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mockAuthorRepository := new(mocks.AuthorRepository)
+
+	t.Run("success-atomic", func(t *testing.T) {
+		articles := []*domain.Article{{Title: "Title", AuthorID: 1}, {Title: "Title", AuthorID: 1}}
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "title").
+			Return(uint(0), nil).Twice()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "title-2").
+			Return(uint(0), nil).Once()
+		mockArticleRepository.On("StoreMany", mock.Anything, articles).
+			Run(func(args mock.Arguments) {
+				for i, article := range args.Get(1).([]*domain.Article) {
+					article.ID = uint(i + 1)
+				}
+			}).
+			Return(nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		results, err := articleSvc.StoreBulk(context.Background(), articles, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BulkResult{
+			{Index: 0, ID: 1, Status: 201},
+			{Index: 1, ID: 2, Status: 201},
+		}, results)
+		assert.Equal(t, "title", articles[0].Slug)
+		assert.Equal(t, "title-2", articles[1].Slug)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-atomic-author-not-found", func(t *testing.T) {
+		articles := []*domain.Article{{Title: "Title", AuthorID: 1}, {Title: "Other", AuthorID: 2}}
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "title").
+			Return(uint(0), nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		results, err := articleSvc.StoreBulk(context.Background(), articles, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BulkResult{
+			{Index: 0, Status: 424, Message: domain.BulkSkippedMessage},
+			{Index: 1, Status: 404, Message: "author not found"},
+		}, results)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-atomic-item", func(t *testing.T) {
+		articles := []*domain.Article{{Title: "First", AuthorID: 1}, {Title: "Second", AuthorID: 1}}
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "first").
+			Return(uint(0), nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "second").
+			Return(uint(0), nil).Once()
+		mockArticleRepository.On("StoreMany", mock.Anything, articles).
+			Return(&domain.BulkItemError{Index: 1, Err: assert.AnError}).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		results, err := articleSvc.StoreBulk(context.Background(), articles, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BulkResult{
+			{Index: 0, Status: 424, Message: domain.BulkSkippedMessage},
+			{Index: 1, Status: 500, Message: "Internal Server Error"},
+		}, results)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-atomic", func(t *testing.T) {
+		articles := []*domain.Article{{Title: "First", AuthorID: 1}}
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "first").
+			Return(uint(0), nil).Once()
+		mockArticleRepository.On("StoreMany", mock.Anything, articles).
 			Return(assert.AnError).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		err := articleSvc.Update(mockArticle)
-		assert.Error(t, err)
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		_, err := articleSvc.StoreBulk(context.Background(), articles, true)
+		assert.ErrorIs(t, err, assert.AnError)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-best-effort", func(t *testing.T) {
+		first := &domain.Article{Title: "First", AuthorID: 1}
+		second := &domain.Article{Title: "Second", AuthorID: 2}
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "first").
+			Return(uint(0), nil).Once()
+		mockArticleRepository.On("Store", mock.Anything, first).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(*domain.Article).ID = 7
+			}).
+			Return(nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		results, err := articleSvc.StoreBulk(context.Background(), []*domain.Article{first, second}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BulkResult{
+			{Index: 0, ID: 7, Status: 201},
+			{Index: 1, Status: 404, Message: "author not found"},
+		}, results)
 
 		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
 	})
 }
 
-func TestArticleService_Delete(t *testing.T) {
+func TestArticleService_Update(t *testing.T) {
 	mockArticleRepository := new(mocks.ArticleRepository)
+	mockAuthorRepository := new(mocks.AuthorRepository)
+	newArticle := func() *domain.Article {
+		return &domain.Article{
+			ID:       1,
+			Title:    "Title 1",
+			Content:  "Content 1",
+			AuthorID: 2,
+		}
+	}
 
 	t.Run("success", func(t *testing.T) {
-		mockArticleRepository.On("Delete", uint(1)).
+		mockArticle := newArticle()
+		stored := &domain.Article{ID: 1, Title: "Title 1", Content: "Content 1", AuthorID: 2, Author: &domain.Author{ID: 2}}
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockArticleRepository.On("Update", mock.Anything, mockArticle).
 			Return(nil).Once()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(stored, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Update(context.Background(), mockArticle)
+		assert.NoError(t, err)
+		assert.Equal(t, stored, mockArticle)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-keeps-content-format", func(t *testing.T) {
+		mockArticle := newArticle()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, ContentFormat: domain.ContentFormatMarkdown}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockArticleRepository.On("Update", mock.Anything, mock.MatchedBy(func(article *domain.Article) bool {
+			return article.ContentFormat == domain.ContentFormatMarkdown && article.RenderedContent == "<p>Content 1</p>\n"
+		})).Return(nil).Once()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1}, nil).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		err := articleSvc.Delete(uint(1))
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Update(context.Background(), mockArticle)
 		assert.NoError(t, err)
 
 		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-article-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Update(context.Background(), newArticle())
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-author-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Update(context.Background(), newArticle())
+		assert.Equal(t, fiber.NewError(fiber.StatusNotFound, "author not found"), err)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-author", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(nil, assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Update(context.Background(), newArticle())
+		assert.ErrorIs(t, err, assert.AnError)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
 	})
 
 	t.Run("error-failed", func(t *testing.T) {
-		mockArticleRepository.On("Delete", uint(1)).
+		mockArticle := newArticle()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockArticleRepository.On("Update", mock.Anything, mockArticle).
 			Return(assert.AnError).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		err := articleSvc.Delete(uint(1))
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Update(context.Background(), mockArticle)
 		assert.Error(t, err)
 
 		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-reload", func(t *testing.T) {
+		mockArticle := newArticle()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockArticleRepository.On("Update", mock.Anything, mockArticle).
+			Return(nil).Once()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(nil, assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Update(context.Background(), mockArticle)
+		assert.ErrorIs(t, err, assert.AnError)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-current-version", func(t *testing.T) {
+		mockArticle := newArticle()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Version: 4}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockArticleRepository.On("Update", mock.Anything, mock.MatchedBy(func(article *domain.Article) bool {
+			return article.Version == 4
+		})).Return(nil).Once()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Version: 5}, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Update(context.Background(), mockArticle)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(5), mockArticle.Version)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-version-mismatch", func(t *testing.T) {
+		mockArticle := newArticle()
+		mockArticle.Version = 2
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Version: 3}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockArticleRepository.On("Update", mock.Anything, mockArticle).
+			Return(domain.ErrVersionMismatch).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Update(context.Background(), mockArticle)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusPreconditionFailed, fiberErr.Code)
+		assert.Equal(t, uint(2), mockArticle.Version)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-regenerate-slug", func(t *testing.T) {
+		mockArticle := newArticle()
+		mockArticle.Title = "New Title"
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Title: "Title 1", Slug: "title-1"}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "new-title").
+			Return(uint(1), nil).Once()
+		mockArticleRepository.On("Update", mock.Anything, mock.MatchedBy(func(article *domain.Article) bool {
+			return article.Slug == "new-title"
+		})).Return(nil).Once()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Title: "New Title", Slug: "new-title"}, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{Article: config.Article{RegenerateSlug: true}})
+		err := articleSvc.Update(context.Background(), mockArticle)
+		assert.NoError(t, err)
+		assert.Equal(t, "new-title", mockArticle.Slug)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-keep-slug", func(t *testing.T) {
+		mockArticle := newArticle()
+		mockArticle.Title = "New Title"
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Title: "Title 1", Slug: "title-1"}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockArticleRepository.On("Update", mock.Anything, mock.MatchedBy(func(article *domain.Article) bool {
+			return article.Slug == "title-1"
+		})).Return(nil).Once()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Title: "New Title", Slug: "title-1"}, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		err := articleSvc.Update(context.Background(), mockArticle)
+		assert.NoError(t, err)
+		assert.Equal(t, "title-1", mockArticle.Slug)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-regenerate-slug", func(t *testing.T) {
+		mockArticle := newArticle()
+		mockArticle.Title = "New Title"
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Title: "Title 1", Slug: "title-1"}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockArticleRepository.On("SlugOwner", mock.Anything, "new-title").
+			Return(uint(0), assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{Article: config.Article{RegenerateSlug: true}})
+		err := articleSvc.Update(context.Background(), mockArticle)
+		assert.ErrorIs(t, err, assert.AnError)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
 	})
 }
 
-func TestArticleService_GetByAuthorID(t *testing.T) {
+func TestArticleService_Transition(t *testing.T) {
 	mockArticleRepository := new(mocks.ArticleRepository)
-	mocksArticleList := make([]*domain.Article, 0)
-	mocksArticleList = append(mocksArticleList, &domain.Article{
-		ID:      1,
-		Title:   "Title 1",
-		Content: "Content 1",
+
+	t.Run("success-publish", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusReview}, nil).Once()
+		mockArticleRepository.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(article *domain.Article) bool {
+			return article.Status == domain.ArticleStatusPublished && article.PublishedAt != nil
+		}), domain.ArticleStatusReview).Return(nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Transition(context.Background(), 1, domain.ArticleStatusPublished)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ArticleStatusPublished, article.Status)
+		assert.NotNil(t, article.PublishedAt)
+
+		mockArticleRepository.AssertExpectations(t)
 	})
 
-	t.Run("success", func(t *testing.T) {
-		mockArticleRepository.On("GetByAuthorID", uint(1)).
-			Return(mocksArticleList, nil).Once()
+	t.Run("success-republish-keeps-published-at", func(t *testing.T) {
+		publishedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusDraft, PublishedAt: &publishedAt}, nil).Once()
+		mockArticleRepository.On("UpdateStatus", mock.Anything, mock.Anything, domain.ArticleStatusDraft).
+			Return(nil).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		articles, err := articleSvc.GetByAuthorID(uint(1))
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Transition(context.Background(), 1, domain.ArticleStatusPublished)
 		assert.NoError(t, err)
-		assert.NotNil(t, articles)
+		assert.Equal(t, publishedAt, *article.PublishedAt)
 
 		mockArticleRepository.AssertExpectations(t)
 	})
 
-	t.Run("error-failed", func(t *testing.T) {
-		mockArticleRepository.On("GetByAuthorID", uint(1)).
-			Return(nil, assert.AnError).Once()
+	t.Run("success-archive", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusPublished}, nil).Once()
+		mockArticleRepository.On("UpdateStatus", mock.Anything, mock.Anything, domain.ArticleStatusPublished).
+			Return(nil).Once()
 
-		articleSvc := NewArticleService(mockArticleRepository, nil)
-		articles, err := articleSvc.GetByAuthorID(uint(1))
-		assert.Error(t, err)
-		assert.Nil(t, articles)
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Transition(context.Background(), 1, domain.ArticleStatusArchived)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ArticleStatusArchived, article.Status)
+		assert.Nil(t, article.PublishedAt)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-invalid-transition", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusArchived}, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Transition(context.Background(), 1, domain.ArticleStatusPublished)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusConflict, fiberErr.Code)
+		assert.Equal(t, "cannot change status from archived to published", fiberErr.Message)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Transition(context.Background(), 1, domain.ArticleStatusPublished)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-concurrent-change", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusDraft}, nil).Once()
+		mockArticleRepository.On("UpdateStatus", mock.Anything, mock.Anything, domain.ArticleStatusDraft).
+			Return(gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Transition(context.Background(), 1, domain.ArticleStatusReview)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusConflict, fiberErr.Code)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusDraft}, nil).Once()
+		mockArticleRepository.On("UpdateStatus", mock.Anything, mock.Anything, domain.ArticleStatusDraft).
+			Return(assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Transition(context.Background(), 1, domain.ArticleStatusReview)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_Schedule(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	publishAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	unpublishAt := publishAt.Add(24 * time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1}, nil).Once()
+		mockArticleRepository.On("UpdateSchedule", mock.Anything, &domain.Article{ID: 1, PublishAt: &publishAt, UnpublishAt: &unpublishAt}).
+			Return(nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Schedule(context.Background(), 1, &publishAt, &unpublishAt)
+		assert.NoError(t, err)
+		assert.Equal(t, &publishAt, article.PublishAt)
+		assert.Equal(t, &unpublishAt, article.UnpublishAt)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-order", func(t *testing.T) {
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Schedule(context.Background(), 1, &unpublishAt, &publishAt)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusBadRequest, fiberErr.Code)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Schedule(context.Background(), 1, &publishAt, nil)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-deleted-meanwhile", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1}, nil).Once()
+		mockArticleRepository.On("UpdateSchedule", mock.Anything, mock.Anything).
+			Return(gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Schedule(context.Background(), 1, nil, nil)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1}, nil).Once()
+		mockArticleRepository.On("UpdateSchedule", mock.Anything, mock.Anything).
+			Return(assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Schedule(context.Background(), 1, nil, nil)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_RecordView(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	view := &domain.ArticleView{Columns: []string{"id", "status"}}
+	cfg := config.Config{Views: config.Views{Window: time.Hour, MaxVisitors: 10}}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), view).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusPublished}, nil).Times(3)
+		mockArticleRepository.On("AddViews", mock.Anything, map[uint]uint64{1: 2}, mock.Anything).
+			Return(nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, cfg)
+		assert.NoError(t, articleSvc.RecordView(context.Background(), 1, "10.0.0.1 Firefox"))
+		// the same visitor is counted once
+		assert.NoError(t, articleSvc.RecordView(context.Background(), 1, "10.0.0.1 Firefox"))
+		assert.NoError(t, articleSvc.RecordView(context.Background(), 1, "10.0.0.2 Firefox"))
+
+		count, err := articleSvc.FlushViews(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		// nothing is left to write
+		count, err = articleSvc.FlushViews(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-published", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), view).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusDraft}, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, cfg)
+		err := articleSvc.RecordView(context.Background(), 1, "10.0.0.1 Firefox")
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusConflict, fiberErr.Code)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(2), view).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, cfg)
+		err := articleSvc.RecordView(context.Background(), 2, "10.0.0.1 Firefox")
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_FlushViews_Error(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	cfg := config.Config{Views: config.Views{Window: time.Hour, MaxVisitors: 10}}
+
+	mockArticleRepository.On("GetByID", mock.Anything, uint(1), mock.Anything).
+		Return(&domain.Article{ID: 1, Status: domain.ArticleStatusPublished}, nil).Twice()
+	mockArticleRepository.On("AddViews", mock.Anything, map[uint]uint64{1: 1}, mock.Anything).
+		Return(assert.AnError).Once()
+	mockArticleRepository.On("AddViews", mock.Anything, map[uint]uint64{1: 2}, mock.Anything).
+		Return(nil).Once()
+
+	articleSvc := NewArticleService(mockArticleRepository, nil, nil, cfg)
+	assert.NoError(t, articleSvc.RecordView(context.Background(), 1, "10.0.0.1 Firefox"))
+	_, err := articleSvc.FlushViews(context.Background())
+	assert.ErrorIs(t, err, assert.AnError)
+
+	// the views that failed are written with the next ones
+	assert.NoError(t, articleSvc.RecordView(context.Background(), 1, "10.0.0.2 Firefox"))
+	count, err := articleSvc.FlushViews(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	mockArticleRepository.AssertExpectations(t)
+}
+
+func TestArticleService_RunSchedule(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("PublishDue", mock.Anything, now).
+			Return(int64(2), nil).Once()
+		mockArticleRepository.On("UnpublishDue", mock.Anything, now).
+			Return(int64(1), nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		published, unpublished, err := articleSvc.RunSchedule(context.Background(), now)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), published)
+		assert.Equal(t, int64(1), unpublished)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-publish", func(t *testing.T) {
+		mockArticleRepository.On("PublishDue", mock.Anything, now).
+			Return(int64(0), assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		_, _, err := articleSvc.RunSchedule(context.Background(), now)
+		assert.ErrorIs(t, err, assert.AnError)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-unpublish", func(t *testing.T) {
+		mockArticleRepository.On("PublishDue", mock.Anything, now).
+			Return(int64(3), nil).Once()
+		mockArticleRepository.On("UnpublishDue", mock.Anything, now).
+			Return(int64(0), assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		published, _, err := articleSvc.RunSchedule(context.Background(), now)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(3), published)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_GetBySlug(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mockArticle := &domain.Article{ID: 1, Title: "Title 1", Slug: "title-1"}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("GetBySlug", mock.Anything, "title-1", (*domain.ArticleView)(nil)).
+			Return(mockArticle, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.GetBySlug(context.Background(), "title-1", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, mockArticle, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetBySlug", mock.Anything, "title-1", (*domain.ArticleView)(nil)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.GetBySlug(context.Background(), "title-1", nil)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("GetBySlug", mock.Anything, "title-1", (*domain.ArticleView)(nil)).
+			Return(nil, assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.GetBySlug(context.Background(), "title-1", nil)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_Update_Tags(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mockAuthorRepository := new(mocks.AuthorRepository)
+	mockTagRepository := new(mocks.TagRepository)
+
+	t.Run("success-clear", func(t *testing.T) {
+		article := &domain.Article{ID: 1, Title: "Title", AuthorID: 2, Tags: []*domain.Tag{}}
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Title: "Title", Slug: "title"}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockTagRepository.On("FirstOrCreate", mock.Anything, []string(nil)).
+			Return(nil, nil).Once()
+		mockArticleRepository.On("Update", mock.Anything, mock.MatchedBy(func(article *domain.Article) bool {
+			return article.Tags != nil && len(article.Tags) == 0
+		})).Return(nil).Once()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Title: "Title", Slug: "title"}, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, mockTagRepository, config.Config{})
+		err := articleSvc.Update(context.Background(), article)
+		assert.NoError(t, err)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockTagRepository.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		article := &domain.Article{ID: 1, Title: "Title", AuthorID: 2, Tags: []*domain.Tag{{Name: "go"}}}
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1, Title: "Title", Slug: "title"}, nil).Once()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockTagRepository.On("FirstOrCreate", mock.Anything, []string{"go"}).
+			Return(nil, assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, mockTagRepository, config.Config{})
+		err := articleSvc.Update(context.Background(), article)
+		assert.ErrorIs(t, err, assert.AnError)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockTagRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_Delete(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("Delete", mock.Anything, uint(1), uint(0)).
+			Return(nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		err := articleSvc.Delete(context.Background(), uint(1), 0)
+		assert.NoError(t, err)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockArticleRepository.On("Delete", mock.Anything, uint(1), uint(0)).
+			Return(gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		err := articleSvc.Delete(context.Background(), uint(1), 0)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("Delete", mock.Anything, uint(1), uint(0)).
+			Return(assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		err := articleSvc.Delete(context.Background(), uint(1), 0)
+		assert.Error(t, err)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-version-mismatch", func(t *testing.T) {
+		mockArticleRepository.On("Delete", mock.Anything, uint(1), uint(2)).
+			Return(domain.ErrVersionMismatch).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		err := articleSvc.Delete(context.Background(), uint(1), 2)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusPreconditionFailed, fiberErr.Code)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_DeleteBulk(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("DeleteMany", mock.Anything, []uint{1, 2}, true).
+			Return([]uint(nil), nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		results, err := articleSvc.DeleteBulk(context.Background(), []uint{1, 2}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BulkResult{
+			{Index: 0, ID: 1, Status: 200},
+			{Index: 1, ID: 2, Status: 200},
+		}, results)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("missing-atomic", func(t *testing.T) {
+		mockArticleRepository.On("DeleteMany", mock.Anything, []uint{1, 2}, true).
+			Return([]uint{2}, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		results, err := articleSvc.DeleteBulk(context.Background(), []uint{1, 2}, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BulkResult{
+			{Index: 0, ID: 1, Status: 424, Message: domain.BulkSkippedMessage},
+			{Index: 1, ID: 2, Status: 404, Message: "Not Found"},
+		}, results)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("missing-best-effort", func(t *testing.T) {
+		mockArticleRepository.On("DeleteMany", mock.Anything, []uint{1, 2}, false).
+			Return([]uint{2}, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		results, err := articleSvc.DeleteBulk(context.Background(), []uint{1, 2}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BulkResult{
+			{Index: 0, ID: 1, Status: 200},
+			{Index: 1, ID: 2, Status: 404, Message: "Not Found"},
+		}, results)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockArticleRepository.On("DeleteMany", mock.Anything, []uint{1}, true).
+			Return(nil, assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		_, err := articleSvc.DeleteBulk(context.Background(), []uint{1}, true)
+		assert.ErrorIs(t, err, assert.AnError)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_ReassignBulk(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mockAuthorRepository := new(mocks.AuthorRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(5)).
+			Return(&domain.Author{}, nil).Once()
+		mockArticleRepository.On("ReassignMany", mock.Anything, []uint{1, 2}, uint(5), true).
+			Return([]uint(nil), nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		results, err := articleSvc.ReassignBulk(context.Background(), []uint{1, 2}, 5, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.BulkResult{
+			{Index: 0, ID: 1, Status: 200},
+			{Index: 1, ID: 2, Status: 200},
+		}, results)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("author-not-found", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(5)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		_, err := articleSvc.ReassignBulk(context.Background(), []uint{1}, 5, true)
+		assert.Equal(t, fiber.NewError(fiber.StatusNotFound, "author not found"), err)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_FetchTrash(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mockArticles := []*domain.Article{{ID: 1, Title: "Title 1"}}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("FetchTrash", mock.Anything, uint(1), uint(10)).
+			Return(mockArticles, uint(2), nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		articles, nextCursor, err := articleSvc.FetchTrash(context.Background(), 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, mockArticles, articles)
+		assert.Equal(t, uint(2), nextCursor)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("FetchTrash", mock.Anything, uint(1), uint(10)).
+			Return(nil, uint(0), assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		articles, nextCursor, err := articleSvc.FetchTrash(context.Background(), 1, 10)
+		assert.Error(t, err)
+		assert.Nil(t, articles)
+		assert.Equal(t, uint(0), nextCursor)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_CountTrash(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mockArticleRepository.On("CountTrash", mock.Anything).
+		Return(int64(4), nil).Once()
+
+	articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+	count, err := articleSvc.CountTrash(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+
+	mockArticleRepository.AssertExpectations(t)
+}
+
+func TestArticleService_Restore(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mockArticle := &domain.Article{ID: 1, Title: "Title 1"}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("Restore", mock.Anything, uint(1)).
+			Return(nil).Once()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(mockArticle, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Restore(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, mockArticle, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockArticleRepository.On("Restore", mock.Anything, uint(1)).
+			Return(gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Restore(context.Background(), 1)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("Restore", mock.Anything, uint(1)).
+			Return(assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		article, err := articleSvc.Restore(context.Background(), 1)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_DeletePermanent(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("DeletePermanent", mock.Anything, uint(1), uint(0)).
+			Return(nil).Once()
+
+		var deleted []uint
+		hook := func(_ context.Context, ids []uint) error {
+			deleted = ids
+			return nil
+		}
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{}, hook)
+		err := articleSvc.DeletePermanent(context.Background(), 1, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{1}, deleted)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockArticleRepository.On("DeletePermanent", mock.Anything, uint(1), uint(0)).
+			Return(gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		err := articleSvc.DeletePermanent(context.Background(), 1, 0)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-version-mismatch", func(t *testing.T) {
+		mockArticleRepository.On("DeletePermanent", mock.Anything, uint(1), uint(2)).
+			Return(domain.ErrVersionMismatch).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		err := articleSvc.DeletePermanent(context.Background(), 1, 2)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusPreconditionFailed, fiberErr.Code)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("DeletePermanent", mock.Anything, uint(1), uint(0)).
+			Return(assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		err := articleSvc.DeletePermanent(context.Background(), 1, 0)
+		assert.ErrorIs(t, err, assert.AnError)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_PurgeTrash(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	retention := 24 * time.Hour
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("Purge", mock.Anything, mock.MatchedBy(func(deletedBefore time.Time) bool {
+			return time.Since(deletedBefore) >= retention && time.Since(deletedBefore) < retention+time.Minute
+		})).Return([]uint{1, 2, 3}, nil).Once()
+
+		var deleted []uint
+		hook := func(_ context.Context, ids []uint) error {
+			deleted = ids
+			return nil
+		}
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{}, hook)
+		count, err := articleSvc.PurgeTrash(context.Background(), retention)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.Equal(t, []uint{1, 2, 3}, deleted)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-empty", func(t *testing.T) {
+		mockArticleRepository.On("Purge", mock.Anything, mock.Anything).
+			Return(nil, nil).Once()
+
+		hook := func(context.Context, []uint) error {
+			t.Error("hook called without purged articles")
+			return nil
+		}
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{}, hook)
+		count, err := articleSvc.PurgeTrash(context.Background(), retention)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-hook", func(t *testing.T) {
+		mockArticleRepository.On("Purge", mock.Anything, mock.Anything).
+			Return([]uint{1}, nil).Once()
+
+		// the hooks after a failed one are still called
+		called := false
+		failing := func(context.Context, []uint) error { return assert.AnError }
+		hook := func(context.Context, []uint) error {
+			called = true
+			return nil
+		}
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{}, failing, hook)
+		count, err := articleSvc.PurgeTrash(context.Background(), retention)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(1), count)
+		assert.True(t, called)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("Purge", mock.Anything, mock.Anything).
+			Return(nil, assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		_, err := articleSvc.PurgeTrash(context.Background(), retention)
+		assert.ErrorIs(t, err, assert.AnError)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_GetByAuthorID(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mocksArticleList := make([]*domain.Article, 0)
+	mocksArticleList = append(mocksArticleList, &domain.Article{
+		ID:      1,
+		Title:   "Title 1",
+		Content: "Content 1",
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("GetByAuthorID", mock.Anything, uint(1)).
+			Return(mocksArticleList, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		articles, err := articleSvc.GetByAuthorID(context.Background(), uint(1))
+		assert.NoError(t, err)
+		assert.NotNil(t, articles)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("GetByAuthorID", mock.Anything, uint(1)).
+			Return(nil, assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		articles, err := articleSvc.GetByAuthorID(context.Background(), uint(1))
+		assert.Error(t, err)
+		assert.Nil(t, articles)
+	})
+}
+
+func TestArticleService_FetchRevisions(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mockRevisions := []*domain.ArticleRevision{{ID: 1, ArticleID: 1, Revision: 1}}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1}, nil).Once()
+		mockArticleRepository.On("FetchRevisions", mock.Anything, uint(1), uint(1), uint(10)).
+			Return(mockRevisions, uint(2), nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		revisions, nextCursor, err := articleSvc.FetchRevisions(context.Background(), 1, 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, mockRevisions, revisions)
+		assert.Equal(t, uint(2), nextCursor)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-article-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		revisions, _, err := articleSvc.FetchRevisions(context.Background(), 1, 1, 10)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, revisions)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(&domain.Article{ID: 1}, nil).Once()
+		mockArticleRepository.On("FetchRevisions", mock.Anything, uint(1), uint(1), uint(10)).
+			Return(nil, uint(0), assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		revisions, _, err := articleSvc.FetchRevisions(context.Background(), 1, 1, 10)
+		assert.Error(t, err)
+		assert.Nil(t, revisions)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_CountRevisions(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mockArticleRepository.On("CountRevisions", mock.Anything, uint(1)).
+		Return(int64(3), nil).Once()
+
+	articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+	count, err := articleSvc.CountRevisions(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	mockArticleRepository.AssertExpectations(t)
+}
+
+func TestArticleService_GetRevision(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockRevision := &domain.ArticleRevision{ArticleID: 1, Revision: 2}
+		mockArticleRepository.On("GetRevision", mock.Anything, uint(1), uint(2)).
+			Return(mockRevision, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		revision, err := articleSvc.GetRevision(context.Background(), 1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, mockRevision, revision)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetRevision", mock.Anything, uint(1), uint(9)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		revision, err := articleSvc.GetRevision(context.Background(), 1, 9)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, revision)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("GetRevision", mock.Anything, uint(1), uint(2)).
+			Return(nil, assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		revision, err := articleSvc.GetRevision(context.Background(), 1, 2)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, revision)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_DiffRevisions(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	from := &domain.ArticleRevision{ArticleID: 1, Revision: 1, Title: "Hello", Content: "the quick fox"}
+	to := &domain.ArticleRevision{ArticleID: 1, Revision: 3, Title: "Hello", Content: "the slow fox"}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("GetRevision", mock.Anything, uint(1), uint(1)).
+			Return(from, nil).Once()
+		mockArticleRepository.On("GetRevision", mock.Anything, uint(1), uint(3)).
+			Return(to, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		diff, err := articleSvc.DiffRevisions(context.Background(), 1, 1, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), diff.From)
+		assert.Equal(t, uint(3), diff.To)
+		assert.Equal(t, []xdiff.Change{{Operation: xdiff.Equal, Text: "Hello"}}, diff.Title)
+		assert.Equal(t, []xdiff.Change{
+			{Operation: xdiff.Equal, Text: "the "},
+			{Operation: xdiff.Delete, Text: "quick"},
+			{Operation: xdiff.Insert, Text: "slow"},
+			{Operation: xdiff.Equal, Text: " fox"},
+		}, diff.Content)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-from-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetRevision", mock.Anything, uint(1), uint(1)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		diff, err := articleSvc.DiffRevisions(context.Background(), 1, 1, 3)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, diff)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-to-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetRevision", mock.Anything, uint(1), uint(1)).
+			Return(from, nil).Once()
+		mockArticleRepository.On("GetRevision", mock.Anything, uint(1), uint(3)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, nil, nil, config.Config{})
+		diff, err := articleSvc.DiffRevisions(context.Background(), 1, 1, 3)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, diff)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestArticleService_Revert(t *testing.T) {
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mockAuthorRepository := new(mocks.AuthorRepository)
+	mockRevision := &domain.ArticleRevision{ArticleID: 1, Revision: 1, Title: "Old Title", Content: "Old Content"}
+	current := &domain.Article{ID: 1, Title: "Title", Slug: "title", Content: "Content", AuthorID: 2}
+
+	t.Run("success", func(t *testing.T) {
+		reverted := &domain.Article{ID: 1, Title: "Old Title", Slug: "title", Content: "Old Content", AuthorID: 2}
+		mockArticleRepository.On("GetRevision", mock.Anything, uint(1), uint(1)).
+			Return(mockRevision, nil).Once()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(current, nil).Twice()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockArticleRepository.On("Update", mock.Anything, mock.MatchedBy(func(article *domain.Article) bool {
+			return article.Title == reverted.Title && article.Content == reverted.Content && article.Slug == reverted.Slug &&
+				article.AuthorID == reverted.AuthorID && article.RenderedContent == "<p>Old Content</p>\n"
+		})).
+			Return(nil).Once()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(reverted, nil).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		article, err := articleSvc.Revert(context.Background(), 1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Old Title", article.Title)
+		assert.Equal(t, "Old Content", article.Content)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-revision-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetRevision", mock.Anything, uint(1), uint(9)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		article, err := articleSvc.Revert(context.Background(), 1, 9)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-article-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetRevision", mock.Anything, uint(1), uint(1)).
+			Return(mockRevision, nil).Once()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		article, err := articleSvc.Revert(context.Background(), 1, 1)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-update", func(t *testing.T) {
+		mockArticleRepository.On("GetRevision", mock.Anything, uint(1), uint(1)).
+			Return(mockRevision, nil).Once()
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), (*domain.ArticleView)(nil)).
+			Return(current, nil).Twice()
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(&domain.Author{ID: 2}, nil).Once()
+		mockArticleRepository.On("Update", mock.Anything, mock.Anything).
+			Return(assert.AnError).Once()
+
+		articleSvc := NewArticleService(mockArticleRepository, mockAuthorRepository, nil, config.Config{})
+		article, err := articleSvc.Revert(context.Background(), 1, 1)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, article)
+
+		mockArticleRepository.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,82 @@
+package article
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// viewKey identifies a visitor of an article by a hash of the visitor, so
+// that every remembered visitor takes the same little memory.
+type viewKey struct {
+	articleID uint
+	visitor   [16]byte
+}
+
+// viewBuffer counts the views of articles in memory until they are taken to
+// be written. A visitor viewing an article again within the window is only
+// counted once.
+type viewBuffer struct {
+	mu          sync.Mutex
+	counts      map[uint]uint64
+	visitors    map[viewKey]time.Time
+	window      time.Duration
+	maxVisitors int
+}
+
+func newViewBuffer(window time.Duration, maxVisitors int) *viewBuffer {
+	return &viewBuffer{
+		counts:      make(map[uint]uint64),
+		visitors:    make(map[viewKey]time.Time),
+		window:      window,
+		maxVisitors: maxVisitors,
+	}
+}
+
+// add counts a view of an article at now and tells whether it was counted.
+func (b *viewBuffer) add(articleID uint, visitor string, now time.Time) bool {
+	sum := sha256.Sum256([]byte(visitor))
+	key := viewKey{articleID: articleID}
+	copy(key.visitor[:], sum[:])
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	expiresAt, known := b.visitors[key]
+	if known && now.Before(expiresAt) {
+		return false
+	}
+	// past the limit, new visitors are counted without being remembered
+	if b.window > 0 && (known || len(b.visitors) < b.maxVisitors) {
+		b.visitors[key] = now.Add(b.window)
+	}
+	b.counts[articleID]++
+	return true
+}
+
+// take returns the views counted since the last call, and forgets the
+// visitors whose window has passed at now.
+func (b *viewBuffer) take(now time.Time) map[uint]uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for key, expiresAt := range b.visitors {
+		if !now.Before(expiresAt) {
+			delete(b.visitors, key)
+		}
+	}
+
+	counts := b.counts
+	b.counts = make(map[uint]uint64)
+	return counts
+}
+
+// restore counts again views that were taken but could not be written.
+func (b *viewBuffer) restore(counts map[uint]uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, count := range counts {
+		b.counts[id] += count
+	}
+}
@@ -0,0 +1,45 @@
+package article
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestViewBuffer(t *testing.T) {
+	now := time.Now()
+
+	t.Run("window", func(t *testing.T) {
+		b := newViewBuffer(time.Minute, 10)
+		assert.True(t, b.add(1, "a", now))
+		assert.False(t, b.add(1, "a", now.Add(30*time.Second)))
+		assert.True(t, b.add(2, "a", now))
+		assert.True(t, b.add(1, "a", now.Add(time.Minute)))
+		assert.Equal(t, map[uint]uint64{1: 2, 2: 1}, b.take(now.Add(time.Minute)))
+		assert.Empty(t, b.take(now))
+	})
+
+	t.Run("forgets visitors", func(t *testing.T) {
+		b := newViewBuffer(time.Minute, 10)
+		b.add(1, "a", now)
+		b.take(now.Add(time.Minute))
+		assert.Empty(t, b.visitors)
+	})
+
+	t.Run("max visitors", func(t *testing.T) {
+		b := newViewBuffer(time.Minute, 1)
+		assert.True(t, b.add(1, "a", now))
+		assert.True(t, b.add(1, "b", now))
+		// b was counted without being remembered
+		assert.True(t, b.add(1, "b", now))
+		assert.False(t, b.add(1, "a", now))
+		assert.Equal(t, map[uint]uint64{1: 3}, b.take(now))
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		b := newViewBuffer(0, 0)
+		b.add(1, "a", now)
+		b.restore(map[uint]uint64{1: 2, 3: 1})
+		assert.Equal(t, map[uint]uint64{1: 3, 3: 1}, b.take(now))
+	})
+}
@@ -0,0 +1,321 @@
+package attachment
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/pkg/xcursor"
+	"mime"
+	"strconv"
+	"time"
+)
+
+// downloadRoute and variantRoute name the routes of files, to build signed
+// URLs from them.
+const (
+	downloadRoute = "attachments.download"
+	variantRoute  = "attachments.variant"
+)
+
+// downloadToken is the signed payload of download URLs, which is the same
+// for the variants of an image.
+type downloadToken struct {
+	ID        uint  `json:"id"`
+	ExpiresAt int64 `json:"exp"`
+}
+
+// Linker sets the signed URLs of attachments, and lists the variants of
+// images with their URLs.
+type Linker struct {
+	cfg    config.Config
+	signer *xcursor.Signer
+}
+
+func NewLinker(cfg config.Config) *Linker {
+	return &Linker{
+		cfg:    cfg,
+		signer: xcursor.New([]byte(cfg.Attachment.URLSecret)),
+	}
+}
+
+// Link sets the URLs of attachments, which point to the routes of
+// NewHttpHandler in the app of c.
+func (l *Linker) Link(c *fiber.Ctx, attachments ...*domain.Attachment) error {
+	expiresAt := time.Now().Add(l.cfg.Attachment.URLExpiry).Truncate(time.Second)
+	for _, attachment := range attachments {
+		token, err := l.signer.Encode(downloadToken{ID: attachment.ID, ExpiresAt: expiresAt.Unix()})
+		if err != nil {
+			return err
+		}
+		id := strconv.FormatUint(uint64(attachment.ID), 10)
+
+		route, err := c.GetRouteURL(downloadRoute, fiber.Map{"id": id})
+		if err != nil {
+			return err
+		}
+		attachment.URL = c.BaseURL() + route + "?token=" + token
+		attachment.URLExpiresAt = &expiresAt
+
+		attachment.Variants = variants(l.cfg.Image, attachment)
+		for _, variant := range attachment.Variants {
+			route, err := c.GetRouteURL(variantRoute, fiber.Map{"id": id, "name": variant.Name})
+			if err != nil {
+				return err
+			}
+			variant.URL = c.BaseURL() + route + "?token=" + token
+		}
+	}
+	return nil
+}
+
+// verify tells whether token is a valid token of the attachment id which has
+// not expired.
+func (l *Linker) verify(token string, id uint) bool {
+	var payload downloadToken
+	if err := l.signer.Decode(token, &payload); err != nil {
+		return false
+	}
+	return payload.ID == id && time.Now().Unix() <= payload.ExpiresAt
+}
+
+type HttpAttachmentHandler struct {
+	attachmentSvc domain.AttachmentService
+	cfg           config.Config
+	linker        *Linker
+}
+
+func NewHttpHandler(r fiber.Router, attachmentSvc domain.AttachmentService, cfg config.Config) {
+	handler := &HttpAttachmentHandler{
+		attachmentSvc: attachmentSvc,
+		cfg:           cfg,
+		linker:        NewLinker(cfg),
+	}
+	r.Get("/articles/:id/attachments", handler.Fetch)
+	r.Post("/articles/:id/attachments", handler.Store)
+	r.Delete("/articles/:id/attachments/:attachmentId", handler.Delete)
+	r.Get("/attachments/:id/download", handler.Download).Name(downloadRoute)
+	r.Get("/attachments/:id/variants/:name", handler.DownloadVariant).Name(variantRoute)
+}
+
+// Fetch used to get the attachments of an article
+//
+//	@Summary		Get attachments of an article
+//	@Description	Get the attachments of an article, oldest first, each with a signed download URL that expires. Images
+//	@Description	also list their resized variants, each with its size and URL.
+//	@Tags			attachments
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int					true	"Article ID"
+//	@Success		200	{array}		domain.Attachment	"List of attachments"
+//	@Failure		400	{object}	domain.Error		"Bad Request"
+//	@Failure		404	{object}	domain.Error		"Not Found"
+//	@Failure		500	{object}	domain.Error		"Internal Server Error"
+//	@Router			/articles/{id}/attachments [get]
+func (h *HttpAttachmentHandler) Fetch(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	attachments, err := h.attachmentSvc.Fetch(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	if attachments == nil {
+		return c.JSON([]domain.Attachment{})
+	}
+	if err := h.linker.Link(c, attachments...); err != nil {
+		return err
+	}
+	return c.JSON(attachments)
+}
+
+// Store used to upload an attachment
+//
+//	@Summary		Upload attachment
+//	@Description	Upload a file to an article. The type of the file is sniffed from its content and must be one of
+//	@Description	ATTACHMENT_ALLOWED_TYPES. Uploading a file the article already has returns the existing attachment
+//	@Description	with 200, and identical files of different articles share their storage.
+//	@Tags			attachments
+//	@Accept			mpfd
+//	@Produce		json
+//	@Param			id		path		int					true	"Article ID"
+//	@Param			file	formData	file				true	"File to upload"
+//	@Success		200		{object}	domain.Attachment	"Existing attachment"
+//	@Success		201		{object}	domain.Attachment	"Attachment detail"
+//	@Failure		400		{object}	domain.Error		"Bad Request"
+//	@Failure		404		{object}	domain.Error		"Not Found"
+//	@Failure		413		{object}	domain.Error		"Request Entity Too Large"
+//	@Failure		415		{object}	domain.Error		"Unsupported Media Type"
+//	@Failure		500		{object}	domain.Error		"Internal Server Error"
+//	@Router			/articles/{id}/attachments [post]
+func (h *HttpAttachmentHandler) Store(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "file is required",
+		})
+	}
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	attachment := &domain.Attachment{
+		ArticleID: uint(id),
+		Filename:  header.Filename,
+	}
+
+	created, err := h.attachmentSvc.Store(c.UserContext(), attachment, file)
+	if err != nil {
+		return err
+	}
+	if err := h.linker.Link(c, attachment); err != nil {
+		return err
+	}
+
+	if created {
+		c.Status(fiber.StatusCreated)
+	}
+	return c.JSON(attachment)
+}
+
+// Delete used to delete an attachment
+//
+//	@Summary		Delete attachment
+//	@Description	Delete an attachment of an article. Its file is removed once no attachment shares it.
+//	@Tags			attachments
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int				true	"Article ID"
+//	@Param			attachmentId	path		int				true	"Attachment ID"
+//	@Success		200				{object}	domain.Message	"Success delete attachment"
+//	@Failure		400				{object}	domain.Error	"Bad Request"
+//	@Failure		404				{object}	domain.Error	"Not Found"
+//	@Failure		500				{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/{id}/attachments/{attachmentId} [delete]
+func (h *HttpAttachmentHandler) Delete(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+	attachmentID, err := c.ParamsInt("attachmentId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	if err := h.attachmentSvc.Delete(c.UserContext(), uint(id), uint(attachmentID)); err != nil {
+		return err
+	}
+
+	return c.JSON(domain.Message{
+		Code:    fiber.StatusOK,
+		Message: "Success delete attachment",
+	})
+}
+
+// Download used to download the file of an attachment
+//
+//	@Summary		Download attachment
+//	@Description	Download the file of an attachment with a signed URL returned with the attachment.
+//	@Tags			attachments
+//	@Produce		octet-stream
+//	@Param			id		path		int				true	"Attachment ID"
+//	@Param			token	query		string			true	"Signature of the URL"
+//	@Success		200		{file}		file			"File content"
+//	@Failure		400		{object}	domain.Error	"Bad Request"
+//	@Failure		403		{object}	domain.Error	"Invalid or expired URL"
+//	@Failure		404		{object}	domain.Error	"Not Found"
+//	@Failure		500		{object}	domain.Error	"Internal Server Error"
+//	@Router			/attachments/{id}/download [get]
+func (h *HttpAttachmentHandler) Download(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	if !h.linker.verify(c.Query("token"), uint(id)) {
+		return c.Status(fiber.StatusForbidden).JSON(domain.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "download URL is invalid or has expired",
+		})
+	}
+
+	// the content is streamed after the handler returns, when the request
+	// context is already done
+	attachment, body, err := h.attachmentSvc.Open(context.WithoutCancel(c.UserContext()), uint(id))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, attachment.ContentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return c.SendStream(body, int(attachment.Size))
+}
+
+// DownloadVariant used to download a resized image
+//
+//	@Summary		Download variant of an image
+//	@Description	Download a resized version of an image attachment with a signed URL returned with the attachment. The
+//	@Description	variants are set by IMAGE_VARIANTS and are made when first downloaded, unless IMAGE_EAGER is set.
+//	@Tags			attachments
+//	@Produce		png,jpeg
+//	@Param			id		path		int				true	"Attachment ID"
+//	@Param			name	path		string			true	"Variant name"
+//	@Param			token	query		string			true	"Signature of the URL"
+//	@Success		200		{file}		file			"Image content"
+//	@Failure		400		{object}	domain.Error	"Bad Request"
+//	@Failure		403		{object}	domain.Error	"Invalid or expired URL"
+//	@Failure		404		{object}	domain.Error	"Not Found"
+//	@Failure		500		{object}	domain.Error	"Internal Server Error"
+//	@Router			/attachments/{id}/variants/{name} [get]
+func (h *HttpAttachmentHandler) DownloadVariant(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	if !h.linker.verify(c.Query("token"), uint(id)) {
+		return c.Status(fiber.StatusForbidden).JSON(domain.Error{
+			Code:    fiber.StatusForbidden,
+			Message: "download URL is invalid or has expired",
+		})
+	}
+
+	variant, body, err := h.attachmentSvc.OpenVariant(context.WithoutCancel(c.UserContext()), uint(id), c.Params("name"))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, variant.ContentType)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return c.SendStream(body)
+}
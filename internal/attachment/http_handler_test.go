@@ -0,0 +1,226 @@
+package attachment
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/mocks"
+	"go-clean-architecture/pkg/xcursor"
+	"go-clean-architecture/pkg/ximage"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+var handlerConfig = config.Config{
+	Attachment: config.Attachment{URLSecret: "secret", URLExpiry: time.Minute},
+	Image:      config.Image{Variants: ximage.Variants{{Name: "thumbnail", Width: 150, Height: 150}}},
+}
+
+func uploadRequest(t *testing.T, path string, filename string, content string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest("POST", path, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestHttpAttachmentHandler_Store(t *testing.T) {
+	mockService := new(mocks.AttachmentService)
+	isUpload := mock.MatchedBy(func(attachment *domain.Attachment) bool {
+		return attachment.ArticleID == 1 && attachment.Filename == "hello.txt"
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Store", mock.Anything, isUpload, mock.Anything).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(*domain.Attachment).ID = 3
+			}).
+			Return(true, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app.Group("/api"), mockService, handlerConfig)
+		resp, err := app.Test(uploadRequest(t, "/api/articles/1/attachments", "hello.txt", "hello"))
+		assert.NoError(t, err)
+		assert.Equal(t, 201, resp.StatusCode)
+
+		var attachment domain.Attachment
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&attachment))
+		link, err := url.Parse(attachment.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "/api/attachments/3/download", link.Path)
+		assert.NotEmpty(t, link.Query().Get("token"))
+		assert.NotNil(t, attachment.URLExpiresAt)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-duplicate", func(t *testing.T) {
+		mockService.On("Store", mock.Anything, isUpload, mock.Anything).Return(false, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, handlerConfig)
+		resp, err := app.Test(uploadRequest(t, "/articles/1/attachments", "hello.txt", "hello"))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-no-file", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, handlerConfig)
+		req := httptest.NewRequest("POST", "/articles/1/attachments", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-not-allowed", func(t *testing.T) {
+		mockService.On("Store", mock.Anything, isUpload, mock.Anything).
+			Return(false, fiber.NewError(fiber.StatusUnsupportedMediaType, "files of type application/pdf are not allowed")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, handlerConfig)
+		resp, err := app.Test(uploadRequest(t, "/articles/1/attachments", "hello.txt", "%PDF-1.4"))
+		assert.NoError(t, err)
+		assert.Equal(t, 415, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpAttachmentHandler_Fetch(t *testing.T) {
+	mockService := new(mocks.AttachmentService)
+
+	mockService.On("Fetch", mock.Anything, uint(1)).
+		Return([]*domain.Attachment{{ID: 3, ArticleID: 1}, {ID: 4, ArticleID: 1, Width: 600, Height: 300}}, nil).Once()
+
+	app := fiber.New()
+	NewHttpHandler(app, mockService, handlerConfig)
+	resp, err := app.Test(httptest.NewRequest("GET", "/articles/1/attachments", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var attachments []domain.Attachment
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&attachments))
+	assert.Len(t, attachments, 2)
+	assert.Contains(t, attachments[1].URL, "/attachments/4/download?token=")
+	assert.Empty(t, attachments[0].Variants)
+	assert.Len(t, attachments[1].Variants, 1)
+	assert.Equal(t, "thumbnail", attachments[1].Variants[0].Name)
+	assert.Equal(t, 150, attachments[1].Variants[0].Width)
+	assert.Equal(t, 75, attachments[1].Variants[0].Height)
+	assert.Contains(t, attachments[1].Variants[0].URL, "/attachments/4/variants/thumbnail?token=")
+	mockService.AssertExpectations(t)
+}
+
+func TestHttpAttachmentHandler_Delete(t *testing.T) {
+	mockService := new(mocks.AttachmentService)
+
+	mockService.On("Delete", mock.Anything, uint(1), uint(3)).Return(nil).Once()
+
+	app := fiber.New()
+	NewHttpHandler(app, mockService, handlerConfig)
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/articles/1/attachments/3", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestHttpAttachmentHandler_Download(t *testing.T) {
+	mockService := new(mocks.AttachmentService)
+	signer := xcursor.New([]byte(handlerConfig.Attachment.URLSecret))
+	attachment := &domain.Attachment{ID: 3, Filename: "héllo.txt", ContentType: "text/plain; charset=utf-8", Size: 5}
+
+	token := func(id uint, expiresAt time.Time) string {
+		token, err := signer.Encode(downloadToken{ID: id, ExpiresAt: expiresAt.Unix()})
+		assert.NoError(t, err)
+		return token
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Open", mock.Anything, uint(3)).
+			Return(attachment, io.NopCloser(strings.NewReader("hello")), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, handlerConfig)
+		resp, err := app.Test(httptest.NewRequest("GET", "/attachments/3/download?token="+token(3, time.Now().Add(time.Minute)), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "text/plain; charset=utf-8", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "attachment; filename*=utf-8''h%C3%A9llo.txt", resp.Header.Get("Content-Disposition"))
+		assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "hello", string(body))
+		mockService.AssertExpectations(t)
+	})
+
+	for name, path := range map[string]string{
+		"error-no-token":      "/attachments/3/download",
+		"error-invalid-token": "/attachments/3/download?token=invalid",
+		"error-other-id":      "/attachments/3/download?token=" + token(4, time.Now().Add(time.Minute)),
+		"error-expired":       "/attachments/3/download?token=" + token(3, time.Now().Add(-time.Minute)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			app := fiber.New()
+			NewHttpHandler(app, mockService, handlerConfig)
+			resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, 403, resp.StatusCode)
+		})
+	}
+}
+
+func TestHttpAttachmentHandler_DownloadVariant(t *testing.T) {
+	mockService := new(mocks.AttachmentService)
+	linker := NewLinker(handlerConfig)
+	attachment := &domain.Attachment{ID: 3, ContentType: "image/png", Width: 600, Height: 300}
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("OpenVariant", mock.Anything, uint(3), "thumbnail").
+			Return(&domain.AttachmentVariant{Name: "thumbnail", Width: 150, Height: 75, ContentType: "image/png"}, io.NopCloser(strings.NewReader("png")), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, handlerConfig)
+		app.Get("/link", func(c *fiber.Ctx) error {
+			if err := linker.Link(c, attachment); err != nil {
+				return err
+			}
+			return c.SendString(attachment.Variants[0].URL)
+		})
+		resp, err := app.Test(httptest.NewRequest("GET", "/link", nil))
+		assert.NoError(t, err)
+		link, _ := io.ReadAll(resp.Body)
+
+		resp, err = app.Test(httptest.NewRequest("GET", string(link), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "png", string(body))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-invalid-token", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, handlerConfig)
+		resp, err := app.Test(httptest.NewRequest("GET", "/attachments/3/variants/thumbnail?token=invalid", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 403, resp.StatusCode)
+	})
+}
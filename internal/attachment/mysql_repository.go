@@ -0,0 +1,118 @@
+package attachment
+
+import (
+	"context"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"slices"
+)
+
+type mysqlAttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewMysqlAttachmentRepository(db *gorm.DB) domain.AttachmentRepository {
+	return &mysqlAttachmentRepository{db: db}
+}
+
+func (r *mysqlAttachmentRepository) FetchByArticle(ctx context.Context, articleID uint) ([]*domain.Attachment, error) {
+	var attachments []*domain.Attachment
+	if err := r.db.WithContext(ctx).Where("article_id = ?", articleID).Order("id").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *mysqlAttachmentRepository) FetchOrphans(ctx context.Context, size uint) ([]*domain.Attachment, error) {
+	var attachments []*domain.Attachment
+	// articles in the trash still own their attachments
+	articles := r.db.Unscoped().Model(&domain.Article{}).Select("1").Where("articles.id = attachments.article_id")
+	if err := r.db.WithContext(ctx).
+		Where("NOT EXISTS (?)", articles).
+		Order("id").Limit(int(size)).
+		Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *mysqlAttachmentRepository) GetByID(ctx context.Context, id uint) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+	if err := r.db.WithContext(ctx).First(&attachment, id).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *mysqlAttachmentRepository) GetByChecksum(ctx context.Context, articleID uint, checksum string) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+	if err := r.db.WithContext(ctx).Where("article_id = ? AND checksum = ?", articleID, checksum).First(&attachment).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *mysqlAttachmentRepository) UnusedChecksums(ctx context.Context, checksums []string) ([]string, error) {
+	if len(checksums) == 0 {
+		return nil, nil
+	}
+
+	var used []string
+	if err := r.db.WithContext(ctx).Model(&domain.Attachment{}).
+		Distinct("checksum").Where("checksum IN ?", checksums).
+		Pluck("checksum", &used).Error; err != nil {
+		return nil, err
+	}
+
+	var unused []string
+	for _, checksum := range checksums {
+		if !slices.Contains(used, checksum) {
+			unused = append(unused, checksum)
+		}
+	}
+	return unused, nil
+}
+
+func (r *mysqlAttachmentRepository) Store(ctx context.Context, attachment *domain.Attachment, checkBlob func() error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the new row holds off a deletion of the blob, or waits for one in
+		// progress to be committed, so the blob is checked once it can no
+		// longer be deleted
+		if err := tx.Create(attachment).Error; err != nil {
+			return err
+		}
+		return checkBlob()
+	})
+}
+
+func (r *mysqlAttachmentRepository) Delete(ctx context.Context, attachment *domain.Attachment, deleteBlob func() error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.Attachment{}, attachment.ID).Error; err != nil {
+			return err
+		}
+		return deleteUnusedBlob(tx, attachment.Checksum, deleteBlob)
+	})
+}
+
+func (r *mysqlAttachmentRepository) DeleteBlob(ctx context.Context, checksum string, deleteBlob func() error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deleteUnusedBlob(tx, checksum, deleteBlob)
+	})
+}
+
+// deleteUnusedBlob calls deleteBlob when no attachment has the checksum. The
+// locking read waits for attachments being stored with the same checksum,
+// and keeps new ones from being stored until the transaction ends.
+func deleteUnusedBlob(tx *gorm.DB, checksum string, deleteBlob func() error) error {
+	var count int64
+	if err := tx.Model(&domain.Attachment{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("checksum = ?", checksum).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	return deleteBlob()
+}
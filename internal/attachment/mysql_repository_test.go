@@ -0,0 +1,274 @@
+package attachment
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func mockDBConnection() (*gorm.DB, sqlmock.Sqlmock, error) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gdb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Info),
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gdb, mock, nil
+}
+
+const checksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+var attachmentColumns = []string{"id", "article_id", "filename", "content_type", "size", "checksum", "created_at"}
+
+func TestMysqlAttachmentRepository_FetchByArticle(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `attachments` WHERE article_id = ? ORDER BY id"
+	rows := sqlmock.NewRows(attachmentColumns).
+		AddRow(1, 1, "hello.txt", "text/plain; charset=utf-8", 5, checksum, time.Now())
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	repo := NewMysqlAttachmentRepository(db)
+
+	attachments, err := repo.FetchByArticle(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 1)
+	assert.Equal(t, "hello.txt", attachments[0].Filename)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlAttachmentRepository_FetchOrphans(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `attachments` WHERE NOT EXISTS (SELECT 1 FROM `articles` WHERE articles.id = attachments.article_id) ORDER BY id LIMIT ?"
+	rows := sqlmock.NewRows(attachmentColumns).
+		AddRow(1, 9, "hello.txt", "text/plain; charset=utf-8", 5, checksum, time.Now())
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(100).
+		WillReturnRows(rows)
+
+	repo := NewMysqlAttachmentRepository(db)
+
+	attachments, err := repo.FetchOrphans(context.Background(), 100)
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlAttachmentRepository_GetByID_NotFound(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `attachments` WHERE `attachments`.`id` = ? ORDER BY `attachments`.`id` LIMIT ?"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows(attachmentColumns))
+
+	repo := NewMysqlAttachmentRepository(db)
+
+	attachment, err := repo.GetByID(context.Background(), 1)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, attachment)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlAttachmentRepository_GetByChecksum(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `attachments` WHERE article_id = ? AND checksum = ? ORDER BY `attachments`.`id` LIMIT ?"
+	rows := sqlmock.NewRows(attachmentColumns).
+		AddRow(1, 1, "hello.txt", "text/plain; charset=utf-8", 5, checksum, time.Now())
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1, checksum, 1).
+		WillReturnRows(rows)
+
+	repo := NewMysqlAttachmentRepository(db)
+
+	attachment, err := repo.GetByChecksum(context.Background(), 1, checksum)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), attachment.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlAttachmentRepository_Store(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `attachments`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlAttachmentRepository(db)
+
+	checked := false
+	attachment := &domain.Attachment{ArticleID: 1, Checksum: checksum}
+	err = repo.Store(context.Background(), attachment, func() error {
+		checked = true
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, checked)
+	assert.Equal(t, uint(1), attachment.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlAttachmentRepository_Store_BlobError(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `attachments`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectRollback()
+
+	repo := NewMysqlAttachmentRepository(db)
+
+	err = repo.Store(context.Background(), &domain.Attachment{ArticleID: 1, Checksum: checksum}, func() error {
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlAttachmentRepository_Delete(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "DELETE FROM `attachments` WHERE `attachments`.`id` = ?"
+	countQuery := "SELECT count(*) FROM `attachments` WHERE checksum = ? FOR UPDATE"
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(countQuery)).
+		WithArgs(checksum).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectCommit()
+
+	repo := NewMysqlAttachmentRepository(db)
+
+	deleted := false
+	err = repo.Delete(context.Background(), &domain.Attachment{ID: 1, Checksum: checksum}, func() error {
+		deleted = true
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlAttachmentRepository_Delete_SharedBlob(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `attachments`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `attachments`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlAttachmentRepository(db)
+
+	err = repo.Delete(context.Background(), &domain.Attachment{ID: 1, Checksum: checksum}, func() error {
+		t.Error("shared blob deleted")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlAttachmentRepository_Delete_BlobError(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `attachments`")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `attachments`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+
+	repo := NewMysqlAttachmentRepository(db)
+
+	err = repo.Delete(context.Background(), &domain.Attachment{ID: 1, Checksum: checksum}, func() error {
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlAttachmentRepository_UnusedChecksums(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	unused := "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	query := "SELECT DISTINCT `checksum` FROM `attachments` WHERE checksum IN (?,?)"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(checksum, unused).
+		WillReturnRows(sqlmock.NewRows([]string{"checksum"}).AddRow(checksum))
+
+	repo := NewMysqlAttachmentRepository(db)
+
+	checksums, err := repo.UnusedChecksums(context.Background(), []string{checksum, unused})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{unused}, checksums)
+
+	checksums, err = repo.UnusedChecksums(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, checksums)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlAttachmentRepository_DeleteBlob(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	countQuery := "SELECT count(*) FROM `attachments` WHERE checksum = ? FOR UPDATE"
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(countQuery)).
+		WithArgs(checksum).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectCommit()
+	// an attachment was stored with the checksum meanwhile
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(countQuery)).
+		WithArgs(checksum).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlAttachmentRepository(db)
+
+	deleted := 0
+	deleteBlob := func() error {
+		deleted++
+		return nil
+	}
+	assert.NoError(t, repo.DeleteBlob(context.Background(), checksum, deleteBlob))
+	assert.NoError(t, repo.DeleteBlob(context.Background(), checksum, deleteBlob))
+	assert.Equal(t, 1, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,461 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/pkg/ximage"
+	"gorm.io/gorm"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// cleanupBatchSize is the number of attachments removed at once by
+	// Cleanup.
+	cleanupBatchSize = 100
+	// orphanBlobAge is the age from which Cleanup removes blobs that no
+	// attachment has, well past the time an attachment takes to be stored.
+	orphanBlobAge = 24 * time.Hour
+)
+
+type attachmentService struct {
+	attachmentRepo domain.AttachmentRepository
+	articleRepo    domain.ArticleRepository
+	blobs          domain.BlobStore
+	images         *ximage.Processor
+	cfg            config.Config
+}
+
+func NewAttachmentService(attachment domain.AttachmentRepository, article domain.ArticleRepository, blobs domain.BlobStore, cfg config.Config) domain.AttachmentService {
+	return &attachmentService{
+		attachmentRepo: attachment,
+		articleRepo:    article,
+		blobs:          blobs,
+		images:         &ximage.Processor{Quality: cfg.Image.JPEGQuality, MaxPixels: cfg.Image.MaxPixels},
+		cfg:            cfg,
+	}
+}
+
+func (s *attachmentService) Fetch(ctx context.Context, articleID uint) ([]*domain.Attachment, error) {
+	if err := s.checkArticle(ctx, articleID); err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.FetchByArticle(ctx, articleID)
+}
+
+// Store sniffs the type of the file from its content and stores its blob
+// under its checksum, unless a blob with the same content exists already.
+// The metadata of images is removed first, and their variants are made when
+// they are made eagerly.
+func (s *attachmentService) Store(ctx context.Context, attachment *domain.Attachment, body io.ReadSeeker) (bool, error) {
+	if err := s.checkArticle(ctx, attachment.ArticleID); err != nil {
+		return false, err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(body, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, err
+	}
+	if n == 0 {
+		return false, fiber.NewError(fiber.StatusBadRequest, "file is empty")
+	}
+	contentType := http.DetectContentType(head[:n])
+	if !s.allowed(contentType) {
+		return false, fiber.NewError(fiber.StatusUnsupportedMediaType, fmt.Sprintf("files of type %s are not allowed", contentType))
+	}
+
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+	var image []byte
+	if ximage.Supported(contentType) {
+		image, err = s.readImage(attachment, body)
+		if err != nil {
+			return false, err
+		}
+		body = bytes.NewReader(image)
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, io.LimitReader(body, s.cfg.Attachment.MaxSize+1))
+	if err != nil {
+		return false, err
+	}
+	if size > s.cfg.Attachment.MaxSize {
+		return false, s.errTooLarge()
+	}
+
+	attachment.Filename = cleanFilename(attachment.Filename)
+	attachment.ContentType = contentType
+	attachment.Size = size
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	existing, err := s.attachmentRepo.GetByChecksum(ctx, attachment.ArticleID, attachment.Checksum)
+	if err == nil {
+		*attachment = *existing
+		return false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	// the blob is stored before the attachment, so that no upload runs in a
+	// transaction; Cleanup removes it when the attachment fails to be stored
+	for retried := false; ; retried = true {
+		if err := s.storeBlobs(ctx, attachment, body, size, image); err != nil {
+			return false, err
+		}
+		err := s.attachmentRepo.Store(ctx, attachment, func() error {
+			return s.checkBlob(ctx, attachment)
+		})
+		if errors.Is(err, domain.ErrBlobNotFound) && !retried {
+			// the last attachment sharing the blob was deleted with it
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// storeBlobs stores the blob of a new attachment unless it exists already,
+// and the variants of images when they are made eagerly.
+func (s *attachmentService) storeBlobs(ctx context.Context, attachment *domain.Attachment, body io.ReadSeeker, size int64, image []byte) error {
+	exists, err := s.blobs.Exists(ctx, attachment.BlobKey())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		if err := s.blobs.Put(ctx, attachment.BlobKey(), body, size, attachment.ContentType); err != nil {
+			return err
+		}
+	}
+	if image == nil || !s.cfg.Image.Eager {
+		return nil
+	}
+
+	for _, variant := range s.cfg.Image.Variants {
+		exists, err := s.blobs.Exists(ctx, attachment.VariantKey(newVariant(attachment, variant)))
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err := s.makeVariant(ctx, attachment, variant, image); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkBlob returns domain.ErrBlobNotFound when the blob of an attachment
+// being stored was deleted since storeBlobs.
+func (s *attachmentService) checkBlob(ctx context.Context, attachment *domain.Attachment) error {
+	exists, err := s.blobs.Exists(ctx, attachment.BlobKey())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrBlobNotFound
+	}
+	return nil
+}
+
+func (s *attachmentService) errTooLarge() error {
+	return fiber.NewError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("files must not be larger than %d bytes", s.cfg.Attachment.MaxSize))
+}
+
+// readImage reads an image without its metadata and sets the width and
+// height of the attachment.
+func (s *attachmentService) readImage(attachment *domain.Attachment, body io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, s.cfg.Attachment.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.cfg.Attachment.MaxSize {
+		return nil, s.errTooLarge()
+	}
+
+	info, err := s.images.Info(data)
+	if errors.Is(err, ximage.ErrTooLarge) {
+		return nil, fiber.NewError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("images must not have more than %d pixels", s.cfg.Image.MaxPixels))
+	}
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "image cannot be read")
+	}
+	data, err = s.images.Strip(data)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "image cannot be read")
+	}
+
+	attachment.Width = info.Width
+	attachment.Height = info.Height
+	return data, nil
+}
+
+func (s *attachmentService) allowed(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range s.cfg.Attachment.AllowedTypes {
+		if strings.EqualFold(strings.TrimSpace(allowed), mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanFilename keeps the last element of a path sent as a filename, without
+// control characters, and cuts it to 255 bytes.
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return "file"
+	}
+
+	for len(name) > 255 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+func (s *attachmentService) Open(ctx context.Context, id uint) (*domain.Attachment, io.ReadCloser, error) {
+	attachment, err := s.attachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, fiber.ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	body, err := s.blobs.Get(ctx, attachment.BlobKey())
+	if err != nil {
+		if errors.Is(err, domain.ErrBlobNotFound) {
+			return nil, nil, fiber.ErrNotFound
+		}
+		return nil, nil, err
+	}
+	return attachment, body, nil
+}
+
+func (s *attachmentService) OpenVariant(ctx context.Context, id uint, name string) (*domain.AttachmentVariant, io.ReadCloser, error) {
+	attachment, err := s.attachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, fiber.ErrNotFound
+		}
+		return nil, nil, err
+	}
+	variant, ok := s.cfg.Image.Variants.Find(name)
+	if !ok || attachment.Width == 0 {
+		return nil, nil, fiber.NewError(fiber.StatusNotFound, "variant not found")
+	}
+
+	resized := newVariant(attachment, variant)
+	resized.ContentType = variantContentType(attachment.ContentType)
+	body, err := s.blobs.Get(ctx, attachment.VariantKey(resized))
+	if err == nil {
+		return resized, body, nil
+	}
+	if !errors.Is(err, domain.ErrBlobNotFound) {
+		return nil, nil, err
+	}
+
+	original, err := s.blobs.Get(ctx, attachment.BlobKey())
+	if err != nil {
+		if errors.Is(err, domain.ErrBlobNotFound) {
+			return nil, nil, fiber.ErrNotFound
+		}
+		return nil, nil, err
+	}
+	defer original.Close()
+	image, err := io.ReadAll(original)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := s.makeVariant(ctx, attachment, variant, image)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resized, io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// makeVariant resizes the image of an attachment and stores the variant.
+func (s *attachmentService) makeVariant(ctx context.Context, attachment *domain.Attachment, variant ximage.Variant, image []byte) ([]byte, error) {
+	key := attachment.VariantKey(newVariant(attachment, variant))
+	data, contentType, err := s.images.Resize(image, variant.Width, variant.Height)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.blobs.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// newVariant returns a variant of an image attachment, with its size.
+func newVariant(attachment *domain.Attachment, variant ximage.Variant) *domain.AttachmentVariant {
+	width, height := ximage.Fit(attachment.Width, attachment.Height, variant.Width, variant.Height)
+	return &domain.AttachmentVariant{Name: variant.Name, Width: width, Height: height}
+}
+
+// variants returns the variants of an image attachment, or nil for other
+// files.
+func variants(cfg config.Image, attachment *domain.Attachment) []*domain.AttachmentVariant {
+	if attachment.Width == 0 || attachment.Height == 0 {
+		return nil
+	}
+	result := make([]*domain.AttachmentVariant, len(cfg.Variants))
+	for i, variant := range cfg.Variants {
+		result[i] = newVariant(attachment, variant)
+	}
+	return result
+}
+
+// variantContentType is the media type of the variants of images: JPEG
+// images stay JPEG images and the others become PNG images.
+func variantContentType(contentType string) string {
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "image/jpeg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+func (s *attachmentService) Delete(ctx context.Context, articleID uint, id uint) error {
+	attachment, err := s.attachmentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.ErrNotFound
+		}
+		return err
+	}
+	if attachment.ArticleID != articleID {
+		return fiber.ErrNotFound
+	}
+
+	return s.attachmentRepo.Delete(ctx, attachment, func() error {
+		return s.deleteBlobs(ctx, attachment)
+	})
+}
+
+func (s *attachmentService) Cleanup(ctx context.Context) (int64, error) {
+	var count int64
+	for {
+		attachments, err := s.attachmentRepo.FetchOrphans(ctx, cleanupBatchSize)
+		if err != nil {
+			return count, err
+		}
+
+		for _, attachment := range attachments {
+			if err := s.attachmentRepo.Delete(ctx, attachment, func() error {
+				return s.deleteBlobs(ctx, attachment)
+			}); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if len(attachments) < cleanupBatchSize {
+			break
+		}
+	}
+
+	blobs, err := s.cleanupBlobs(ctx, time.Now().Add(-orphanBlobAge))
+	return count + blobs, err
+}
+
+// cleanupBlobs deletes the blobs stored before the given time that no
+// attachment has, with their variants.
+func (s *attachmentService) cleanupBlobs(ctx context.Context, before time.Time) (int64, error) {
+	var count int64
+	err := s.blobs.List(ctx, "", func(blobs []*domain.BlobInfo) error {
+		var checksums []string
+		for _, blob := range blobs {
+			if checksum, ok := blobChecksum(blob.Key); ok && blob.ModifiedAt.Before(before) {
+				checksums = append(checksums, checksum)
+			}
+		}
+
+		unused, err := s.attachmentRepo.UnusedChecksums(ctx, checksums)
+		if err != nil {
+			return err
+		}
+		for _, checksum := range unused {
+			orphan := &domain.Attachment{Checksum: checksum}
+			if err := s.attachmentRepo.DeleteBlob(ctx, checksum, func() error {
+				if err := s.blobs.Delete(ctx, orphan.BlobKey()); err != nil && !errors.Is(err, domain.ErrBlobNotFound) {
+					return err
+				}
+				// whether the blob is an image is not known
+				return s.blobs.DeleteDir(ctx, orphan.VariantDir())
+			}); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// blobChecksum returns the checksum of the attachments whose blob has the
+// key, which is not the case of variants and other files.
+func blobChecksum(key string) (string, bool) {
+	checksum := path.Base(key)
+	if len(checksum) != sha256.Size*2 || (&domain.Attachment{Checksum: checksum}).BlobKey() != key {
+		return "", false
+	}
+	return checksum, true
+}
+
+// deleteBlobs deletes the blob of a deleted attachment and the blobs of its
+// variants, once no attachment shares them anymore. Variants are deleted by
+// directory, as their sizes may have changed since they were made.
+func (s *attachmentService) deleteBlobs(ctx context.Context, attachment *domain.Attachment) error {
+	if err := s.blobs.Delete(ctx, attachment.BlobKey()); err != nil && !errors.Is(err, domain.ErrBlobNotFound) {
+		return err
+	}
+	if attachment.Width == 0 {
+		return nil
+	}
+	return s.blobs.DeleteDir(ctx, attachment.VariantDir())
+}
+
+// checkArticle checks that the article exists and is not in the trash.
+func (s *attachmentService) checkArticle(ctx context.Context, id uint) error {
+	if _, err := s.articleRepo.GetByID(ctx, id, &domain.ArticleView{Columns: []string{"id"}}); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "article not found")
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,504 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/mocks"
+	"go-clean-architecture/pkg/ximage"
+	"gorm.io/gorm"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// articleView is the view used to check the article of attachments.
+var articleView = &domain.ArticleView{Columns: []string{"id"}}
+
+var attachmentConfig = config.Config{Attachment: config.Attachment{
+	MaxSize:      16,
+	AllowedTypes: []string{"text/plain", "image/png"},
+}}
+
+// blobKey is the key of the blob of "hello".
+var blobKey = checksum[:2] + "/" + checksum[2:4] + "/" + checksum
+
+var imageConfig = config.Config{
+	Attachment: config.Attachment{MaxSize: 1 << 20, AllowedTypes: []string{"image/png"}},
+	Image: config.Image{
+		Variants:    ximage.Variants{{Name: "thumbnail", Width: 10, Height: 10}},
+		JPEGQuality: 85,
+		MaxPixels:   1000,
+	},
+}
+
+// pngImage returns a w by h PNG image, with a text chunk when text is set.
+func pngImage(t *testing.T, w, h int, text string) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))))
+	data := buf.Bytes()
+	if text == "" {
+		return data
+	}
+
+	// after the signature and the header chunk
+	chunk := []byte{0, 0, 0, byte(len(text))}
+	chunk = append(chunk, "tEXt"+text+"\x00\x00\x00\x00"...)
+	return append(append(append([]byte{}, data[:33]...), chunk...), data[33:]...)
+}
+
+// withBlob is returned by the Store and Delete mocks of the repository to
+// store or delete the blob as the repository does.
+func withBlob(_ context.Context, _ *domain.Attachment, blob func() error) error {
+	return blob()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAttachmentService_Store(t *testing.T) {
+	mockAttachmentRepository := new(mocks.AttachmentRepository)
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mockBlobStore := new(mocks.BlobStore)
+	article := &domain.Article{ID: 1}
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+		mockAttachmentRepository.On("GetByChecksum", mock.Anything, uint(1), checksum).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockBlobStore.On("Exists", mock.Anything, blobKey).Return(false, nil).Once()
+		mockBlobStore.On("Put", mock.Anything, blobKey, mock.MatchedBy(func(body io.Reader) bool {
+			content, _ := io.ReadAll(body)
+			return string(content) == "hello"
+		}), int64(5), "text/plain; charset=utf-8").Return(nil).Once()
+		mockAttachmentRepository.On("Store", mock.Anything, mock.Anything, mock.Anything).Return(withBlob).Once()
+		mockBlobStore.On("Exists", mock.Anything, blobKey).Return(true, nil).Once()
+
+		attachment := &domain.Attachment{ArticleID: 1, Filename: `C:\Users\ana\hello.txt`}
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, attachmentConfig)
+		created, err := attachmentSvc.Store(context.Background(), attachment, strings.NewReader("hello"))
+		assert.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, "hello.txt", attachment.Filename)
+		assert.Equal(t, "text/plain; charset=utf-8", attachment.ContentType)
+		assert.Equal(t, int64(5), attachment.Size)
+		assert.Equal(t, checksum, attachment.Checksum)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("success-shared-blob", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+		mockAttachmentRepository.On("GetByChecksum", mock.Anything, uint(1), checksum).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockBlobStore.On("Exists", mock.Anything, blobKey).Return(true, nil).Twice()
+		mockAttachmentRepository.On("Store", mock.Anything, mock.Anything, mock.Anything).Return(withBlob).Once()
+
+		attachment := &domain.Attachment{ArticleID: 1, Filename: "hello.txt"}
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, attachmentConfig)
+		created, err := attachmentSvc.Store(context.Background(), attachment, strings.NewReader("hello"))
+		assert.NoError(t, err)
+		assert.True(t, created)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("error-blob", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+		mockAttachmentRepository.On("GetByChecksum", mock.Anything, uint(1), checksum).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockBlobStore.On("Exists", mock.Anything, blobKey).Return(false, nil).Once()
+		mockBlobStore.On("Put", mock.Anything, blobKey, mock.Anything, int64(5), "text/plain; charset=utf-8").Return(assert.AnError).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, attachmentConfig)
+		_, err := attachmentSvc.Store(context.Background(), &domain.Attachment{ArticleID: 1}, strings.NewReader("hello"))
+		assert.ErrorIs(t, err, assert.AnError)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("success-blob-deleted", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+		mockAttachmentRepository.On("GetByChecksum", mock.Anything, uint(1), checksum).Return(nil, gorm.ErrRecordNotFound).Once()
+		// the shared blob is deleted with the last attachment having it
+		// before the attachment is stored, so it is stored again
+		mockBlobStore.On("Exists", mock.Anything, blobKey).Return(true, nil).Once()
+		mockBlobStore.On("Exists", mock.Anything, blobKey).Return(false, nil).Twice()
+		mockBlobStore.On("Put", mock.Anything, blobKey, mock.Anything, int64(5), "text/plain; charset=utf-8").Return(nil).Once()
+		mockBlobStore.On("Exists", mock.Anything, blobKey).Return(true, nil).Once()
+		mockAttachmentRepository.On("Store", mock.Anything, mock.Anything, mock.Anything).Return(withBlob).Twice()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, attachmentConfig)
+		created, err := attachmentSvc.Store(context.Background(), &domain.Attachment{ArticleID: 1}, strings.NewReader("hello"))
+		assert.NoError(t, err)
+		assert.True(t, created)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("error-blob-deleted-again", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+		mockAttachmentRepository.On("GetByChecksum", mock.Anything, uint(1), checksum).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockBlobStore.On("Exists", mock.Anything, blobKey).Return(true, nil).Twice()
+		mockAttachmentRepository.On("Store", mock.Anything, mock.Anything, mock.Anything).Return(domain.ErrBlobNotFound).Twice()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, attachmentConfig)
+		_, err := attachmentSvc.Store(context.Background(), &domain.Attachment{ArticleID: 1}, strings.NewReader("hello"))
+		assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("success-duplicate", func(t *testing.T) {
+		existing := &domain.Attachment{ID: 3, ArticleID: 1, Filename: "first.txt", Checksum: checksum}
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+		mockAttachmentRepository.On("GetByChecksum", mock.Anything, uint(1), checksum).Return(existing, nil).Once()
+
+		attachment := &domain.Attachment{ArticleID: 1, Filename: "hello.txt"}
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, attachmentConfig)
+		created, err := attachmentSvc.Store(context.Background(), attachment, strings.NewReader("hello"))
+		assert.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, existing, attachment)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("error-type-not-allowed", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, attachmentConfig)
+		created, err := attachmentSvc.Store(context.Background(), &domain.Attachment{ArticleID: 1}, bytes.NewReader([]byte("%PDF-1.4\n")))
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusUnsupportedMediaType, fiberErr.Code)
+		assert.Equal(t, "files of type application/pdf are not allowed", fiberErr.Message)
+		assert.False(t, created)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-too-large", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, attachmentConfig)
+		_, err := attachmentSvc.Store(context.Background(), &domain.Attachment{ArticleID: 1}, strings.NewReader(strings.Repeat("a", 17)))
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusRequestEntityTooLarge, fiberErr.Code)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-empty", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, attachmentConfig)
+		_, err := attachmentSvc.Store(context.Background(), &domain.Attachment{ArticleID: 1}, strings.NewReader(""))
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusBadRequest, fiberErr.Code)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-article-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, attachmentConfig)
+		_, err := attachmentSvc.Store(context.Background(), &domain.Attachment{ArticleID: 1}, strings.NewReader("hello"))
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusNotFound, fiberErr.Code)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentService_StoreImage(t *testing.T) {
+	mockAttachmentRepository := new(mocks.AttachmentRepository)
+	mockArticleRepository := new(mocks.ArticleRepository)
+	mockBlobStore := new(mocks.BlobStore)
+	article := &domain.Article{ID: 1}
+	stripped := pngImage(t, 40, 20, "")
+	imageChecksum := sha256Hex(stripped)
+	key := imageChecksum[:2] + "/" + imageChecksum[2:4] + "/" + imageChecksum
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+		mockAttachmentRepository.On("GetByChecksum", mock.Anything, uint(1), imageChecksum).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockBlobStore.On("Exists", mock.Anything, key).Return(false, nil).Once()
+		mockBlobStore.On("Put", mock.Anything, key, mock.Anything, int64(len(stripped)), "image/png").Return(nil).Once()
+		mockAttachmentRepository.On("Store", mock.Anything, mock.Anything, mock.Anything).Return(withBlob).Once()
+		mockBlobStore.On("Exists", mock.Anything, key).Return(true, nil).Once()
+
+		attachment := &domain.Attachment{ArticleID: 1, Filename: "photo.png"}
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, imageConfig)
+		created, err := attachmentSvc.Store(context.Background(), attachment, bytes.NewReader(pngImage(t, 40, 20, "Author\x00Ana")))
+		assert.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, imageChecksum, attachment.Checksum)
+		assert.Equal(t, 40, attachment.Width)
+		assert.Equal(t, 20, attachment.Height)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("success-eager", func(t *testing.T) {
+		cfg := imageConfig
+		cfg.Image.Eager = true
+		variantKey := "variants/" + imageChecksum + "/thumbnail-10x5"
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+		mockAttachmentRepository.On("GetByChecksum", mock.Anything, uint(1), imageChecksum).Return(nil, gorm.ErrRecordNotFound).Once()
+		mockBlobStore.On("Exists", mock.Anything, key).Return(true, nil).Twice()
+		mockBlobStore.On("Exists", mock.Anything, variantKey).Return(false, nil).Once()
+		mockBlobStore.On("Put", mock.Anything, variantKey, mock.Anything, mock.Anything, "image/png").Return(nil).Once()
+		mockAttachmentRepository.On("Store", mock.Anything, mock.Anything, mock.Anything).Return(withBlob).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, cfg)
+		created, err := attachmentSvc.Store(context.Background(), &domain.Attachment{ArticleID: 1}, bytes.NewReader(stripped))
+		assert.NoError(t, err)
+		assert.True(t, created)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("error-too-many-pixels", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, imageConfig)
+		_, err := attachmentSvc.Store(context.Background(), &domain.Attachment{ArticleID: 1}, bytes.NewReader(pngImage(t, 100, 100, "")))
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusRequestEntityTooLarge, fiberErr.Code)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-corrupt", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(article, nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, mockArticleRepository, mockBlobStore, imageConfig)
+		_, err := attachmentSvc.Store(context.Background(), &domain.Attachment{ArticleID: 1}, bytes.NewReader(stripped[:40]))
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusBadRequest, fiberErr.Code)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentService_OpenVariant(t *testing.T) {
+	mockAttachmentRepository := new(mocks.AttachmentRepository)
+	mockBlobStore := new(mocks.BlobStore)
+	attachment := &domain.Attachment{ID: 1, ArticleID: 1, ContentType: "image/png", Checksum: checksum, Width: 40, Height: 20}
+	variantKey := "variants/" + checksum + "/thumbnail-10x5"
+
+	t.Run("success-stored", func(t *testing.T) {
+		mockAttachmentRepository.On("GetByID", mock.Anything, uint(1)).Return(attachment, nil).Once()
+		mockBlobStore.On("Get", mock.Anything, variantKey).Return(io.NopCloser(strings.NewReader("thumbnail")), nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, nil, mockBlobStore, imageConfig)
+		variant, body, err := attachmentSvc.OpenVariant(context.Background(), 1, "thumbnail")
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.AttachmentVariant{Name: "thumbnail", Width: 10, Height: 5, ContentType: "image/png"}, variant)
+		content, _ := io.ReadAll(body)
+		assert.Equal(t, "thumbnail", string(content))
+
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("success-made", func(t *testing.T) {
+		mockAttachmentRepository.On("GetByID", mock.Anything, uint(1)).Return(attachment, nil).Once()
+		mockBlobStore.On("Get", mock.Anything, variantKey).Return(nil, domain.ErrBlobNotFound).Once()
+		mockBlobStore.On("Get", mock.Anything, blobKey).Return(io.NopCloser(bytes.NewReader(pngImage(t, 40, 20, ""))), nil).Once()
+		mockBlobStore.On("Put", mock.Anything, variantKey, mock.Anything, mock.Anything, "image/png").Return(nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, nil, mockBlobStore, imageConfig)
+		_, body, err := attachmentSvc.OpenVariant(context.Background(), 1, "thumbnail")
+		assert.NoError(t, err)
+		config, err := png.DecodeConfig(body)
+		assert.NoError(t, err)
+		assert.Equal(t, 10, config.Width)
+		assert.Equal(t, 5, config.Height)
+
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("error-unknown-variant", func(t *testing.T) {
+		mockAttachmentRepository.On("GetByID", mock.Anything, uint(1)).Return(attachment, nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, nil, mockBlobStore, imageConfig)
+		_, _, err := attachmentSvc.OpenVariant(context.Background(), 1, "huge")
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusNotFound, fiberErr.Code)
+
+		mockAttachmentRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-an-image", func(t *testing.T) {
+		mockAttachmentRepository.On("GetByID", mock.Anything, uint(2)).Return(&domain.Attachment{ID: 2, Checksum: checksum}, nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, nil, mockBlobStore, imageConfig)
+		_, _, err := attachmentSvc.OpenVariant(context.Background(), 2, "thumbnail")
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusNotFound, fiberErr.Code)
+
+		mockAttachmentRepository.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentService_Open(t *testing.T) {
+	mockAttachmentRepository := new(mocks.AttachmentRepository)
+	mockBlobStore := new(mocks.BlobStore)
+	attachment := &domain.Attachment{ID: 1, ArticleID: 1, Checksum: checksum}
+
+	t.Run("success", func(t *testing.T) {
+		mockAttachmentRepository.On("GetByID", mock.Anything, uint(1)).Return(attachment, nil).Once()
+		mockBlobStore.On("Get", mock.Anything, blobKey).Return(io.NopCloser(strings.NewReader("hello")), nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, nil, mockBlobStore, attachmentConfig)
+		result, body, err := attachmentSvc.Open(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, attachment, result)
+		content, _ := io.ReadAll(body)
+		assert.Equal(t, "hello", string(content))
+
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("error-blob-not-found", func(t *testing.T) {
+		mockAttachmentRepository.On("GetByID", mock.Anything, uint(1)).Return(attachment, nil).Once()
+		mockBlobStore.On("Get", mock.Anything, blobKey).Return(nil, domain.ErrBlobNotFound).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, nil, mockBlobStore, attachmentConfig)
+		_, _, err := attachmentSvc.Open(context.Background(), 1)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentService_Delete(t *testing.T) {
+	mockAttachmentRepository := new(mocks.AttachmentRepository)
+	mockBlobStore := new(mocks.BlobStore)
+	attachment := &domain.Attachment{ID: 1, ArticleID: 1, Checksum: checksum}
+
+	t.Run("success", func(t *testing.T) {
+		mockAttachmentRepository.On("GetByID", mock.Anything, uint(1)).Return(attachment, nil).Once()
+		mockAttachmentRepository.On("Delete", mock.Anything, attachment, mock.Anything).Return(withBlob).Once()
+		mockBlobStore.On("Delete", mock.Anything, blobKey).Return(nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, nil, mockBlobStore, attachmentConfig)
+		err := attachmentSvc.Delete(context.Background(), 1, 1)
+		assert.NoError(t, err)
+
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("success-shared-blob", func(t *testing.T) {
+		mockAttachmentRepository.On("GetByID", mock.Anything, uint(1)).Return(attachment, nil).Once()
+		// the blob is only deleted by the repository when no attachment shares it
+		mockAttachmentRepository.On("Delete", mock.Anything, attachment, mock.Anything).Return(nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, nil, mockBlobStore, attachmentConfig)
+		err := attachmentSvc.Delete(context.Background(), 1, 1)
+		assert.NoError(t, err)
+
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("success-image", func(t *testing.T) {
+		image := &domain.Attachment{ID: 2, ArticleID: 1, ContentType: "image/png", Checksum: checksum, Width: 40, Height: 20}
+		mockAttachmentRepository.On("GetByID", mock.Anything, uint(2)).Return(image, nil).Once()
+		mockAttachmentRepository.On("Delete", mock.Anything, image, mock.Anything).Return(withBlob).Once()
+		mockBlobStore.On("Delete", mock.Anything, blobKey).Return(nil).Once()
+		mockBlobStore.On("DeleteDir", mock.Anything, "variants/"+checksum).Return(nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, nil, mockBlobStore, imageConfig)
+		err := attachmentSvc.Delete(context.Background(), 1, 2)
+		assert.NoError(t, err)
+
+		mockAttachmentRepository.AssertExpectations(t)
+		mockBlobStore.AssertExpectations(t)
+	})
+
+	t.Run("error-other-article", func(t *testing.T) {
+		mockAttachmentRepository.On("GetByID", mock.Anything, uint(1)).Return(attachment, nil).Once()
+
+		attachmentSvc := NewAttachmentService(mockAttachmentRepository, nil, mockBlobStore, attachmentConfig)
+		err := attachmentSvc.Delete(context.Background(), 2, 1)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+
+		mockAttachmentRepository.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentService_Cleanup(t *testing.T) {
+	mockAttachmentRepository := new(mocks.AttachmentRepository)
+	mockBlobStore := new(mocks.BlobStore)
+	orphans := []*domain.Attachment{{ID: 1, ArticleID: 9, Checksum: checksum}}
+	unused := sha256Hex([]byte("unused"))
+	unusedKey := unused[:2] + "/" + unused[2:4] + "/" + unused
+	recent := sha256Hex([]byte("recent"))
+	old := time.Now().Add(-48 * time.Hour)
+
+	mockAttachmentRepository.On("FetchOrphans", mock.Anything, uint(cleanupBatchSize)).Return(orphans, nil).Once()
+	mockAttachmentRepository.On("Delete", mock.Anything, orphans[0], mock.Anything).Return(withBlob).Once()
+	mockBlobStore.On("Delete", mock.Anything, blobKey).Return(domain.ErrBlobNotFound).Once()
+	mockBlobStore.On("List", mock.Anything, "", mock.Anything).Return(func(_ context.Context, _ string, fn func([]*domain.BlobInfo) error) error {
+		return fn([]*domain.BlobInfo{
+			{Key: blobKey, ModifiedAt: old},
+			{Key: unusedKey, ModifiedAt: old},
+			// the attachment of a blob stored recently may still be stored
+			{Key: recent[:2] + "/" + recent[2:4] + "/" + recent, ModifiedAt: time.Now()},
+			{Key: "variants/" + unused + "/thumbnail-10x5", ModifiedAt: old},
+		})
+	}).Once()
+	mockAttachmentRepository.On("UnusedChecksums", mock.Anything, []string{checksum, unused}).Return([]string{unused}, nil).Once()
+	mockAttachmentRepository.On("DeleteBlob", mock.Anything, unused, mock.Anything).Return(func(_ context.Context, _ string, deleteBlob func() error) error {
+		return deleteBlob()
+	}).Once()
+	mockBlobStore.On("Delete", mock.Anything, unusedKey).Return(nil).Once()
+	mockBlobStore.On("DeleteDir", mock.Anything, "variants/"+unused).Return(nil).Once()
+
+	attachmentSvc := NewAttachmentService(mockAttachmentRepository, nil, mockBlobStore, attachmentConfig)
+	count, err := attachmentSvc.Cleanup(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	mockAttachmentRepository.AssertExpectations(t)
+	mockBlobStore.AssertExpectations(t)
+}
@@ -0,0 +1,301 @@
+package author
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/internal/middleware/validation"
+	"go-clean-architecture/internal/utilities"
+)
+
+type HttpAuthorHandler struct {
+	authorSvc  domain.AuthorService
+	articleSvc domain.ArticleService
+	cfg        config.Config
+}
+
+func NewHttpHandler(r fiber.Router, authorSvc domain.AuthorService, articleSvc domain.ArticleService, cfg config.Config) {
+	handler := &HttpAuthorHandler{
+		authorSvc:  authorSvc,
+		articleSvc: articleSvc,
+		cfg:        cfg,
+	}
+	r.Post("/", validation.New[domain.AuthorStoreRequest](), handler.Store)
+	r.Get("/", handler.Fetch)
+	r.Get("/:id", handler.GetByID)
+	r.Get("/:id/articles", handler.FetchArticles)
+	r.Put("/:id", validation.New[domain.AuthorUpdateRequest](), handler.Update)
+	r.Delete("/:id", handler.Delete)
+}
+
+// Fetch used to get list of authors
+//
+//	@Summary		Get list of authors
+//	@Description	Get list of authors
+//	@Tags			authors
+//	@Accept			json
+//	@Produce		json
+//	@Param			page	query		int				false	"Page number (default 1)"
+//	@Param			size	query		int				false	"Size of page (default 10)"
+//	@Param			q		query		string			false	"Search by name"
+//	@Header			200		{string}	X-Cursor		"Next page"
+//	@Header			200		{string}	X-Total-Count	"Total item"
+//	@Header			200		{string}	X-Max-Page		"Max page"
+//	@Success		200		{array}		domain.Author	"List of authors"
+//	@Failure		400		{object}	domain.Error	"Bad Request"
+//	@Failure		500		{object}	domain.Error	"Internal Server Error"
+//	@Router			/authors [get]
+func (h *HttpAuthorHandler) Fetch(c *fiber.Ctx) error {
+	page, size, query := c.QueryInt("page", 1), c.QueryInt("size", 10), c.Query("q")
+	if page <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "page must be a positive integer",
+		})
+	}
+	if size <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "size must be a positive integer",
+		})
+	}
+	if maxSize := h.cfg.Pagination.MaxSize; maxSize > 0 && size > maxSize {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: fmt.Sprintf("size must not be greater than %d", maxSize),
+		})
+	}
+
+	filter := &domain.Author{Name: query}
+	authors, nextPage, err := h.authorSvc.Fetch(c.UserContext(), uint(page), uint(size), filter)
+	if err != nil {
+		return err
+	}
+
+	if authors == nil {
+		return c.JSON([]domain.Author{})
+	}
+
+	totalItem, err := h.authorSvc.Count(c.UserContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	utilities.SetPaginationHeaders(c, nextPage, totalItem, size)
+	return c.JSON(authors)
+}
+
+// GetByID used to get author by id
+//
+//	@Summary		Get author by id
+//	@Description	Get author by id
+//	@Tags			authors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				true	"Author ID"
+//	@Success		200	{object}	domain.Author	"Author detail"
+//	@Failure		400	{object}	domain.Error	"Bad Request"
+//	@Failure		404	{object}	domain.Error	"Not Found"
+//	@Failure		500	{object}	domain.Error	"Internal Server Error"
+//	@Router			/authors/{id} [get]
+func (h *HttpAuthorHandler) GetByID(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	author, err := h.authorSvc.GetByID(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(author)
+}
+
+// FetchArticles used to get list of articles written by an author
+//
+//	@Summary		Get list of articles by author
+//	@Description	Get list of articles written by an author
+//	@Tags			authors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int				true	"Author ID"
+//	@Param			page	query		int				false	"Page number (default 1)"
+//	@Param			size	query		int				false	"Size of page (default 10)"
+//	@Param			q		query		string			false	"Full-text search over title and content. It needs a letter or a digit"
+//	@Param			status	query		string			false	"Filter by status (default published). Other statuses need the X-Editor header"	Enums(draft, review, published, archived, all)
+//	@Header			200		{string}	X-Cursor		"Next page"
+//	@Header			200		{string}	X-Total-Count	"Total item"
+//	@Header			200		{string}	X-Max-Page		"Max page"
+//	@Success		200		{array}		domain.Article	"List of articles"
+//	@Failure		400		{object}	domain.Error	"Bad Request"
+//	@Failure		403		{object}	domain.Error	"Status other than published without the X-Editor header"
+//	@Failure		404		{object}	domain.Error	"Not Found"
+//	@Failure		500		{object}	domain.Error	"Internal Server Error"
+//	@Router			/authors/{id}/articles [get]
+func (h *HttpAuthorHandler) FetchArticles(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	page, size := c.QueryInt("page", 1), c.QueryInt("size", 10)
+	if page <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "page must be a positive integer",
+		})
+	}
+	if size <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "size must be a positive integer",
+		})
+	}
+	if maxSize := h.cfg.Pagination.MaxSize; maxSize > 0 && size > maxSize {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: fmt.Sprintf("size must not be greater than %d", maxSize),
+		})
+	}
+
+	query, err := utilities.QuerySearch(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	status, err := utilities.QueryStatus(c)
+	if err != nil {
+		statusErr := err.(domain.Error)
+		return c.Status(statusErr.Code).JSON(statusErr)
+	}
+
+	if _, err := h.authorSvc.GetByID(c.UserContext(), uint(id)); err != nil {
+		return err
+	}
+
+	filter := &domain.ArticleFilter{Query: query, AuthorID: uint(id), Status: status}
+	articles, nextPage, err := h.articleSvc.Fetch(c.UserContext(), uint(page), uint(size), filter)
+	if err != nil {
+		return err
+	}
+
+	if articles == nil {
+		return c.JSON([]domain.Article{})
+	}
+
+	totalItem, err := h.articleSvc.Count(c.UserContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	utilities.SetPaginationHeaders(c, nextPage, totalItem, size)
+	return c.JSON(articles)
+}
+
+// Store used to store author
+//
+//	@Summary		Store author
+//	@Description	Store author
+//	@Tags			authors
+//	@Accept			json
+//	@Produce		json
+//	@Param			author	body		domain.AuthorStoreRequest	true	"Author data"
+//	@Success		201		{object}	domain.Author				"Author detail"
+//	@Failure		400		{object}	domain.Error				"Bad Request"
+//	@Failure		500		{object}	domain.Error				"Internal Server Error"
+//	@Router			/authors [post]
+func (h *HttpAuthorHandler) Store(c *fiber.Ctx) error {
+	authorReq := utilities.ExtractStructFromValidator[domain.AuthorStoreRequest](c)
+
+	author := &domain.Author{
+		Name: authorReq.Name,
+	}
+
+	if err := h.authorSvc.Store(c.UserContext(), author); err != nil {
+		return err
+	}
+
+	c.Status(fiber.StatusCreated)
+	return c.JSON(author)
+}
+
+// Update used to update author
+//
+//	@Summary		Update author
+//	@Description	Update author
+//	@Tags			authors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Author ID"
+//	@Param			author	body		domain.AuthorUpdateRequest	true	"Author data"
+//	@Success		200		{object}	domain.Author				"Author detail"
+//	@Failure		400		{object}	domain.Error				"Bad Request"
+//	@Failure		404		{object}	domain.Error				"Not Found"
+//	@Failure		500		{object}	domain.Error				"Internal Server Error"
+//	@Router			/authors/{id} [put]
+func (h *HttpAuthorHandler) Update(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	authorReq := utilities.ExtractStructFromValidator[domain.AuthorUpdateRequest](c)
+
+	author := &domain.Author{
+		ID:   uint(id),
+		Name: authorReq.Name,
+	}
+
+	if err := h.authorSvc.Update(c.UserContext(), author); err != nil {
+		return err
+	}
+
+	return c.JSON(author)
+}
+
+// Delete used to delete author
+//
+//	@Summary		Delete author
+//	@Description	Delete author, only allowed when the author has no articles
+//	@Tags			authors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int				true	"Author ID"
+//	@Success		200	{object}	domain.Message	"Success delete author"
+//	@Failure		400	{object}	domain.Error	"Bad Request"
+//	@Failure		404	{object}	domain.Error	"Not Found"
+//	@Failure		409	{object}	domain.Error	"Author still has articles"
+//	@Failure		500	{object}	domain.Error	"Internal Server Error"
+//	@Router			/authors/{id} [delete]
+func (h *HttpAuthorHandler) Delete(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	if err := h.authorSvc.Delete(c.UserContext(), uint(id)); err != nil {
+		return err
+	}
+
+	return c.JSON(domain.Message{
+		Code:    fiber.StatusOK,
+		Message: "Success delete author",
+	})
+}
@@ -0,0 +1,476 @@
+package author
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/go-faker/faker/v4"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/internal/middleware/editor"
+	"go-clean-architecture/mocks"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestHttpAuthorHandler_Fetch(t *testing.T) {
+	var mockAuthor domain.Author
+	var mockAuthor2 domain.Author
+	err := faker.FakeData(&mockAuthor)
+	assert.NoError(t, err)
+	err = faker.FakeData(&mockAuthor2)
+	assert.NoError(t, err)
+	mockService := new(mocks.AuthorService)
+	mockListAuthor := make([]*domain.Author, 0)
+	mockListAuthor = append(mockListAuthor, &mockAuthor, &mockAuthor2)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Fetch", mock.Anything, uint(1), uint(1), &domain.Author{}).
+			Return(mockListAuthor, uint(2), nil).Once()
+		mockService.On("Count", mock.Anything, &domain.Author{}).
+			Return(int64(2), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?page=1&size=1", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("X-Cursor"))
+		assert.Equal(t, "2", resp.Header.Get("X-Total-Count"))
+		assert.Equal(t, "2", resp.Header.Get("X-Max-Page"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success with search", func(t *testing.T) {
+		mockService.On("Fetch", mock.Anything, uint(1), uint(1), &domain.Author{Name: mockAuthor.Name}).
+			Return(mockListAuthor, uint(2), nil).Once()
+		mockService.On("Count", mock.Anything, &domain.Author{Name: mockAuthor.Name}).
+			Return(int64(2), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/?page=1&size=1&q="+mockAuthor.Name, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(bodyBytes), mockAuthor.Name)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success with no data", func(t *testing.T) {
+		mockNewService := new(mocks.AuthorService)
+		mockNewService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.Author{}).
+			Return(nil, uint(0), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockNewService, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(bodyBytes), "[]")
+		mockNewService.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockNewService := new(mocks.AuthorService)
+		mockNewService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.Author{}).
+			Return(nil, uint(0), errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockNewService, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockNewService.AssertExpectations(t)
+	})
+
+	t.Run("error total item", func(t *testing.T) {
+		mockNewService := new(mocks.AuthorService)
+		mockNewService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.Author{}).
+			Return(mockListAuthor, uint(2), nil).Once()
+		mockNewService.On("Count", mock.Anything, &domain.Author{}).
+			Return(int64(0), errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockNewService, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockNewService.AssertExpectations(t)
+	})
+}
+
+func TestHttpAuthorHandler_Fetch_WithErrorSize(t *testing.T) {
+	app := fiber.New()
+	NewHttpHandler(app, nil, nil, config.Config{})
+	resp, err := app.Test(httptest.NewRequest("GET", "/?page=1&size=0", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestHttpAuthorHandler_Fetch_WithErrorPage(t *testing.T) {
+	app := fiber.New()
+	NewHttpHandler(app, nil, nil, config.Config{})
+	resp, err := app.Test(httptest.NewRequest("GET", "/?page=0&size=1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestHttpAuthorHandler_Fetch_WithErrorMaxSize(t *testing.T) {
+	app := fiber.New()
+	NewHttpHandler(app, nil, nil, config.Config{Pagination: config.Pagination{MaxSize: 50}})
+	resp, err := app.Test(httptest.NewRequest("GET", "/?size=51", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
+func TestHttpAuthorHandler_GetByID(t *testing.T) {
+	var mockAuthor domain.Author
+	err := faker.FakeData(&mockAuthor)
+	assert.NoError(t, err)
+	mockService := new(mocks.AuthorService)
+	id := strconv.Itoa(int(mockAuthor.ID))
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("GetByID", mock.Anything, mockAuthor.ID).
+			Return(&mockAuthor, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/"+id, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService.On("GetByID", mock.Anything, mockAuthor.ID).
+			Return(nil, fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/"+id, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/abc", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpAuthorHandler_Store(t *testing.T) {
+	mockAuthorStoreRequest := domain.AuthorStoreRequest{Name: faker.Name()}
+	mockAuthor := &domain.Author{Name: mockAuthorStoreRequest.Name}
+	mockService := new(mocks.AuthorService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Store", mock.Anything, mockAuthor).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		bodyRequest, err := json.Marshal(mockAuthorStoreRequest)
+		assert.NoError(t, err)
+		req := httptest.NewRequest("POST", "/", bytes.NewReader(bodyRequest))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 201, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-validation", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		req := httptest.NewRequest("POST", "/", bytes.NewReader([]byte(`{"name":""}`)))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockService.On("Store", mock.Anything, mockAuthor).
+			Return(errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		bodyRequest, err := json.Marshal(mockAuthorStoreRequest)
+		assert.NoError(t, err)
+		req := httptest.NewRequest("POST", "/", bytes.NewReader(bodyRequest))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpAuthorHandler_Update(t *testing.T) {
+	mockAuthorUpdateRequest := domain.AuthorUpdateRequest{Name: faker.Name()}
+	mockAuthor := &domain.Author{ID: 1, Name: mockAuthorUpdateRequest.Name}
+	mockService := new(mocks.AuthorService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Update", mock.Anything, mockAuthor).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		bodyRequest, err := json.Marshal(mockAuthorUpdateRequest)
+		assert.NoError(t, err)
+		req := httptest.NewRequest("PUT", "/1", bytes.NewReader(bodyRequest))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService.On("Update", mock.Anything, mockAuthor).
+			Return(fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		bodyRequest, err := json.Marshal(mockAuthorUpdateRequest)
+		assert.NoError(t, err)
+		req := httptest.NewRequest("PUT", "/1", bytes.NewReader(bodyRequest))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		bodyRequest, err := json.Marshal(mockAuthorUpdateRequest)
+		assert.NoError(t, err)
+		req := httptest.NewRequest("PUT", "/abc", bytes.NewReader(bodyRequest))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpAuthorHandler_Delete(t *testing.T) {
+	mockService := new(mocks.AuthorService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Delete", mock.Anything, uint(1)).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/1", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		mockService.On("Delete", mock.Anything, uint(1)).
+			Return(fiber.NewError(fiber.StatusConflict, "author still has articles")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/1", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 409, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/abc", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpAuthorHandler_FetchArticles(t *testing.T) {
+	var mockArticle domain.Article
+	err := faker.FakeData(&mockArticle)
+	assert.NoError(t, err)
+	mockListArticle := []*domain.Article{&mockArticle}
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorService := new(mocks.AuthorService)
+		mockArticleService := new(mocks.ArticleService)
+		mockAuthorService.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{ID: 1}, nil).Once()
+		mockArticleService.On("Fetch", mock.Anything, uint(1), uint(1), &domain.ArticleFilter{AuthorID: 1, Status: domain.ArticleStatusPublished}).
+			Return(mockListArticle, uint(2), nil).Once()
+		mockArticleService.On("Count", mock.Anything, &domain.ArticleFilter{AuthorID: 1, Status: domain.ArticleStatusPublished}).
+			Return(int64(2), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockAuthorService, mockArticleService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/articles?page=1&size=1", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "2", resp.Header.Get("X-Cursor"))
+		assert.Equal(t, "2", resp.Header.Get("X-Total-Count"))
+		assert.Equal(t, "2", resp.Header.Get("X-Max-Page"))
+		mockAuthorService.AssertExpectations(t)
+		mockArticleService.AssertExpectations(t)
+	})
+
+	t.Run("success with no data", func(t *testing.T) {
+		mockAuthorService := new(mocks.AuthorService)
+		mockArticleService := new(mocks.ArticleService)
+		mockAuthorService.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{ID: 1}, nil).Once()
+		mockArticleService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{AuthorID: 1, Status: domain.ArticleStatusPublished}).
+			Return(nil, uint(0), nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockAuthorService, mockArticleService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/articles", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		bodyBytes, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Contains(t, string(bodyBytes), "[]")
+		mockAuthorService.AssertExpectations(t)
+		mockArticleService.AssertExpectations(t)
+	})
+
+	t.Run("author not found", func(t *testing.T) {
+		mockAuthorService := new(mocks.AuthorService)
+		mockAuthorService.On("GetByID", mock.Anything, uint(1)).
+			Return(nil, fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockAuthorService, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/articles", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockAuthorService.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockAuthorService := new(mocks.AuthorService)
+		mockArticleService := new(mocks.ArticleService)
+		mockAuthorService.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{ID: 1}, nil).Once()
+		mockArticleService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{AuthorID: 1, Status: domain.ArticleStatusPublished}).
+			Return(nil, uint(0), errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockAuthorService, mockArticleService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/articles", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockArticleService.AssertExpectations(t)
+	})
+
+	t.Run("error total item", func(t *testing.T) {
+		mockAuthorService := new(mocks.AuthorService)
+		mockArticleService := new(mocks.ArticleService)
+		mockAuthorService.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{ID: 1}, nil).Once()
+		mockArticleService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{AuthorID: 1, Status: domain.ArticleStatusPublished}).
+			Return(mockListArticle, uint(2), nil).Once()
+		mockArticleService.On("Count", mock.Anything, &domain.ArticleFilter{AuthorID: 1, Status: domain.ArticleStatusPublished}).
+			Return(int64(0), errors.New("unexpected Error")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockAuthorService, mockArticleService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/articles", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockArticleService.AssertExpectations(t)
+	})
+
+	t.Run("error-parsing-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, nil, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/abc/articles", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-page", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, nil, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/articles?page=0", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-size", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, nil, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/articles?size=0", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-search", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, nil, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/articles?q=%3F%21", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-status", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, nil, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/articles?status=unknown", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-status-not-editor", func(t *testing.T) {
+		app := fiber.New()
+		app.Use(editor.New())
+		NewHttpHandler(app, nil, nil, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/1/articles?status=draft", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 403, resp.StatusCode)
+	})
+
+	t.Run("success-all-status", func(t *testing.T) {
+		mockAuthorService := new(mocks.AuthorService)
+		mockArticleService := new(mocks.ArticleService)
+		mockAuthorService.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{ID: 1}, nil).Once()
+		mockArticleService.On("Fetch", mock.Anything, uint(1), uint(10), &domain.ArticleFilter{AuthorID: 1}).
+			Return(nil, uint(0), nil).Once()
+
+		app := fiber.New()
+		app.Use(editor.New())
+		NewHttpHandler(app, mockAuthorService, mockArticleService, config.Config{})
+		req := httptest.NewRequest("GET", "/1/articles?status=all", nil)
+		req.Header.Set(editor.HeaderEditor, "jane")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockAuthorService.AssertExpectations(t)
+		mockArticleService.AssertExpectations(t)
+	})
+}
@@ -1,6 +1,7 @@
 package author
 
 import (
+	"context"
 	"go-clean-architecture/internal/domain"
 	"gorm.io/gorm"
 )
@@ -13,22 +14,58 @@ func NewMysqlAuthorRepository(db *gorm.DB) domain.AuthorRepository {
 	return &mysqlAuthorRepository{db: db}
 }
 
-func (r *mysqlAuthorRepository) GetByID(id uint) (*domain.Author, error) {
+func (r *mysqlAuthorRepository) Fetch(ctx context.Context, page uint, size uint, filter *domain.Author) ([]*domain.Author, uint, error) {
+	var authors []*domain.Author
+
+	offset := (page - 1) * size
+	query := r.db.WithContext(ctx)
+
+	if filter.Name != "" {
+		query = query.Where("name LIKE ?", "%"+filter.Name+"%")
+	}
+
+	if err := query.Order("created_at DESC").Offset(int(offset)).Limit(int(size)).Find(&authors).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var nextCursor uint
+	if len(authors) > 0 {
+		nextCursor = page + 1 // next page
+	}
+
+	return authors, nextCursor, nil
+}
+
+func (r *mysqlAuthorRepository) GetByID(ctx context.Context, id uint) (*domain.Author, error) {
 	var author domain.Author
-	if err := r.db.First(&author, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&author, id).Error; err != nil {
 		return nil, err
 	}
 	return &author, nil
 }
 
-func (r *mysqlAuthorRepository) Count() (int64, error) {
+func (r *mysqlAuthorRepository) Count(ctx context.Context, filter *domain.Author) (int64, error) {
 	var count int64
-	if err := r.db.Model(&domain.Author{}).Count(&count).Error; err != nil {
+	query := r.db.WithContext(ctx).Model(&domain.Author{})
+
+	if filter.Name != "" {
+		query = query.Where("name LIKE ?", "%"+filter.Name+"%")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return count, nil
 }
 
-func (r *mysqlAuthorRepository) Store(author *domain.Author) error {
-	return r.db.Create(author).Error
+func (r *mysqlAuthorRepository) Store(ctx context.Context, author *domain.Author) error {
+	return r.db.WithContext(ctx).Create(author).Error
+}
+
+func (r *mysqlAuthorRepository) Update(ctx context.Context, author *domain.Author) error {
+	return r.db.WithContext(ctx).Updates(author).Error
+}
+
+func (r *mysqlAuthorRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.Author{}, id).Error
 }
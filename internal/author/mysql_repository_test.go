@@ -1,6 +1,7 @@
 package author
 
 import (
+	"context"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"go-clean-architecture/internal/domain"
@@ -44,7 +45,7 @@ func TestMysqlAuthorRepository_GetByID(t *testing.T) {
 	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(userID, 1).WillReturnRows(rows)
 
 	repo := NewMysqlAuthorRepository(db)
-	author, err := repo.GetByID(userID)
+	author, err := repo.GetByID(context.Background(), userID)
 	assert.NoError(t, err)
 	assert.NotNil(t, author)
 }
@@ -59,7 +60,7 @@ func TestMysqlAuthorRepository_GetByID_NotFound(t *testing.T) {
 	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(userID).WillReturnError(gorm.ErrRecordNotFound)
 
 	repo := NewMysqlAuthorRepository(db)
-	author, err := repo.GetByID(userID)
+	author, err := repo.GetByID(context.Background(), userID)
 	assert.Error(t, err)
 	assert.Nil(t, author)
 }
@@ -75,7 +76,7 @@ func TestMysqlAuthorRepository_Count(t *testing.T) {
 	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
 
 	repo := NewMysqlAuthorRepository(db)
-	count, err := repo.Count()
+	count, err := repo.Count(context.Background(), &domain.Author{})
 	assert.NoError(t, err)
 	assert.Equal(t, int64(10), count)
 }
@@ -89,7 +90,7 @@ func TestMysqlAuthorRepository_Count_Error(t *testing.T) {
 	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(gorm.ErrInvalidDB)
 
 	repo := NewMysqlAuthorRepository(db)
-	count, err := repo.Count()
+	count, err := repo.Count(context.Background(), &domain.Author{})
 	assert.Error(t, err)
 	assert.Equal(t, int64(0), count)
 }
@@ -111,6 +112,102 @@ func TestMysqlAuthorRepository_Store(t *testing.T) {
 
 	repo := NewMysqlAuthorRepository(db)
 
-	err = repo.Store(author)
+	err = repo.Store(context.Background(), author)
+	assert.NoError(t, err)
+}
+
+func TestMysqlAuthorRepository_Count_WithFilter(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT count(*) FROM `authors` WHERE name LIKE ?"
+
+	expectedName := "author"
+	rows := sqlmock.NewRows([]string{"count"}).
+		AddRow(3)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("%" + expectedName + "%").
+		WillReturnRows(rows)
+
+	repo := NewMysqlAuthorRepository(db)
+	count, err := repo.Count(context.Background(), &domain.Author{Name: expectedName})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestMysqlAuthorRepository_Fetch(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `authors` WHERE name LIKE ? ORDER BY created_at DESC LIMIT ?"
+
+	expectedName := "author"
+	rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+		AddRow(1, "Author 1", time.Now(), time.Now())
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("%"+expectedName+"%", 10).
+		WillReturnRows(rows)
+
+	repo := NewMysqlAuthorRepository(db)
+	authors, nextCursor, err := repo.Fetch(context.Background(), 1, 10, &domain.Author{Name: expectedName})
+	assert.NoError(t, err)
+	assert.Len(t, authors, 1)
+	assert.Equal(t, uint(2), nextCursor)
+}
+
+func TestMysqlAuthorRepository_Fetch_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `authors` ORDER BY created_at DESC LIMIT ?"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(10).
+		WillReturnError(assert.AnError)
+
+	repo := NewMysqlAuthorRepository(db)
+	authors, _, err := repo.Fetch(context.Background(), 1, 10, &domain.Author{})
+	assert.Error(t, err)
+	assert.Nil(t, authors)
+}
+
+func TestMysqlAuthorRepository_Update(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `authors` SET `name`=?,`updated_at`=? WHERE `id` = ?"
+
+	author := &domain.Author{
+		ID:   1,
+		Name: "Author 1",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(author.Name, sqlmock.AnyArg(), author.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlAuthorRepository(db)
+	err = repo.Update(context.Background(), author)
+	assert.NoError(t, err)
+}
+
+func TestMysqlAuthorRepository_Delete(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "DELETE FROM `authors` WHERE `authors`.`id` = ?"
+
+	expectedID := uint(1)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(expectedID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlAuthorRepository(db)
+	err = repo.Delete(context.Background(), expectedID)
 	assert.NoError(t, err)
 }
@@ -0,0 +1,81 @@
+package author
+
+import (
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+)
+
+type authorService struct {
+	authorRepo  domain.AuthorRepository
+	articleRepo domain.ArticleRepository
+}
+
+func NewAuthorService(author domain.AuthorRepository, article domain.ArticleRepository) domain.AuthorService {
+	return &authorService{
+		authorRepo:  author,
+		articleRepo: article,
+	}
+}
+
+func (a *authorService) Fetch(ctx context.Context, page uint, size uint, filter *domain.Author) ([]*domain.Author, uint, error) {
+	authors, nextCursor, err := a.authorRepo.Fetch(ctx, page, size, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return authors, nextCursor, nil
+}
+
+func (a *authorService) GetByID(ctx context.Context, id uint) (*domain.Author, error) {
+	author, err := a.authorRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return author, nil
+}
+
+func (a *authorService) Count(ctx context.Context, filter *domain.Author) (int64, error) {
+	count, err := a.authorRepo.Count(ctx, filter)
+	return count, err
+}
+
+func (a *authorService) Store(ctx context.Context, author *domain.Author) error {
+	return a.authorRepo.Store(ctx, author)
+}
+
+func (a *authorService) Update(ctx context.Context, author *domain.Author) error {
+	existing, err := a.GetByID(ctx, author.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.authorRepo.Update(ctx, author); err != nil {
+		return err
+	}
+
+	author.CreatedAt = existing.CreatedAt
+	return nil
+}
+
+func (a *authorService) Delete(ctx context.Context, id uint) error {
+	if _, err := a.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	articles, err := a.articleRepo.Count(ctx, &domain.ArticleFilter{AuthorID: id})
+	if err != nil {
+		return err
+	}
+	if articles > 0 {
+		return fiber.NewError(fiber.StatusConflict, "author still has articles")
+	}
+
+	return a.authorRepo.Delete(ctx, id)
+}
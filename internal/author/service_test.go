@@ -0,0 +1,224 @@
+package author
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/mocks"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestAuthorService_Fetch(t *testing.T) {
+	mockAuthorRepository := new(mocks.AuthorRepository)
+	mocksAuthorList := make([]*domain.Author, 0)
+	mocksAuthorList = append(mocksAuthorList, &domain.Author{
+		ID:   1,
+		Name: "Author 1",
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorRepository.On("Fetch", mock.Anything, uint(1), uint(10), &domain.Author{}).
+			Return(mocksAuthorList, uint(2), nil).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, nil)
+		authors, nextCursor, err := authorSvc.Fetch(context.Background(), uint(1), uint(10), &domain.Author{})
+		assert.NoError(t, err)
+		assert.NotNil(t, authors)
+		assert.Equal(t, uint(2), nextCursor)
+
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockAuthorRepository.On("Fetch", mock.Anything, uint(1), uint(10), &domain.Author{}).
+			Return(nil, uint(0), assert.AnError).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, nil)
+		authors, nextCursor, err := authorSvc.Fetch(context.Background(), uint(1), uint(10), &domain.Author{})
+		assert.Error(t, err)
+		assert.Nil(t, authors)
+		assert.Equal(t, uint(0), nextCursor)
+	})
+}
+
+func TestAuthorService_GetByID(t *testing.T) {
+	mockAuthorRepository := new(mocks.AuthorRepository)
+	mockAuthor := &domain.Author{
+		ID:   1,
+		Name: "Author 1",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(mockAuthor, nil).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, nil)
+		author, err := authorSvc.GetByID(context.Background(), uint(1))
+		assert.NoError(t, err)
+		assert.NotNil(t, author)
+
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, nil)
+		author, err := authorSvc.GetByID(context.Background(), uint(1))
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, author)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(nil, assert.AnError).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, nil)
+		author, err := authorSvc.GetByID(context.Background(), uint(1))
+		assert.Error(t, err)
+		assert.Nil(t, author)
+	})
+}
+
+func TestAuthorService_Count(t *testing.T) {
+	mockAuthorRepository := new(mocks.AuthorRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorRepository.On("Count", mock.Anything, &domain.Author{}).
+			Return(int64(10), nil).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, nil)
+		count, err := authorSvc.Count(context.Background(), &domain.Author{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), count)
+
+		mockAuthorRepository.AssertExpectations(t)
+	})
+}
+
+func TestAuthorService_Store(t *testing.T) {
+	mockAuthorRepository := new(mocks.AuthorRepository)
+	mockAuthor := &domain.Author{Name: "Author 1"}
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorRepository.On("Store", mock.Anything, mockAuthor).
+			Return(nil).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, nil)
+		err := authorSvc.Store(context.Background(), mockAuthor)
+		assert.NoError(t, err)
+
+		mockAuthorRepository.AssertExpectations(t)
+	})
+}
+
+func TestAuthorService_Update(t *testing.T) {
+	mockAuthorRepository := new(mocks.AuthorRepository)
+	mockAuthor := &domain.Author{
+		ID:   1,
+		Name: "Author 1",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{ID: 1, Name: "Old"}, nil).Once()
+		mockAuthorRepository.On("Update", mock.Anything, mockAuthor).
+			Return(nil).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, nil)
+		err := authorSvc.Update(context.Background(), mockAuthor)
+		assert.NoError(t, err)
+
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, nil)
+		err := authorSvc.Update(context.Background(), mockAuthor)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{ID: 1, Name: "Old"}, nil).Once()
+		mockAuthorRepository.On("Update", mock.Anything, mockAuthor).
+			Return(assert.AnError).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, nil)
+		err := authorSvc.Update(context.Background(), mockAuthor)
+		assert.Error(t, err)
+
+		mockAuthorRepository.AssertExpectations(t)
+	})
+}
+
+func TestAuthorService_Delete(t *testing.T) {
+	mockAuthorRepository := new(mocks.AuthorRepository)
+	mockArticleRepository := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{ID: 1}, nil).Once()
+		mockArticleRepository.On("Count", mock.Anything, &domain.ArticleFilter{AuthorID: 1}).
+			Return(int64(0), nil).Once()
+		mockAuthorRepository.On("Delete", mock.Anything, uint(1)).
+			Return(nil).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, mockArticleRepository)
+		err := authorSvc.Delete(context.Background(), uint(1))
+		assert.NoError(t, err)
+
+		mockAuthorRepository.AssertExpectations(t)
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, mockArticleRepository)
+		err := authorSvc.Delete(context.Background(), uint(1))
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-has-articles", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{ID: 1}, nil).Once()
+		mockArticleRepository.On("Count", mock.Anything, &domain.ArticleFilter{AuthorID: 1}).
+			Return(int64(1), nil).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, mockArticleRepository)
+		err := authorSvc.Delete(context.Background(), uint(1))
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusConflict, fiberErr.Code)
+
+		mockAuthorRepository.AssertExpectations(t)
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-articles-failed", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{ID: 1}, nil).Once()
+		mockArticleRepository.On("Count", mock.Anything, &domain.ArticleFilter{AuthorID: 1}).
+			Return(int64(0), assert.AnError).Once()
+
+		authorSvc := NewAuthorService(mockAuthorRepository, mockArticleRepository)
+		err := authorSvc.Delete(context.Background(), uint(1))
+		assert.Error(t, err)
+
+		mockAuthorRepository.AssertExpectations(t)
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,162 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-clean-architecture/internal/domain"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// uploadPattern names the temporary files of blobs being written.
+const uploadPattern = ".upload-*"
+
+// listPageSize is the number of blobs passed at once by List.
+const listPageSize = 1000
+
+type localBlobStore struct {
+	root string
+}
+
+// NewLocalBlobStore returns a blob store keeping each blob in a file under
+// root, which is created when missing.
+func NewLocalBlobStore(root string) (domain.BlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &localBlobStore{root: root}, nil
+}
+
+// path returns the file of key, refusing keys that would escape the root.
+func (s *localBlobStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || !fs.ValidPath(key) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// Put writes the blob to a temporary file first, so that a blob is never
+// seen half written.
+func (s *localBlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), uploadPattern)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, body); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+func (s *localBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (s *localBlobStore) Exists(ctx context.Context, key string) (bool, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *localBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return domain.ErrBlobNotFound
+	}
+	return err
+}
+
+func (s *localBlobStore) DeleteDir(ctx context.Context, dir string) error {
+	path, err := s.path(dir)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
+
+// List walks the files under root in lexical order, leaving out the
+// temporary files of blobs being written.
+func (s *localBlobStore) List(ctx context.Context, prefix string, fn func(blobs []*domain.BlobInfo) error) error {
+	var blobs []*domain.BlobInfo
+	err := filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), strings.TrimSuffix(uploadPattern, "*")) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		blobs = append(blobs, &domain.BlobInfo{Key: key, ModifiedAt: info.ModTime()})
+		if len(blobs) < listPageSize {
+			return nil
+		}
+		page := blobs
+		blobs = nil
+		return fn(page)
+	})
+	if err != nil || len(blobs) == 0 {
+		return err
+	}
+	return fn(blobs)
+}
@@ -0,0 +1,108 @@
+package blob
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	ctx := context.Background()
+	root := filepath.Join(t.TempDir(), "blobs")
+	store, err := NewLocalBlobStore(root)
+	assert.NoError(t, err)
+
+	exists, err := store.Exists(ctx, "ab/cd/abcd")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.NoError(t, store.Put(ctx, "ab/cd/abcd", strings.NewReader("hello"), 5, "text/plain"))
+
+	exists, err = store.Exists(ctx, "ab/cd/abcd")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	body, err := store.Get(ctx, "ab/cd/abcd")
+	assert.NoError(t, err)
+	content, err := io.ReadAll(body)
+	assert.NoError(t, err)
+	assert.NoError(t, body.Close())
+	assert.Equal(t, "hello", string(content))
+
+	// no temporary file is left behind
+	entries, err := os.ReadDir(filepath.Join(root, "ab", "cd"))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	assert.NoError(t, store.Delete(ctx, "ab/cd/abcd"))
+	assert.ErrorIs(t, store.Delete(ctx, "ab/cd/abcd"), domain.ErrBlobNotFound)
+
+	_, err = store.Get(ctx, "ab/cd/abcd")
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+}
+
+func TestLocalBlobStore_DeleteDir(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"variants/abcd/small-10x10", "variants/abcd/large-90x90", "variants/abcde/small-10x10"} {
+		assert.NoError(t, store.Put(ctx, key, strings.NewReader("x"), 1, ""))
+	}
+	assert.NoError(t, store.DeleteDir(ctx, "variants/abcd"))
+	assert.NoError(t, store.DeleteDir(ctx, "variants/missing"))
+
+	for key, exists := range map[string]bool{
+		"variants/abcd/small-10x10":  false,
+		"variants/abcd/large-90x90":  false,
+		"variants/abcde/small-10x10": true,
+	} {
+		found, err := store.Exists(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, exists, found, key)
+	}
+}
+
+func TestLocalBlobStore_List(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store, err := NewLocalBlobStore(root)
+	assert.NoError(t, err)
+	for _, key := range []string{"ab/cd/abcd", "ab/ef/abef", "variants/abcd/small-10x10"} {
+		assert.NoError(t, store.Put(ctx, key, strings.NewReader("x"), 1, ""))
+	}
+	// a blob still being written is left out
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "ab", "cd", ".upload-123"), []byte("x"), 0o644))
+
+	var keys []string
+	err = store.List(ctx, "ab/", func(blobs []*domain.BlobInfo) error {
+		for _, blob := range blobs {
+			assert.WithinDuration(t, time.Now(), blob.ModifiedAt, time.Minute)
+			keys = append(keys, blob.Key)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ab/cd/abcd", "ab/ef/abef"}, keys)
+
+	err = store.List(ctx, "", func(blobs []*domain.BlobInfo) error { return assert.AnError })
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestLocalBlobStore_InvalidKey(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "a/../../secret"} {
+		assert.Error(t, store.Put(ctx, key, strings.NewReader("x"), 1, ""), key)
+		_, err := store.Get(ctx, key)
+		assert.Error(t, err, key)
+	}
+}
@@ -0,0 +1,301 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// emptyPayloadHash is the SHA-256 of an empty body.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	// unsignedPayload lets uploads be streamed without hashing them first.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+type s3BlobStore struct {
+	cfg    config.S3
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3BlobStore returns a blob store keeping each blob in an object of an
+// S3 compatible storage. Requests are signed with AWS Signature Version 4
+// and cut off after cfg.Timeout, since downloads outlive the request that
+// started them.
+func NewS3BlobStore(cfg config.S3) domain.BlobStore {
+	var timeout time.Duration
+	if cfg.Timeout > 0 {
+		timeout = cfg.Timeout
+	}
+	return &s3BlobStore{cfg: cfg, client: &http.Client{Timeout: timeout}, now: time.Now}
+}
+
+func (s *s3BlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	req, err := s.request(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req, unsignedPayload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return responseError(req, resp)
+	}
+	return nil
+}
+
+func (s *s3BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, domain.ErrBlobNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, responseError(req, resp)
+	}
+}
+
+func (s *s3BlobStore) Exists(ctx context.Context, key string) (bool, error) {
+	req, err := s.request(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, responseError(req, resp)
+	}
+}
+
+// Delete removes the object of key. S3 does not tell whether there was one.
+func (s *s3BlobStore) Delete(ctx context.Context, key string) error {
+	req, err := s.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return responseError(req, resp)
+	}
+	return nil
+}
+
+// listBucketResult is the part of a ListObjectsV2 response read by List.
+type listBucketResult struct {
+	Contents []struct {
+		Key          string
+		LastModified time.Time
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// DeleteDir lists the objects under dir and removes them one by one.
+func (s *s3BlobStore) DeleteDir(ctx context.Context, dir string) error {
+	return s.List(ctx, dir+"/", func(blobs []*domain.BlobInfo) error {
+		for _, blob := range blobs {
+			if err := s.Delete(ctx, blob.Key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// List lists the objects under prefix with ListObjectsV2, one page of up to
+// 1000 objects at a time.
+func (s *s3BlobStore) List(ctx context.Context, prefix string, fn func(blobs []*domain.BlobInfo) error) error {
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	endpoint := strings.TrimSuffix(s.cfg.Endpoint, "/")
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/"+uriEncode(s.cfg.Bucket, true)+"?"+canonicalQuery(query), nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.do(req, emptyPayloadHash)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := responseError(req, resp)
+			resp.Body.Close()
+			return err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		blobs := make([]*domain.BlobInfo, len(result.Contents))
+		for i, object := range result.Contents {
+			blobs[i] = &domain.BlobInfo{Key: object.Key, ModifiedAt: object.LastModified}
+		}
+		if len(blobs) > 0 {
+			if err := fn(blobs); err != nil {
+				return err
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// request returns a request for the object of key, addressed by path.
+func (s *s3BlobStore) request(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	endpoint := strings.TrimSuffix(s.cfg.Endpoint, "/")
+	return http.NewRequestWithContext(ctx, method, endpoint+"/"+uriEncode(s.cfg.Bucket, true)+"/"+uriEncode(key, false), body)
+}
+
+func (s *s3BlobStore) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.sign(req, payloadHash)
+	return s.client.Do(req)
+}
+
+// sign sets the Authorization header of req as described in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html.
+// The host, the range, the content type and the x-amz-* headers are signed.
+func (s *s3BlobStore) sign(req *http.Request, payloadHash string) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "range" || name == "content-type" || name == "content-md5" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.cfg.AccessKey+"/"+scope+
+		",SignedHeaders="+signedHeaders+",Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func canonicalQuery(query url.Values) string {
+	params := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// uriEncode percent-encodes every byte but the unreserved characters of RFC
+// 3986, and slashes unless encodeSlash is set, as expected by AWS.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func responseError(req *http.Request, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+}
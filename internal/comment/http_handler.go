@@ -0,0 +1,258 @@
+package comment
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/internal/middleware/validation"
+	"go-clean-architecture/internal/utilities"
+	"go-clean-architecture/pkg/xcursor"
+)
+
+type HttpCommentHandler struct {
+	commentSvc domain.CommentService
+	cfg        config.Config
+	cursor     *xcursor.Signer
+}
+
+func NewHttpHandler(r fiber.Router, commentSvc domain.CommentService, cfg config.Config) {
+	handler := &HttpCommentHandler{
+		commentSvc: commentSvc,
+		cfg:        cfg,
+		cursor:     xcursor.New([]byte(cfg.Pagination.CursorSecret)),
+	}
+	r.Get("/articles/:id/comments", handler.FetchThreads)
+	r.Post("/articles/:id/comments", rateLimit(cfg.Comment), validation.New[domain.CommentStoreRequest](), handler.Store)
+	r.Get("/comments", handler.Fetch)
+	r.Put("/comments/:id/status", validation.New[domain.CommentStatusRequest](), handler.Moderate)
+}
+
+// rateLimit limits the comments posted from one IP address.
+func rateLimit(cfg config.Comment) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Next: func(c *fiber.Ctx) bool {
+			return cfg.RateLimit <= 0
+		},
+		Max:        cfg.RateLimit,
+		Expiration: cfg.RateWindow,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(domain.Error{
+				Code:    fiber.StatusTooManyRequests,
+				Message: "too many comments, try again later",
+			})
+		},
+	})
+}
+
+// FetchThreads used to get the comments of an article
+//
+//	@Summary		Get comments of an article
+//	@Description	Get the approved comments of an article, oldest first, with their approved replies nested. Pages
+//	@Description	are threads: the next cursor is returned in X-Cursor and Link.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int				true	"Article ID"
+//	@Param			size	query		int				false	"Number of threads (default 10)"
+//	@Param			cursor	query		string			false	"Opaque cursor from a previous X-Cursor header"
+//	@Header			200		{string}	X-Cursor		"Next cursor"
+//	@Success		200		{array}		domain.Comment	"List of threads"
+//	@Failure		400		{object}	domain.Error	"Bad Request"
+//	@Failure		404		{object}	domain.Error	"Not Found"
+//	@Failure		500		{object}	domain.Error	"Internal Server Error"
+//	@Router			/articles/{id}/comments [get]
+func (h *HttpCommentHandler) FetchThreads(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	size, cursor, err := h.parsePage(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	comments, nextCursor, err := h.commentSvc.FetchThreads(c.UserContext(), uint(id), cursor, size)
+	if err != nil {
+		return err
+	}
+	return h.writePage(c, comments, nextCursor)
+}
+
+// Fetch used to get the moderation queue
+//
+//	@Summary		Get list of comments
+//	@Description	Get comments of every article, oldest first, without nesting replies. Used to moderate comments.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Param			status		query		string			false	"Status of the comments (default pending)"	Enums(pending, approved, rejected, spam, all)
+//	@Param			articleId	query		int				false	"Article ID"
+//	@Param			size		query		int				false	"Size of page (default 10)"
+//	@Param			cursor		query		string			false	"Opaque cursor from a previous X-Cursor header"
+//	@Header			200			{string}	X-Cursor		"Next cursor"
+//	@Success		200			{array}		domain.Comment	"List of comments"
+//	@Failure		400			{object}	domain.Error	"Bad Request"
+//	@Failure		500			{object}	domain.Error	"Internal Server Error"
+//	@Router			/comments [get]
+func (h *HttpCommentHandler) Fetch(c *fiber.Ctx) error {
+	status := domain.CommentStatus(c.Query("status", string(domain.CommentStatusPending)))
+	if status == domain.CommentStatusAll {
+		status = ""
+	} else if !status.Valid() {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "status must be one of pending, approved, rejected, spam or all",
+		})
+	}
+
+	articleID := c.QueryInt("articleId", 0)
+	if articleID < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: "articleId must be a positive integer",
+		})
+	}
+
+	size, cursor, err := h.parsePage(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	filter := &domain.CommentFilter{ArticleID: uint(articleID), Status: status}
+	comments, nextCursor, err := h.commentSvc.Fetch(c.UserContext(), filter, cursor, size)
+	if err != nil {
+		return err
+	}
+	return h.writePage(c, comments, nextCursor)
+}
+
+// Store used to comment an article
+//
+//	@Summary		Store comment
+//	@Description	Comment a published article, or reply to one of its approved comments with parentId. The comment
+//	@Description	is approved, held for moderation or marked as spam by the moderation rules. Each IP address may
+//	@Description	only post a limited number of comments at a time.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Article ID"
+//	@Param			comment	body		domain.CommentStoreRequest	true	"Comment data"
+//	@Success		201		{object}	domain.Comment				"Comment detail"
+//	@Failure		400		{object}	domain.Error				"Bad Request"
+//	@Failure		404		{object}	domain.Error				"Not Found"
+//	@Failure		409		{object}	domain.Error				"Article not published"
+//	@Failure		429		{object}	domain.Error				"Too Many Requests"
+//	@Failure		500		{object}	domain.Error				"Internal Server Error"
+//	@Router			/articles/{id}/comments [post]
+func (h *HttpCommentHandler) Store(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	commentReq := utilities.ExtractStructFromValidator[domain.CommentStoreRequest](c)
+
+	comment := &domain.Comment{
+		ArticleID:   uint(id),
+		ParentID:    commentReq.ParentID,
+		AuthorName:  commentReq.AuthorName,
+		AuthorEmail: commentReq.AuthorEmail,
+		Content:     commentReq.Content,
+		IP:          c.IP(),
+	}
+
+	if err := h.commentSvc.Store(c.UserContext(), comment); err != nil {
+		return err
+	}
+
+	c.Status(fiber.StatusCreated)
+	return c.JSON(comment)
+}
+
+// Moderate used to change the status of a comment
+//
+//	@Summary		Moderate comment
+//	@Description	Approve, reject or mark a comment as spam, or put it back in the moderation queue. Only approved
+//	@Description	comments are shown on their article.
+//	@Tags			comments
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Comment ID"
+//	@Param			status	body		domain.CommentStatusRequest	true	"New status"
+//	@Success		200		{object}	domain.Comment				"Comment detail"
+//	@Failure		400		{object}	domain.Error				"Bad Request"
+//	@Failure		404		{object}	domain.Error				"Not Found"
+//	@Failure		500		{object}	domain.Error				"Internal Server Error"
+//	@Router			/comments/{id}/status [put]
+func (h *HttpCommentHandler) Moderate(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	statusReq := utilities.ExtractStructFromValidator[domain.CommentStatusRequest](c)
+
+	comment, err := h.commentSvc.Moderate(c.UserContext(), uint(id), statusReq.Status)
+	if err != nil {
+		return err
+	}
+	return c.JSON(comment)
+}
+
+// parsePage reads the size and cursor query parameters.
+func (h *HttpCommentHandler) parsePage(c *fiber.Ctx) (uint, *domain.CommentCursor, error) {
+	size := c.QueryInt("size", 10)
+	if size <= 0 {
+		return 0, nil, errors.New("size must be a positive integer")
+	}
+	if maxSize := h.cfg.Pagination.MaxSize; maxSize > 0 && size > maxSize {
+		return 0, nil, fmt.Errorf("size must not be greater than %d", maxSize)
+	}
+
+	token := c.Query("cursor")
+	if token == "" {
+		return uint(size), nil, nil
+	}
+	cursor := &domain.CommentCursor{}
+	if err := h.cursor.Decode(token, cursor); err != nil {
+		return 0, nil, err
+	}
+	return uint(size), cursor, nil
+}
+
+func (h *HttpCommentHandler) writePage(c *fiber.Ctx, comments []*domain.Comment, nextCursor *domain.CommentCursor) error {
+	if nextCursor != nil {
+		token, err := h.cursor.Encode(nextCursor)
+		if err != nil {
+			return err
+		}
+		utilities.SetCursorHeaders(c, token)
+	}
+
+	if comments == nil {
+		return c.JSON([]domain.Comment{})
+	}
+	return c.JSON(comments)
+}
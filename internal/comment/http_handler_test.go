@@ -0,0 +1,234 @@
+package comment
+
+import (
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/mocks"
+	"go-clean-architecture/pkg/xcursor"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHttpCommentHandler_FetchThreads(t *testing.T) {
+	mockService := new(mocks.CommentService)
+	cfg := config.Config{Pagination: config.Pagination{CursorSecret: "secret", MaxSize: 50}}
+	signer := xcursor.New([]byte(cfg.Pagination.CursorSecret))
+
+	t.Run("success", func(t *testing.T) {
+		threads := []*domain.Comment{
+			{ID: 1, ArticleID: 1, Content: "First", Replies: []*domain.Comment{{ID: 2, ArticleID: 1, Content: "Reply"}}},
+		}
+		nextCursor := &domain.CommentCursor{CreatedAt: time.Unix(1700000000, 0).UTC(), ID: 1}
+		mockService.On("FetchThreads", mock.Anything, uint(1), (*domain.CommentCursor)(nil), uint(1)).
+			Return(threads, nextCursor, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/articles/1/comments?size=1", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var decoded domain.CommentCursor
+		assert.NoError(t, signer.Decode(resp.Header.Get("X-Cursor"), &decoded))
+		assert.Equal(t, nextCursor.ID, decoded.ID)
+
+		var comments []domain.Comment
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&comments))
+		assert.Len(t, comments, 1)
+		assert.Len(t, comments[0].Replies, 1)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-cursor", func(t *testing.T) {
+		cursor := &domain.CommentCursor{CreatedAt: time.Unix(1700000000, 0).UTC(), ID: 1}
+		token, err := signer.Encode(cursor)
+		assert.NoError(t, err)
+		mockService.On("FetchThreads", mock.Anything, uint(1), cursor, uint(10)).
+			Return(nil, nil, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/articles/1/comments?cursor="+token, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("X-Cursor"))
+
+		var comments []domain.Comment
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&comments))
+		assert.NotNil(t, comments)
+		assert.Empty(t, comments)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-invalid-cursor", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/articles/1/comments?cursor=invalid", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-size-too-large", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/articles/1/comments?size=51", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("FetchThreads", mock.Anything, uint(2), (*domain.CommentCursor)(nil), uint(10)).
+			Return(nil, nil, fiber.NewError(fiber.StatusNotFound, "article not found")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/articles/2/comments", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpCommentHandler_Fetch(t *testing.T) {
+	mockService := new(mocks.CommentService)
+
+	t.Run("success", func(t *testing.T) {
+		filter := &domain.CommentFilter{Status: domain.CommentStatusPending}
+		mockService.On("Fetch", mock.Anything, filter, (*domain.CommentCursor)(nil), uint(10)).
+			Return([]*domain.Comment{{ID: 1}}, nil, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/comments", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-all-statuses", func(t *testing.T) {
+		filter := &domain.CommentFilter{ArticleID: 3}
+		mockService.On("Fetch", mock.Anything, filter, (*domain.CommentCursor)(nil), uint(10)).
+			Return(nil, nil, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/comments?status=all&articleId=3", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-invalid-status", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		resp, err := app.Test(httptest.NewRequest("GET", "/comments?status=deleted", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+}
+
+func TestHttpCommentHandler_Store(t *testing.T) {
+	mockService := new(mocks.CommentService)
+	body := `{"parentId":2,"authorName":"Ana","authorEmail":"ana@example.com","content":"Nice article"}`
+	isComment := mock.MatchedBy(func(comment *domain.Comment) bool {
+		return comment.ArticleID == 1 && *comment.ParentID == 2 && comment.AuthorEmail == "ana@example.com" &&
+			comment.Content == "Nice article" && comment.IP != ""
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Store", mock.Anything, isComment).
+			Run(func(args mock.Arguments) {
+				comment := args.Get(1).(*domain.Comment)
+				comment.ID = 3
+				comment.Status = domain.CommentStatusPending
+			}).
+			Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/articles/1/comments", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 201, resp.StatusCode)
+
+		var comment map[string]any
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&comment))
+		assert.Equal(t, "pending", comment["status"])
+		assert.NotContains(t, comment, "authorEmail")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-validation", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("POST", "/articles/1/comments", strings.NewReader(`{"authorName":"Ana","authorEmail":"ana","content":"Nice article"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-rate-limit", func(t *testing.T) {
+		mockService.On("Store", mock.Anything, isComment).Return(nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{Comment: config.Comment{RateLimit: 1, RateWindow: time.Minute}})
+		for _, status := range []int{201, 429} {
+			req := httptest.NewRequest("POST", "/articles/1/comments", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, status, resp.StatusCode)
+		}
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpCommentHandler_Moderate(t *testing.T) {
+	mockService := new(mocks.CommentService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Moderate", mock.Anything, uint(1), domain.CommentStatusApproved).
+			Return(&domain.Comment{ID: 1, Status: domain.CommentStatusApproved}, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("PUT", "/comments/1/status", strings.NewReader(`{"status":"approved"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-invalid-status", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("PUT", "/comments/1/status", strings.NewReader(`{"status":"all"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("Moderate", mock.Anything, uint(9), domain.CommentStatusSpam).
+			Return(nil, fiber.ErrNotFound).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, config.Config{})
+		req := httptest.NewRequest("PUT", "/comments/9/status", strings.NewReader(`{"status":"spam"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,112 @@
+package comment
+
+import (
+	"context"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+)
+
+type mysqlCommentRepository struct {
+	db *gorm.DB
+}
+
+func NewMysqlCommentRepository(db *gorm.DB) domain.CommentRepository {
+	return &mysqlCommentRepository{db: db}
+}
+
+// Fetch returns the comments matching filter, oldest first, after cursor.
+func (r *mysqlCommentRepository) Fetch(ctx context.Context, filter *domain.CommentFilter, cursor *domain.CommentCursor, size uint) ([]*domain.Comment, *domain.CommentCursor, error) {
+	var comments []*domain.Comment
+
+	query := r.db.WithContext(ctx)
+	if filter.ArticleID != 0 {
+		query = query.Where("article_id = ?", filter.ArticleID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.RootsOnly {
+		query = query.Where("parent_id IS NULL")
+	}
+	if cursor != nil {
+		query = query.Where(r.db.Where("created_at > ?", cursor.CreatedAt).Or("created_at = ? AND id > ?", cursor.CreatedAt, cursor.ID))
+	}
+
+	// one extra row tells whether there is a next page without a count query
+	if err := query.Order("created_at").Order("id").Limit(int(size) + 1).Find(&comments).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var nextCursor *domain.CommentCursor
+	if len(comments) > int(size) {
+		comments = comments[:size]
+		last := comments[len(comments)-1]
+		nextCursor = &domain.CommentCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return comments, nextCursor, nil
+}
+
+func (r *mysqlCommentRepository) FetchReplies(ctx context.Context, rootIDs []uint, status domain.CommentStatus) ([]*domain.Comment, error) {
+	if len(rootIDs) == 0 {
+		return nil, nil
+	}
+
+	var comments []*domain.Comment
+	if err := r.db.WithContext(ctx).
+		Where("root_id IN ? AND status = ?", rootIDs, status).
+		Order("created_at").Order("id").
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r *mysqlCommentRepository) GetByID(ctx context.Context, id uint) (*domain.Comment, error) {
+	var comment domain.Comment
+	if err := r.db.WithContext(ctx).First(&comment, id).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *mysqlCommentRepository) CountApproved(ctx context.Context, email string) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&domain.Comment{}).
+		Where("author_email = ? AND status = ?", email, domain.CommentStatusApproved).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *mysqlCommentRepository) Store(ctx context.Context, comment *domain.Comment) error {
+	return r.db.WithContext(ctx).Create(comment).Error
+}
+
+// UpdateStatus changes the status of a comment. It returns
+// gorm.ErrRecordNotFound when there is no such comment.
+func (r *mysqlCommentRepository) UpdateStatus(ctx context.Context, id uint, status domain.CommentStatus) error {
+	db := r.db.WithContext(ctx)
+	result := db.Model(&domain.Comment{ID: id}).Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	// MySQL does not count rows whose values are unchanged
+	var count int64
+	if err := db.Model(&domain.Comment{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *mysqlCommentRepository) DeleteByArticles(ctx context.Context, articleIDs []uint) error {
+	return r.db.WithContext(ctx).Where("article_id IN ?", articleIDs).Delete(&domain.Comment{}).Error
+}
@@ -0,0 +1,280 @@
+package comment
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func mockDBConnection() (*gorm.DB, sqlmock.Sqlmock, error) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gdb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Info),
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gdb, mock, nil
+}
+
+var commentColumns = []string{"id", "article_id", "parent_id", "root_id", "depth", "author_name", "author_email", "content", "status", "created_at", "updated_at"}
+
+func TestMysqlCommentRepository_Fetch(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	now := time.Now()
+	query := "SELECT * FROM `comments` WHERE article_id = ? AND status = ? AND parent_id IS NULL ORDER BY created_at,id LIMIT ?"
+	rows := sqlmock.NewRows(commentColumns).
+		AddRow(1, 1, nil, nil, 0, "Ana", "ana@example.com", "First", "approved", now, now).
+		AddRow(2, 1, nil, nil, 0, "Budi", "budi@example.com", "Second", "approved", now, now).
+		AddRow(3, 1, nil, nil, 0, "Citra", "citra@example.com", "Third", "approved", now, now)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1, domain.CommentStatusApproved, 3).
+		WillReturnRows(rows)
+
+	repo := NewMysqlCommentRepository(db)
+
+	filter := &domain.CommentFilter{ArticleID: 1, Status: domain.CommentStatusApproved, RootsOnly: true}
+	comments, nextCursor, err := repo.Fetch(context.Background(), filter, nil, 2)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, &domain.CommentCursor{CreatedAt: now, ID: 2}, nextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlCommentRepository_Fetch_Cursor(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	now := time.Now()
+	query := "SELECT * FROM `comments` WHERE status = ? AND (created_at > ? OR (created_at = ? AND id > ?)) ORDER BY created_at,id LIMIT ?"
+	rows := sqlmock.NewRows(commentColumns).
+		AddRow(3, 1, nil, nil, 0, "Citra", "citra@example.com", "Third", "pending", now, now)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(domain.CommentStatusPending, now, now, 2, 11).
+		WillReturnRows(rows)
+
+	repo := NewMysqlCommentRepository(db)
+
+	filter := &domain.CommentFilter{Status: domain.CommentStatusPending}
+	comments, nextCursor, err := repo.Fetch(context.Background(), filter, &domain.CommentCursor{CreatedAt: now, ID: 2}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Nil(t, nextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlCommentRepository_Fetch_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `comments`")).
+		WillReturnError(assert.AnError)
+
+	repo := NewMysqlCommentRepository(db)
+
+	comments, nextCursor, err := repo.Fetch(context.Background(), &domain.CommentFilter{}, nil, 10)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, comments)
+	assert.Nil(t, nextCursor)
+}
+
+func TestMysqlCommentRepository_FetchReplies(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	now := time.Now()
+	query := "SELECT * FROM `comments` WHERE root_id IN (?,?) AND status = ? ORDER BY created_at,id"
+	rows := sqlmock.NewRows(commentColumns).
+		AddRow(4, 1, 1, 1, 1, "Dewi", "dewi@example.com", "Reply", "approved", now, now)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1, 2, domain.CommentStatusApproved).
+		WillReturnRows(rows)
+
+	repo := NewMysqlCommentRepository(db)
+
+	comments, err := repo.FetchReplies(context.Background(), []uint{1, 2}, domain.CommentStatusApproved)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, uint(1), *comments[0].RootID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlCommentRepository_FetchReplies_NoRoots(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	repo := NewMysqlCommentRepository(db)
+
+	comments, err := repo.FetchReplies(context.Background(), nil, domain.CommentStatusApproved)
+	assert.NoError(t, err)
+	assert.Nil(t, comments)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlCommentRepository_GetByID(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	now := time.Now()
+	query := "SELECT * FROM `comments` WHERE `comments`.`id` = ? ORDER BY `comments`.`id` LIMIT ?"
+	rows := sqlmock.NewRows(commentColumns).
+		AddRow(1, 1, nil, nil, 0, "Ana", "ana@example.com", "First", "approved", now, now)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1, 1).
+		WillReturnRows(rows)
+
+	repo := NewMysqlCommentRepository(db)
+
+	comment, err := repo.GetByID(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ana", comment.AuthorName)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlCommentRepository_GetByID_NotFound(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `comments`")).
+		WillReturnRows(sqlmock.NewRows(commentColumns))
+
+	repo := NewMysqlCommentRepository(db)
+
+	comment, err := repo.GetByID(context.Background(), 1)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, comment)
+}
+
+func TestMysqlCommentRepository_CountApproved(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT count(*) FROM `comments` WHERE author_email = ? AND status = ?"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("ana@example.com", domain.CommentStatusApproved).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	repo := NewMysqlCommentRepository(db)
+
+	count, err := repo.CountApproved(context.Background(), "ana@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlCommentRepository_Store(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "INSERT INTO `comments` (`article_id`,`parent_id`,`root_id`,`depth`,`author_name`,`author_email`,`content`,`status`,`ip`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)"
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlCommentRepository(db)
+
+	comment := &domain.Comment{
+		ArticleID:   1,
+		AuthorName:  "Ana",
+		AuthorEmail: "ana@example.com",
+		Content:     "First",
+		Status:      domain.CommentStatusPending,
+		IP:          "127.0.0.1",
+	}
+	err = repo.Store(context.Background(), comment)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), comment.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlCommentRepository_UpdateStatus(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `comments` SET `status`=?,`updated_at`=? WHERE `id` = ?"
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(domain.CommentStatusApproved, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlCommentRepository(db)
+
+	err = repo.UpdateStatus(context.Background(), 1, domain.CommentStatusApproved)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlCommentRepository_UpdateStatus_Unchanged(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `comments`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `comments` WHERE id = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	repo := NewMysqlCommentRepository(db)
+
+	err = repo.UpdateStatus(context.Background(), 1, domain.CommentStatusApproved)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlCommentRepository_UpdateStatus_NotFound(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `comments`")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `comments` WHERE id = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	repo := NewMysqlCommentRepository(db)
+
+	err = repo.UpdateStatus(context.Background(), 1, domain.CommentStatusApproved)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlCommentRepository_DeleteByArticles(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `comments` WHERE article_id IN (?,?)")).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	repo := NewMysqlCommentRepository(db)
+
+	err = repo.DeleteByArticles(context.Background(), []uint{1, 2})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
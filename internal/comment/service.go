@@ -0,0 +1,175 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"strings"
+)
+
+type commentService struct {
+	commentRepo domain.CommentRepository
+	articleRepo domain.ArticleRepository
+	cfg         config.Config
+}
+
+func NewCommentService(comment domain.CommentRepository, article domain.ArticleRepository, cfg config.Config) domain.CommentService {
+	return &commentService{
+		commentRepo: comment,
+		articleRepo: article,
+		cfg:         cfg,
+	}
+}
+
+func (s *commentService) FetchThreads(ctx context.Context, articleID uint, cursor *domain.CommentCursor, size uint) ([]*domain.Comment, *domain.CommentCursor, error) {
+	if _, err := s.article(ctx, articleID); err != nil {
+		return nil, nil, err
+	}
+
+	filter := &domain.CommentFilter{ArticleID: articleID, Status: domain.CommentStatusApproved, RootsOnly: true}
+	roots, nextCursor, err := s.commentRepo.Fetch(ctx, filter, cursor, size)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rootIDs := make([]uint, len(roots))
+	for i, root := range roots {
+		rootIDs[i] = root.ID
+	}
+	replies, err := s.commentRepo.FetchReplies(ctx, rootIDs, domain.CommentStatusApproved)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nest(roots, replies)
+	return roots, nextCursor, nil
+}
+
+// nest attaches replies, oldest first, to their parents. Replies come after
+// their parents, so a reply whose parent is not among the comments, because
+// it is not approved, is left out with its own replies.
+func nest(roots []*domain.Comment, replies []*domain.Comment) {
+	byID := make(map[uint]*domain.Comment, len(roots)+len(replies))
+	for _, root := range roots {
+		byID[root.ID] = root
+	}
+
+	for _, reply := range replies {
+		if reply.ParentID == nil {
+			continue
+		}
+		parent, ok := byID[*reply.ParentID]
+		if !ok {
+			continue
+		}
+		parent.Replies = append(parent.Replies, reply)
+		byID[reply.ID] = reply
+	}
+}
+
+func (s *commentService) Fetch(ctx context.Context, filter *domain.CommentFilter, cursor *domain.CommentCursor, size uint) ([]*domain.Comment, *domain.CommentCursor, error) {
+	return s.commentRepo.Fetch(ctx, filter, cursor, size)
+}
+
+// Store stores a comment on a published article, with the status given by
+// the moderation rules. Replies must answer an approved comment of the same
+// article.
+func (s *commentService) Store(ctx context.Context, comment *domain.Comment) error {
+	article, err := s.article(ctx, comment.ArticleID)
+	if err != nil {
+		return err
+	}
+	if article.Status != domain.ArticleStatusPublished {
+		return fiber.NewError(fiber.StatusConflict, "comments are only allowed on published articles")
+	}
+
+	comment.Depth = 0
+	comment.RootID = nil
+	if comment.ParentID != nil {
+		parent, err := s.commentRepo.GetByID(ctx, *comment.ParentID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if parent == nil || parent.ArticleID != comment.ArticleID || parent.Status != domain.CommentStatusApproved {
+			return fiber.NewError(fiber.StatusNotFound, "parent comment not found")
+		}
+		if int(parent.Depth) >= s.cfg.Comment.MaxDepth {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("replies cannot be nested more than %d levels deep", s.cfg.Comment.MaxDepth))
+		}
+
+		comment.Depth = parent.Depth + 1
+		comment.RootID = parent.RootID
+		if comment.RootID == nil {
+			comment.RootID = &parent.ID
+		}
+	}
+
+	if comment.Status, err = s.initialStatus(ctx, comment); err != nil {
+		return err
+	}
+	return s.commentRepo.Store(ctx, comment)
+}
+
+// initialStatus applies the moderation rules to a new comment.
+func (s *commentService) initialStatus(ctx context.Context, comment *domain.Comment) (domain.CommentStatus, error) {
+	content := strings.ToLower(comment.Content)
+	for _, word := range s.cfg.Comment.SpamWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" && strings.Contains(content, word) {
+			return domain.CommentStatusSpam, nil
+		}
+	}
+
+	links := strings.Count(content, "http://") + strings.Count(content, "https://")
+	if links > s.cfg.Comment.MaxLinks {
+		return domain.CommentStatusPending, nil
+	}
+
+	if s.cfg.Comment.AutoApprove {
+		return domain.CommentStatusApproved, nil
+	}
+	if s.cfg.Comment.AutoApproveKnown {
+		count, err := s.commentRepo.CountApproved(ctx, comment.AuthorEmail)
+		if err != nil {
+			return "", err
+		}
+		if count > 0 {
+			return domain.CommentStatusApproved, nil
+		}
+	}
+	return domain.CommentStatusPending, nil
+}
+
+func (s *commentService) Moderate(ctx context.Context, id uint, status domain.CommentStatus) (*domain.Comment, error) {
+	if err := s.commentRepo.UpdateStatus(ctx, id, status); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		return nil, err
+	}
+
+	comment, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.ErrNotFound
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
+// article returns the article comments belong to. Articles in the trash have
+// no comments until they are restored.
+func (s *commentService) article(ctx context.Context, id uint) (*domain.Article, error) {
+	article, err := s.articleRepo.GetByID(ctx, id, &domain.ArticleView{Columns: []string{"id", "status"}})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "article not found")
+		}
+		return nil, err
+	}
+	return article, nil
+}
@@ -0,0 +1,356 @@
+package comment
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/mocks"
+	"gorm.io/gorm"
+	"testing"
+)
+
+// articleView is the view used to check the article of comments.
+var articleView = &domain.ArticleView{Columns: []string{"id", "status"}}
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func TestCommentService_FetchThreads(t *testing.T) {
+	mockCommentRepository := new(mocks.CommentRepository)
+	mockArticleRepository := new(mocks.ArticleRepository)
+	filter := &domain.CommentFilter{ArticleID: 1, Status: domain.CommentStatusApproved, RootsOnly: true}
+
+	t.Run("success", func(t *testing.T) {
+		roots := []*domain.Comment{{ID: 1}, {ID: 2}}
+		replies := []*domain.Comment{
+			{ID: 3, ParentID: uintPtr(1), RootID: uintPtr(1)},
+			{ID: 4, ParentID: uintPtr(3), RootID: uintPtr(1)},
+			// the parent of 6 is not approved
+			{ID: 6, ParentID: uintPtr(5), RootID: uintPtr(2)},
+		}
+		nextCursor := &domain.CommentCursor{ID: 2}
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusPublished}, nil).Once()
+		mockCommentRepository.On("Fetch", mock.Anything, filter, (*domain.CommentCursor)(nil), uint(2)).
+			Return(roots, nextCursor, nil).Once()
+		mockCommentRepository.On("FetchReplies", mock.Anything, []uint{1, 2}, domain.CommentStatusApproved).
+			Return(replies, nil).Once()
+
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, config.Config{})
+		threads, cursor, err := commentSvc.FetchThreads(context.Background(), 1, nil, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, nextCursor, cursor)
+		assert.Len(t, threads, 2)
+		assert.Equal(t, []*domain.Comment{replies[0]}, threads[0].Replies)
+		assert.Equal(t, []*domain.Comment{replies[1]}, threads[0].Replies[0].Replies)
+		assert.Empty(t, threads[1].Replies)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-article-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, config.Config{})
+		threads, cursor, err := commentSvc.FetchThreads(context.Background(), 1, nil, 2)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusNotFound, fiberErr.Code)
+		assert.Nil(t, threads)
+		assert.Nil(t, cursor)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusPublished}, nil).Once()
+		mockCommentRepository.On("Fetch", mock.Anything, filter, (*domain.CommentCursor)(nil), uint(2)).
+			Return(nil, nil, assert.AnError).Once()
+
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, config.Config{})
+		threads, _, err := commentSvc.FetchThreads(context.Background(), 1, nil, 2)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, threads)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+}
+
+func TestCommentService_Fetch(t *testing.T) {
+	mockCommentRepository := new(mocks.CommentRepository)
+	filter := &domain.CommentFilter{Status: domain.CommentStatusPending}
+	comments := []*domain.Comment{{ID: 1}}
+
+	mockCommentRepository.On("Fetch", mock.Anything, filter, (*domain.CommentCursor)(nil), uint(10)).
+		Return(comments, nil, nil).Once()
+
+	commentSvc := NewCommentService(mockCommentRepository, nil, config.Config{})
+	result, cursor, err := commentSvc.Fetch(context.Background(), filter, nil, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, comments, result)
+	assert.Nil(t, cursor)
+
+	mockCommentRepository.AssertExpectations(t)
+}
+
+func TestCommentService_Store(t *testing.T) {
+	mockCommentRepository := new(mocks.CommentRepository)
+	mockArticleRepository := new(mocks.ArticleRepository)
+	published := &domain.Article{ID: 1, Status: domain.ArticleStatusPublished}
+	cfg := config.Config{Comment: config.Comment{
+		AutoApproveKnown: true,
+		MaxLinks:         1,
+		SpamWords:        []string{"casino"},
+		MaxDepth:         2,
+	}}
+
+	newComment := func(content string) *domain.Comment {
+		return &domain.Comment{ArticleID: 1, AuthorName: "Ana", AuthorEmail: "ana@example.com", Content: content}
+	}
+
+	t.Run("success-pending", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(published, nil).Once()
+		mockCommentRepository.On("CountApproved", mock.Anything, "ana@example.com").Return(int64(0), nil).Once()
+		mockCommentRepository.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+
+		comment := newComment("Nice article")
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, cfg)
+		err := commentSvc.Store(context.Background(), comment)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CommentStatusPending, comment.Status)
+		assert.Equal(t, uint(0), comment.Depth)
+		assert.Nil(t, comment.RootID)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-known-author", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(published, nil).Once()
+		mockCommentRepository.On("CountApproved", mock.Anything, "ana@example.com").Return(int64(2), nil).Once()
+		mockCommentRepository.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+
+		comment := newComment("Nice article")
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, cfg)
+		err := commentSvc.Store(context.Background(), comment)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CommentStatusApproved, comment.Status)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-known-author-disabled", func(t *testing.T) {
+		mockCommentRepository := new(mocks.CommentRepository)
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(published, nil).Once()
+		mockCommentRepository.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+
+		// the email of an approved commenter does not skip moderation
+		disabled := cfg
+		disabled.Comment.AutoApproveKnown = false
+		comment := newComment("Nice article")
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, disabled)
+		err := commentSvc.Store(context.Background(), comment)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CommentStatusPending, comment.Status)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+		mockCommentRepository.AssertNotCalled(t, "CountApproved", mock.Anything, mock.Anything)
+	})
+
+	t.Run("success-auto-approve", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(published, nil).Once()
+		mockCommentRepository.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+
+		comment := newComment("Nice article")
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, config.Config{Comment: config.Comment{AutoApprove: true}})
+		err := commentSvc.Store(context.Background(), comment)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CommentStatusApproved, comment.Status)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-spam", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(published, nil).Once()
+		mockCommentRepository.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+
+		comment := newComment("Visit my CASINO")
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, cfg)
+		err := commentSvc.Store(context.Background(), comment)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CommentStatusSpam, comment.Status)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-too-many-links", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(published, nil).Once()
+		mockCommentRepository.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+
+		comment := newComment("See https://a.example and http://b.example")
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, config.Config{Comment: config.Comment{AutoApprove: true, MaxLinks: 1}})
+		err := commentSvc.Store(context.Background(), comment)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CommentStatusPending, comment.Status)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-reply", func(t *testing.T) {
+		parent := &domain.Comment{ID: 5, ArticleID: 1, RootID: uintPtr(2), Depth: 1, Status: domain.CommentStatusApproved}
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(published, nil).Once()
+		mockCommentRepository.On("GetByID", mock.Anything, uint(5)).Return(parent, nil).Once()
+		mockCommentRepository.On("CountApproved", mock.Anything, "ana@example.com").Return(int64(0), nil).Once()
+		mockCommentRepository.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+
+		comment := newComment("I agree")
+		comment.ParentID = uintPtr(5)
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, cfg)
+		err := commentSvc.Store(context.Background(), comment)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), comment.Depth)
+		assert.Equal(t, uint(2), *comment.RootID)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+
+	t.Run("success-reply-to-root", func(t *testing.T) {
+		parent := &domain.Comment{ID: 2, ArticleID: 1, Status: domain.CommentStatusApproved}
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(published, nil).Once()
+		mockCommentRepository.On("GetByID", mock.Anything, uint(2)).Return(parent, nil).Once()
+		mockCommentRepository.On("CountApproved", mock.Anything, "ana@example.com").Return(int64(0), nil).Once()
+		mockCommentRepository.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+
+		comment := newComment("I agree")
+		comment.ParentID = uintPtr(2)
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, cfg)
+		err := commentSvc.Store(context.Background(), comment)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), comment.Depth)
+		assert.Equal(t, uint(2), *comment.RootID)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-published", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusDraft}, nil).Once()
+
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, cfg)
+		err := commentSvc.Store(context.Background(), newComment("Nice article"))
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusConflict, fiberErr.Code)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-parent-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(published, nil).Once()
+		mockCommentRepository.On("GetByID", mock.Anything, uint(5)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		comment := newComment("I agree")
+		comment.ParentID = uintPtr(5)
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, cfg)
+		err := commentSvc.Store(context.Background(), comment)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusNotFound, fiberErr.Code)
+		assert.Equal(t, "parent comment not found", fiberErr.Message)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-parent-other-article", func(t *testing.T) {
+		parent := &domain.Comment{ID: 5, ArticleID: 2, Status: domain.CommentStatusApproved}
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(published, nil).Once()
+		mockCommentRepository.On("GetByID", mock.Anything, uint(5)).Return(parent, nil).Once()
+
+		comment := newComment("I agree")
+		comment.ParentID = uintPtr(5)
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, cfg)
+		err := commentSvc.Store(context.Background(), comment)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusNotFound, fiberErr.Code)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-too-deep", func(t *testing.T) {
+		parent := &domain.Comment{ID: 5, ArticleID: 1, RootID: uintPtr(2), Depth: 2, Status: domain.CommentStatusApproved}
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(published, nil).Once()
+		mockCommentRepository.On("GetByID", mock.Anything, uint(5)).Return(parent, nil).Once()
+
+		comment := newComment("I agree")
+		comment.ParentID = uintPtr(5)
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, cfg)
+		err := commentSvc.Store(context.Background(), comment)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusBadRequest, fiberErr.Code)
+		assert.Equal(t, "replies cannot be nested more than 2 levels deep", fiberErr.Message)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).Return(published, nil).Once()
+		mockCommentRepository.On("CountApproved", mock.Anything, "ana@example.com").Return(int64(0), nil).Once()
+		mockCommentRepository.On("Store", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+
+		commentSvc := NewCommentService(mockCommentRepository, mockArticleRepository, cfg)
+		err := commentSvc.Store(context.Background(), newComment("Nice article"))
+		assert.ErrorIs(t, err, assert.AnError)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockCommentRepository.AssertExpectations(t)
+	})
+}
+
+func TestCommentService_Moderate(t *testing.T) {
+	mockCommentRepository := new(mocks.CommentRepository)
+
+	t.Run("success", func(t *testing.T) {
+		comment := &domain.Comment{ID: 1, Status: domain.CommentStatusApproved}
+		mockCommentRepository.On("UpdateStatus", mock.Anything, uint(1), domain.CommentStatusApproved).Return(nil).Once()
+		mockCommentRepository.On("GetByID", mock.Anything, uint(1)).Return(comment, nil).Once()
+
+		commentSvc := NewCommentService(mockCommentRepository, nil, config.Config{})
+		result, err := commentSvc.Moderate(context.Background(), 1, domain.CommentStatusApproved)
+		assert.NoError(t, err)
+		assert.Equal(t, comment, result)
+
+		mockCommentRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockCommentRepository.On("UpdateStatus", mock.Anything, uint(1), domain.CommentStatusApproved).
+			Return(gorm.ErrRecordNotFound).Once()
+
+		commentSvc := NewCommentService(mockCommentRepository, nil, config.Config{})
+		result, err := commentSvc.Moderate(context.Background(), 1, domain.CommentStatusApproved)
+		assert.ErrorIs(t, err, fiber.ErrNotFound)
+		assert.Nil(t, result)
+
+		mockCommentRepository.AssertExpectations(t)
+	})
+}
@@ -1,15 +1,149 @@
 package config
 
+import (
+	"go-clean-architecture/pkg/ximage"
+	"go-clean-architecture/pkg/xmarkup"
+	"go-clean-architecture/pkg/xrank"
+	"time"
+)
+
 type Config struct {
-	Host          string   `env:"HOST"`
-	Port          int      `env:"PORT" envDefault:"3000"`
-	IsDevelopment bool     `env:"IS_DEVELOPMENT"`
-	ProxyHeader   string   `env:"PROXY_HEADER"`
-	Database      Database `envPrefix:"DB_"`
-	LogFields     []string `env:"LOG_FIELDS" envSeparator:","`
+	Host           string        `env:"HOST"`
+	Port           int           `env:"PORT" envDefault:"3000"`
+	IsDevelopment  bool          `env:"IS_DEVELOPMENT"`
+	ProxyHeader    string        `env:"PROXY_HEADER"`
+	RequestTimeout time.Duration `env:"REQUEST_TIMEOUT" envDefault:"30s"`
+	Database       Database      `envPrefix:"DB_"`
+	Pagination     Pagination    `envPrefix:"PAGINATION_"`
+	Trash          Trash         `envPrefix:"TRASH_"`
+	Article        Article       `envPrefix:"ARTICLE_"`
+	Scheduler      Scheduler     `envPrefix:"SCHEDULER_"`
+	Comment        Comment       `envPrefix:"COMMENT_"`
+	Attachment     Attachment    `envPrefix:"ATTACHMENT_"`
+	Storage        Storage       `envPrefix:"STORAGE_"`
+	Image          Image         `envPrefix:"IMAGE_"`
+	Views          Views         `envPrefix:"VIEWS_"`
+	Trending       Trending      `envPrefix:"TRENDING_"`
+	LogFields      []string      `env:"LOG_FIELDS" envSeparator:","`
 }
 
 type Database struct {
 	Driver string `env:"DRIVER" envDefault:"sqlite"`
 	DSN    string `env:"DSN" envDefault:"file::memory:?cache=shared"`
 }
+
+type Pagination struct {
+	MaxSize      int    `env:"MAX_SIZE" envDefault:"100"`
+	CursorSecret string `env:"CURSOR_SECRET"`
+}
+
+type Trash struct {
+	Retention     time.Duration `env:"RETENTION" envDefault:"720h"`
+	PurgeInterval time.Duration `env:"PURGE_INTERVAL" envDefault:"1h"`
+}
+
+type Article struct {
+	RegenerateSlug bool `env:"REGENERATE_SLUG"`
+	RequireIfMatch bool `env:"REQUIRE_IF_MATCH"`
+	BulkMaxSize    int  `env:"BULK_MAX_SIZE" envDefault:"1000"`
+	// ImportMaxSize is the largest import body in bytes. Imports are read
+	// as they arrive, so they are not bound by the body limit of the server.
+	ImportMaxSize int64 `env:"IMPORT_MAX_SIZE" envDefault:"104857600"`
+	// HTMLAllowlist replaces the markup allowed in rendered content, nil
+	// keeps xmarkup.DefaultAllowlist.
+	HTMLAllowlist xmarkup.Allowlist `env:"HTML_ALLOWLIST"`
+}
+
+type Scheduler struct {
+	Interval time.Duration `env:"INTERVAL" envDefault:"1m"`
+}
+
+// Comment holds the moderation rules of new comments. Comments with spam
+// words are marked as spam, comments with more links than MaxLinks wait for
+// moderation, and the others are approved when AutoApprove is set or their
+// email already has an approved comment and AutoApproveKnown is set.
+type Comment struct {
+	AutoApprove bool `env:"AUTO_APPROVE"`
+	// AutoApproveKnown trusts the email sent with a comment, which is not
+	// verified, so anyone who knows the email of an approved commenter
+	// skips moderation.
+	AutoApproveKnown bool     `env:"AUTO_APPROVE_KNOWN"`
+	MaxLinks         int      `env:"MAX_LINKS" envDefault:"2"`
+	SpamWords        []string `env:"SPAM_WORDS" envSeparator:","`
+	MaxDepth         int      `env:"MAX_DEPTH" envDefault:"5"`
+	// RateLimit is the number of comments one IP may post per RateWindow, 0
+	// disables the limit.
+	RateLimit  int           `env:"RATE_LIMIT" envDefault:"5"`
+	RateWindow time.Duration `env:"RATE_WINDOW" envDefault:"1m"`
+}
+
+type Attachment struct {
+	// MaxSize is the largest file that can be uploaded, in bytes.
+	MaxSize int64 `env:"MAX_SIZE" envDefault:"10485760"`
+	// AllowedTypes are the media types accepted, as sniffed from the content
+	// of the files.
+	AllowedTypes []string `env:"ALLOWED_TYPES" envSeparator:"," envDefault:"image/jpeg,image/png,image/gif,image/webp,application/pdf,text/plain"`
+	// URLSecret signs the download URLs, a random one is used when empty.
+	URLSecret string        `env:"URL_SECRET"`
+	URLExpiry time.Duration `env:"URL_EXPIRY" envDefault:"15m"`
+	// CleanupInterval is how often the attachments of permanently deleted
+	// articles are removed, 0 disables the cleanup.
+	CleanupInterval time.Duration `env:"CLEANUP_INTERVAL" envDefault:"1h"`
+}
+
+// Image configures the resized variants of image attachments. Variants are
+// made when first downloaded, or right after the upload when Eager is set,
+// and kept in the storage.
+type Image struct {
+	Variants    ximage.Variants `env:"VARIANTS" envDefault:"thumbnail:150x150,medium:800x800,large:1600x1600"`
+	Eager       bool            `env:"EAGER"`
+	JPEGQuality int             `env:"JPEG_QUALITY" envDefault:"85"`
+	// MaxPixels is the largest number of pixels of the images uploaded.
+	MaxPixels int `env:"MAX_PIXELS" envDefault:"50000000"`
+}
+
+// Views configures how article views are counted. A visitor viewing an
+// article again within Window is counted once, and the views are written to
+// the database every FlushInterval.
+type Views struct {
+	Window        time.Duration `env:"WINDOW" envDefault:"30m"`
+	FlushInterval time.Duration `env:"FLUSH_INTERVAL" envDefault:"10s"`
+	// MaxVisitors is the number of recent visitors remembered, past which
+	// views are counted without checking the visitor.
+	MaxVisitors int `env:"MAX_VISITORS" envDefault:"100000"`
+}
+
+// Trending configures how articles are ranked. Every Interval, the views,
+// approved comments and reactions of each window are weighed by their age
+// with Decay and summed, each view counting ViewWeight, each comment
+// CommentWeight and each reaction ReactionWeight.
+type Trending struct {
+	Interval       time.Duration `env:"INTERVAL" envDefault:"5m"`
+	ViewWeight     float64       `env:"VIEW_WEIGHT" envDefault:"1"`
+	CommentWeight  float64       `env:"COMMENT_WEIGHT" envDefault:"5"`
+	ReactionWeight float64       `env:"REACTION_WEIGHT" envDefault:"2"`
+	Decay          xrank.Decay   `env:"DECAY" envDefault:"exponential"`
+	// HalfLife is the half-life of exponential decay as a fraction of the
+	// window.
+	HalfLife float64 `env:"HALF_LIFE" envDefault:"0.25"`
+}
+
+// Storage selects where the files of attachments are stored, local or s3.
+type Storage struct {
+	Driver    string `env:"DRIVER" envDefault:"local"`
+	LocalPath string `env:"LOCAL_PATH" envDefault:"storage"`
+	S3        S3     `envPrefix:"S3_"`
+}
+
+// S3 configures an S3 compatible storage. Objects are addressed by path,
+// as in Endpoint/Bucket/key, which is supported by most implementations.
+type S3 struct {
+	Endpoint  string `env:"ENDPOINT" envDefault:"https://s3.amazonaws.com"`
+	Region    string `env:"REGION" envDefault:"us-east-1"`
+	Bucket    string `env:"BUCKET"`
+	AccessKey string `env:"ACCESS_KEY"`
+	SecretKey string `env:"SECRET_KEY"`
+	// Timeout bounds each request to the storage, including the time taken
+	// to read a downloaded blob. A non-positive duration disables it.
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"5m"`
+}
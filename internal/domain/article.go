@@ -1,48 +1,278 @@
 package domain
 
 import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
+// ErrVersionMismatch is returned by writes that expected another version of
+// the article than the stored one.
+var ErrVersionMismatch = errors.New("article version does not match")
+
+// ArticleDeleteHook removes what another module keeps about the articles with
+// the given IDs, once they are deleted permanently.
+type ArticleDeleteHook func(ctx context.Context, articleIDs []uint) error
+
+type ArticleStatus string
+
+const (
+	ArticleStatusDraft     ArticleStatus = "draft"
+	ArticleStatusReview    ArticleStatus = "review"
+	ArticleStatusPublished ArticleStatus = "published"
+	ArticleStatusArchived  ArticleStatus = "archived"
+
+	// ArticleStatusAll is only used by listings to match every status.
+	ArticleStatusAll ArticleStatus = "all"
+)
+
+// Valid reports whether s is one of the known article statuses.
+func (s ArticleStatus) Valid() bool {
+	switch s {
+	case ArticleStatusDraft, ArticleStatusReview, ArticleStatusPublished, ArticleStatusArchived:
+		return true
+	}
+	return false
+}
+
+// ContentFormat is the format article content is written in.
+type ContentFormat string
+
+const (
+	ContentFormatPlain    ContentFormat = "plain"
+	ContentFormatMarkdown ContentFormat = "markdown"
+	ContentFormatHTML     ContentFormat = "html"
+)
+
 type Article struct {
+	ID      uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	Title   string `json:"title" gorm:"type:varchar(255)"`
+	Slug    string `json:"slug" gorm:"type:varchar(255);uniqueIndex"`
+	Content string `json:"content" gorm:"type:text"`
+	// ContentFormat tells how Content is rendered to HTML.
+	ContentFormat ContentFormat `json:"contentFormat" gorm:"type:varchar(20);default:plain" enums:"plain,markdown,html"`
+	// ContentHTML is the rendered and sanitised content. It is only set when
+	// asked for, from RenderedContent.
+	ContentHTML string `json:"contentHtml,omitempty" gorm:"-"`
+	// RenderedContent caches the rendered content, made by the renderer
+	// identified by RenderKey.
+	RenderedContent string `json:"-" gorm:"type:text"`
+	RenderKey       string `json:"-" gorm:"type:varchar(64)"`
+	// Excerpt, WordCount and ReadingTimeMinutes are computed from the text
+	// of the content whenever it is written.
+	Excerpt            string         `json:"excerpt" gorm:"type:varchar(255)"`
+	WordCount          uint           `json:"wordCount" gorm:"not null;default:0"`
+	ReadingTimeMinutes uint           `json:"readingTimeMinutes" gorm:"not null;default:0"`
+	AuthorID           uint           `json:"authorId" gorm:"index"`
+	Author             *Author        `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+	Tags               []*Tag         `json:"tags" gorm:"many2many:article_tags"`
+	Status             ArticleStatus  `json:"status" gorm:"type:varchar(20);default:draft;index" enums:"draft,review,published,archived"`
+	PublishedAt        *time.Time     `json:"publishedAt"`
+	PublishAt          *time.Time     `json:"publishAt" gorm:"index"`
+	UnpublishAt        *time.Time     `json:"unpublishAt" gorm:"index"`
+	Version            uint           `json:"version" gorm:"not null;default:1"`
+	ViewCount          uint64         `json:"viewCount" gorm:"<-:false;not null;default:0;index"`
+	CreatedAt          time.Time      `json:"createdAt" gorm:"autoCreateTime;index"`
+	UpdatedAt          time.Time      `json:"updatedAt" gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `json:"deletedAt" gorm:"index" swaggertype:"string" format:"date-time"`
+
+	// Highlight is only set on search results.
+	Highlight *ArticleHighlight `json:"highlight,omitempty" gorm:"-"`
+	// Attachments are only loaded when asked for. They outlive the article
+	// until they are cleaned up, so no foreign key is made for them.
+	Attachments []*Attachment `json:"attachments,omitempty" gorm:"foreignKey:ArticleID;constraint:-"`
+}
+
+// ArticleSlug is a previous slug of an article, kept so that old URLs can be
+// redirected to the current one.
+type ArticleSlug struct {
 	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
-	Title     string    `json:"title" gorm:"type:varchar(255)"`
-	Content   string    `json:"content" gorm:"type:text"`
-	AuthorID  uint      `json:"authorId" gorm:"index"`
-	Author    *Author   `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+	ArticleID uint      `json:"articleId" gorm:"index"`
+	Slug      string    `json:"slug" gorm:"type:varchar(255);uniqueIndex"`
 	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// ArticleViewBucket holds the views of an article within an hour, starting
+// at Hour, so that views can be counted over a period of time.
+type ArticleViewBucket struct {
+	ArticleID uint      `json:"articleId" gorm:"primaryKey;autoIncrement:false"`
+	Hour      time.Time `json:"hour" gorm:"primaryKey;index"`
+	Views     uint64    `json:"views" gorm:"not null;default:0"`
+}
+
+// ArticleFilter narrows article listings. Zero values match every article.
+type ArticleFilter struct {
+	// Query is a full-text search over the title and content. Listings with a
+	// query are ordered by relevance.
+	Query    string
+	AuthorID uint
+	Status   ArticleStatus
+	// Tags matches articles with any of the tags, or with all of them when
+	// AllTags is set.
+	Tags    []string
+	AllTags bool
+	// CreatedFrom and CreatedTo bound the creation time, both inclusive.
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	UpdatedSince *time.Time
+	// Sort replaces the default order of page listings. It has no effect on
+	// counts and keyset pagination.
+	Sort []SortField
+	// Expression is an extra condition compiled from a filter expression.
+	Expression clause.Expression
+	// View selects the columns and relations that are loaded. Nil loads every
+	// column and the tags.
+	View *ArticleView
+}
+
+// ArticleView selects what is loaded for article responses.
+type ArticleView struct {
+	// Columns limits the loaded columns; empty loads them all.
+	Columns []string
+	Author  bool
+	Tags    bool
+	// Attachments are loaded oldest first.
+	Attachments bool
+	// HTML sets the rendered content, rendering it again when the cached
+	// copy is missing or stale.
+	HTML bool
+	// Deleted also finds articles in the trash.
+	Deleted bool
+}
+
+// ArticleCursor is the keyset position of an article in the listing order
+// (created_at DESC, id DESC).
+type ArticleCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uint      `json:"id"`
 }
 
 type ArticleStoreRequest struct {
-	Title    string `json:"title" validate:"required"`
-	Content  string `json:"content" validate:"required"`
-	AuthorID uint   `json:"authorId" validate:"required"`
+	Title         string        `json:"title" validate:"required"`
+	Content       string        `json:"content" validate:"required"`
+	ContentFormat ContentFormat `json:"contentFormat" validate:"omitempty,oneof=plain markdown html" enums:"plain,markdown,html"`
+	AuthorID      uint          `json:"authorId" validate:"required"`
+	Tags          []string      `json:"tags" validate:"max=20,dive,required,max=64"`
 }
 
 type ArticleUpdateRequest struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Title         string        `json:"title" validate:"required"`
+	Content       string        `json:"content" validate:"required"`
+	ContentFormat ContentFormat `json:"contentFormat" validate:"omitempty,oneof=plain markdown html" enums:"plain,markdown,html"`
+	AuthorID      uint          `json:"authorId" validate:"required"`
+	Tags          []string      `json:"tags" validate:"max=20,dive,required,max=64"`
+}
+
+type ArticleScheduleRequest struct {
+	PublishAt   *time.Time `json:"publishAt"`
+	UnpublishAt *time.Time `json:"unpublishAt"`
+}
+
+type ArticleBulkDeleteRequest struct {
+	IDs []uint `json:"ids" validate:"required,min=1,unique,dive,required"`
+}
+
+type ArticleBulkReassignRequest struct {
+	IDs      []uint `json:"ids" validate:"required,min=1,unique,dive,required"`
+	AuthorID uint   `json:"authorId" validate:"required"`
+}
+
+// ArticleImportResult summarises an import of articles.
+type ArticleImportResult struct {
+	Imported int                  `json:"imported"`
+	Failed   int                  `json:"failed"`
+	Errors   []ArticleImportError `json:"errors"`
+}
+
+// ArticleImportError is a row of an import that was not stored.
+type ArticleImportError struct {
+	// Line is the line of the row in the file, starting at 1.
+	Line    int      `json:"line"`
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Errors  []string `json:"errors,omitempty"`
 }
 
 type ArticleRepository interface {
-	Fetch(page uint, size uint, filter *Article) ([]*Article, uint, error)
-	GetByID(id uint) (*Article, error)
-	Count(filter *Article) (int64, error)
-	GetByAuthorID(authorID uint) ([]*Article, error)
-	GetByTitle(title string) ([]*Article, error)
-	Store(article *Article) error
-	Update(article *Article) error
-	Delete(id uint) error
+	Fetch(ctx context.Context, page uint, size uint, filter *ArticleFilter) ([]*Article, uint, error)
+	FetchByCursor(ctx context.Context, cursor *ArticleCursor, size uint, filter *ArticleFilter) ([]*Article, *ArticleCursor, error)
+	// Export calls fn with every article matching filter, in batches ordered
+	// by ID, until fn returns an error.
+	Export(ctx context.Context, filter *ArticleFilter, fn func(articles []*Article) error) error
+	GetByID(ctx context.Context, id uint, view *ArticleView) (*Article, error)
+	// SaveRendered stores the rendered content of an article without
+	// changing its version or update time.
+	SaveRendered(ctx context.Context, article *Article) error
+	GetBySlug(ctx context.Context, slug string, view *ArticleView) (*Article, error)
+	SlugOwner(ctx context.Context, slug string) (uint, error)
+	Count(ctx context.Context, filter *ArticleFilter) (int64, error)
+	GetByAuthorID(ctx context.Context, authorID uint) ([]*Article, error)
+	GetByTitle(ctx context.Context, title string) ([]*Article, error)
+	Store(ctx context.Context, article *Article) error
+	// StoreMany stores the articles in one transaction. When one of them
+	// fails nothing is stored, and the error is a *BulkItemError.
+	StoreMany(ctx context.Context, articles []*Article) error
+	Update(ctx context.Context, article *Article) error
+	Delete(ctx context.Context, id uint, version uint) error
+	// DeleteMany and ReassignMany change the articles with the given IDs in
+	// one transaction and return the IDs that do not exist. In atomic mode
+	// nothing is changed when an ID does not exist.
+	DeleteMany(ctx context.Context, ids []uint, atomic bool) ([]uint, error)
+	ReassignMany(ctx context.Context, ids []uint, authorID uint, atomic bool) ([]uint, error)
+	FetchTrash(ctx context.Context, page uint, size uint) ([]*Article, uint, error)
+	CountTrash(ctx context.Context) (int64, error)
+	Restore(ctx context.Context, id uint) error
+	DeletePermanent(ctx context.Context, id uint, version uint) error
+	// Purge permanently deletes the articles deleted before the given time
+	// and returns their IDs.
+	Purge(ctx context.Context, deletedBefore time.Time) ([]uint, error)
+	UpdateStatus(ctx context.Context, article *Article, from ArticleStatus) error
+	UpdateSchedule(ctx context.Context, article *Article) error
+	PublishDue(ctx context.Context, now time.Time) (int64, error)
+	UnpublishDue(ctx context.Context, now time.Time) (int64, error)
+	// AddViews adds views to the view count of articles, without changing
+	// their version or update time, and to their bucket of the hour of at.
+	AddViews(ctx context.Context, views map[uint]uint64, at time.Time) error
+	FetchRevisions(ctx context.Context, articleID uint, page uint, size uint) ([]*ArticleRevision, uint, error)
+	CountRevisions(ctx context.Context, articleID uint) (int64, error)
+	GetRevision(ctx context.Context, articleID uint, revision uint) (*ArticleRevision, error)
 }
 
 type ArticleService interface {
-	Fetch(page uint, size uint, filter *Article) ([]*Article, uint, error)
-	GetByID(id uint) (*Article, error)
-	Count(filter *Article) (int64, error)
-	GetByTitle(title string) ([]*Article, error)
-	GetByAuthorID(authorID uint) ([]*Article, error)
-	Store(article *Article) error
-	Update(article *Article) error
-	Delete(id uint) error
+	Fetch(ctx context.Context, page uint, size uint, filter *ArticleFilter) ([]*Article, uint, error)
+	FetchByCursor(ctx context.Context, cursor *ArticleCursor, size uint, filter *ArticleFilter) ([]*Article, *ArticleCursor, error)
+	Export(ctx context.Context, filter *ArticleFilter, fn func(articles []*Article) error) error
+	GetByID(ctx context.Context, id uint, view *ArticleView) (*Article, error)
+	GetBySlug(ctx context.Context, slug string, view *ArticleView) (*Article, error)
+	Count(ctx context.Context, filter *ArticleFilter) (int64, error)
+	GetByTitle(ctx context.Context, title string) ([]*Article, error)
+	GetByAuthorID(ctx context.Context, authorID uint) ([]*Article, error)
+	Store(ctx context.Context, article *Article) error
+	// StoreBulk, DeleteBulk and ReassignBulk return one result per article,
+	// in order. In atomic mode either every article is changed or none is.
+	StoreBulk(ctx context.Context, articles []*Article, atomic bool) ([]BulkResult, error)
+	Update(ctx context.Context, article *Article) error
+	Delete(ctx context.Context, id uint, version uint) error
+	DeleteBulk(ctx context.Context, ids []uint, atomic bool) ([]BulkResult, error)
+	ReassignBulk(ctx context.Context, ids []uint, authorID uint, atomic bool) ([]BulkResult, error)
+	FetchTrash(ctx context.Context, page uint, size uint) ([]*Article, uint, error)
+	CountTrash(ctx context.Context) (int64, error)
+	Restore(ctx context.Context, id uint) (*Article, error)
+	DeletePermanent(ctx context.Context, id uint, version uint) error
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+	Transition(ctx context.Context, id uint, status ArticleStatus) (*Article, error)
+	Schedule(ctx context.Context, id uint, publishAt *time.Time, unpublishAt *time.Time) (*Article, error)
+	RunSchedule(ctx context.Context, now time.Time) (int64, int64, error)
+	// RecordView counts a view of a published article, unless the visitor
+	// viewed it recently. Views are kept in memory until FlushViews writes
+	// them and returns their number.
+	RecordView(ctx context.Context, id uint, visitor string) error
+	FlushViews(ctx context.Context) (int64, error)
+	FetchRevisions(ctx context.Context, articleID uint, page uint, size uint) ([]*ArticleRevision, uint, error)
+	CountRevisions(ctx context.Context, articleID uint) (int64, error)
+	GetRevision(ctx context.Context, articleID uint, revision uint) (*ArticleRevision, error)
+	DiffRevisions(ctx context.Context, articleID uint, from uint, to uint) (*ArticleRevisionDiff, error)
+	Revert(ctx context.Context, articleID uint, revision uint) (*Article, error)
 }
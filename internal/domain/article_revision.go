@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"go-clean-architecture/pkg/xdiff"
+	"time"
+)
+
+// ArticleRevision is a snapshot of the title and content of an article, taken
+// every time the article is stored or updated. Revisions are numbered from 1
+// for every article.
+type ArticleRevision struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	ArticleID uint      `json:"articleId" gorm:"uniqueIndex:idx_article_revision"`
+	Revision  uint      `json:"revision" gorm:"uniqueIndex:idx_article_revision"`
+	Title     string    `json:"title" gorm:"type:varchar(255)"`
+	Content   string    `json:"content" gorm:"type:text"`
+	Editor    string    `json:"editor" gorm:"type:varchar(255)"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// ArticleRevisionDiff holds the word level changes between two revisions.
+type ArticleRevisionDiff struct {
+	ArticleID uint           `json:"articleId"`
+	From      uint           `json:"from"`
+	To        uint           `json:"to"`
+	Title     []xdiff.Change `json:"title"`
+	Content   []xdiff.Change `json:"content"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+// ArticleHighlight holds the parts of an article that match a search query,
+// as HTML with the matching words wrapped in <mark>.
+type ArticleHighlight struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// ArticleSearchIndex is a full-text index over the title and content of
+// articles. Each database driver has its own implementation.
+type ArticleSearchIndex interface {
+	// Setup creates the index when it does not exist yet.
+	Setup(ctx context.Context) error
+	// Reindex rebuilds the index from the stored articles.
+	Reindex(ctx context.Context) error
+	// Match narrows query, which selects from articles, to the articles
+	// matching any word of terms.
+	Match(query *gorm.DB, terms string) *gorm.DB
+	// Rank orders query, which selects from articles, by relevance to terms,
+	// best match first. It selects every column of articles and the score as
+	// relevance.
+	Rank(query *gorm.DB, terms string) *gorm.DB
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "testing"
+
+func TestArticleStatus_Valid(t *testing.T) {
+	tests := []struct {
+		name   string
+		status ArticleStatus
+		want   bool
+	}{
+		{name: "draft", status: ArticleStatusDraft, want: true},
+		{name: "review", status: ArticleStatusReview, want: true},
+		{name: "published", status: ArticleStatusPublished, want: true},
+		{name: "archived", status: ArticleStatusArchived, want: true},
+		{name: "empty", status: "", want: false},
+		{name: "unknown", status: "deleted", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Valid(); got != tt.want {
+				t.Errorf("Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrBlobNotFound is returned by a BlobStore for keys that hold no blob.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore stores the content of files under keys.
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get returns the content of a blob, which the caller must close.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+	// DeleteDir deletes every blob whose key starts with dir and a slash.
+	DeleteDir(ctx context.Context, dir string) error
+	// List calls fn with the blobs whose key starts with prefix, a page at a
+	// time, in the order of their keys.
+	List(ctx context.Context, prefix string, fn func(blobs []*BlobInfo) error) error
+}
+
+// BlobInfo describes a stored blob.
+type BlobInfo struct {
+	Key        string
+	ModifiedAt time.Time
+}
+
+// Attachment is a file uploaded to an article. Its content is stored once
+// per checksum, so identical files share a blob.
+type Attachment struct {
+	ID          uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	ArticleID   uint   `json:"articleId" gorm:"index"`
+	Filename    string `json:"filename" gorm:"type:varchar(255)"`
+	ContentType string `json:"contentType" gorm:"type:varchar(100)"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"checksum" gorm:"type:char(64);index"`
+	// Width and Height are the size of images that can be resized, and are
+	// 0 for other files.
+	Width     int       `json:"width,omitempty" gorm:"not null;default:0"`
+	Height    int       `json:"height,omitempty" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+
+	// URL is a signed download URL, valid until URLExpiresAt, as are the
+	// URLs of the variants of images.
+	URL          string               `json:"url,omitempty" gorm:"-"`
+	URLExpiresAt *time.Time           `json:"urlExpiresAt,omitempty" gorm:"-"`
+	Variants     []*AttachmentVariant `json:"variants,omitempty" gorm:"-"`
+}
+
+// AttachmentVariant is a resized version of an image attachment.
+type AttachmentVariant struct {
+	Name   string `json:"name"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	URL    string `json:"url,omitempty"`
+	// ContentType is only set when the variant is opened.
+	ContentType string `json:"-"`
+}
+
+// BlobKey is the key of the blob holding the content of the attachment.
+func (a *Attachment) BlobKey() string {
+	return a.Checksum[:2] + "/" + a.Checksum[2:4] + "/" + a.Checksum
+}
+
+// VariantKey is the key of the blob holding a variant of the attachment. It
+// changes with the size of the variant, so that resized images are made
+// again when the configured sizes change.
+func (a *Attachment) VariantKey(variant *AttachmentVariant) string {
+	return fmt.Sprintf("%s/%s-%dx%d", a.VariantDir(), variant.Name, variant.Width, variant.Height)
+}
+
+// VariantDir is the directory of the blobs of every variant of the
+// attachment, including variants of sizes no longer configured.
+func (a *Attachment) VariantDir() string {
+	return "variants/" + a.Checksum
+}
+
+type AttachmentRepository interface {
+	FetchByArticle(ctx context.Context, articleID uint) ([]*Attachment, error)
+	// FetchOrphans returns up to size attachments of articles that no longer
+	// exist, not even in the trash.
+	FetchOrphans(ctx context.Context, size uint) ([]*Attachment, error)
+	GetByID(ctx context.Context, id uint) (*Attachment, error)
+	GetByChecksum(ctx context.Context, articleID uint, checksum string) (*Attachment, error)
+	// UnusedChecksums returns the checksums that no attachment has.
+	UnusedChecksums(ctx context.Context, checksums []string) ([]string, error)
+	// Store stores an attachment whose blob is stored already. checkBlob is
+	// called before the attachment is committed, which fails when checkBlob
+	// does, once attachments sharing the blob can no longer delete it.
+	Store(ctx context.Context, attachment *Attachment, checkBlob func() error) error
+	// Delete deletes an attachment. When no other attachment of any article
+	// shares its blob, deleteBlob is called before the deletion is committed,
+	// and attachments sharing the blob cannot be stored meanwhile.
+	Delete(ctx context.Context, attachment *Attachment, deleteBlob func() error) error
+	// DeleteBlob calls deleteBlob when no attachment has the checksum, and
+	// attachments with the checksum cannot be stored meanwhile.
+	DeleteBlob(ctx context.Context, checksum string, deleteBlob func() error) error
+}
+
+type AttachmentService interface {
+	Fetch(ctx context.Context, articleID uint) ([]*Attachment, error)
+	// Store stores the file of an attachment of an article. When the article
+	// already has the same file, attachment is set to the existing one and
+	// false is returned.
+	Store(ctx context.Context, attachment *Attachment, body io.ReadSeeker) (bool, error)
+	// Open returns an attachment with its content, which the caller must
+	// close.
+	Open(ctx context.Context, id uint) (*Attachment, io.ReadCloser, error)
+	// OpenVariant returns a variant of an image attachment with its content,
+	// which the caller must close. The variant is made when it is not stored
+	// yet.
+	OpenVariant(ctx context.Context, id uint, name string) (*AttachmentVariant, io.ReadCloser, error)
+	Delete(ctx context.Context, articleID uint, id uint) error
+	// Cleanup removes the attachments of permanently deleted articles, and
+	// their blobs unless other attachments share them. It then removes the
+	// blobs no attachment has, left behind by attachments that failed to be
+	// stored. It returns the number of attachments and blobs removed.
+	Cleanup(ctx context.Context) (int64, error)
+}
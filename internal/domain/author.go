@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type Author struct {
 	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
@@ -9,8 +12,28 @@ type Author struct {
 	UpdatedAt time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
 }
 
+type AuthorStoreRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+type AuthorUpdateRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
 type AuthorRepository interface {
-	GetByID(id uint) (*Author, error)
-	Count() (int64, error)
-	Store(author *Author) error
+	Fetch(ctx context.Context, page uint, size uint, filter *Author) ([]*Author, uint, error)
+	GetByID(ctx context.Context, id uint) (*Author, error)
+	Count(ctx context.Context, filter *Author) (int64, error)
+	Store(ctx context.Context, author *Author) error
+	Update(ctx context.Context, author *Author) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type AuthorService interface {
+	Fetch(ctx context.Context, page uint, size uint, filter *Author) ([]*Author, uint, error)
+	GetByID(ctx context.Context, id uint) (*Author, error)
+	Count(ctx context.Context, filter *Author) (int64, error)
+	Store(ctx context.Context, author *Author) error
+	Update(ctx context.Context, author *Author) error
+	Delete(ctx context.Context, id uint) error
 }
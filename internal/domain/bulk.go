@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// BulkSkippedMessage explains the results of items that were valid but not
+// applied, because another item of an atomic bulk request failed.
+const BulkSkippedMessage = "not applied because another item failed"
+
+// BulkResult is the outcome of one item of a bulk request.
+type BulkResult struct {
+	// Index is the position of the item in the request.
+	Index   int      `json:"index"`
+	ID      uint     `json:"id,omitempty"`
+	Status  int      `json:"status"`
+	Message string   `json:"message,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// SkipPending marks the results that have no status yet as skipped.
+func SkipPending(results []BulkResult) {
+	for i := range results {
+		if results[i].Status == 0 {
+			results[i].Status = http.StatusFailedDependency
+			results[i].Message = BulkSkippedMessage
+		}
+	}
+}
+
+// BulkItemError is returned by bulk writes that failed on one item.
+type BulkItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *BulkItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e *BulkItemError) Unwrap() error {
+	return e.Err
+}
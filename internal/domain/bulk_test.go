@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSkipPending(t *testing.T) {
+	results := []BulkResult{
+		{Index: 0, ID: 1, Status: 201},
+		{Index: 1, Status: 400, Message: "validation error"},
+		{Index: 2},
+	}
+
+	SkipPending(results)
+
+	assert.Equal(t, []BulkResult{
+		{Index: 0, ID: 1, Status: 201},
+		{Index: 1, Status: 400, Message: "validation error"},
+		{Index: 2, Status: 424, Message: BulkSkippedMessage},
+	}, results)
+}
+
+func TestBulkItemError(t *testing.T) {
+	cause := errors.New("duplicate slug")
+	err := error(&BulkItemError{Index: 3, Err: cause})
+
+	assert.EqualError(t, err, "item 3: duplicate slug")
+	assert.ErrorIs(t, err, cause)
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type CommentStatus string
+
+const (
+	CommentStatusPending  CommentStatus = "pending"
+	CommentStatusApproved CommentStatus = "approved"
+	CommentStatusRejected CommentStatus = "rejected"
+	CommentStatusSpam     CommentStatus = "spam"
+
+	// CommentStatusAll is only used by listings to match every status.
+	CommentStatusAll CommentStatus = "all"
+)
+
+// Valid reports whether s is one of the known comment statuses.
+func (s CommentStatus) Valid() bool {
+	switch s {
+	case CommentStatusPending, CommentStatusApproved, CommentStatusRejected, CommentStatusSpam:
+		return true
+	}
+	return false
+}
+
+// Comment is a comment on an article, or a reply to another comment of the
+// same article.
+type Comment struct {
+	ID        uint  `json:"id" gorm:"primaryKey;autoIncrement"`
+	ArticleID uint  `json:"articleId" gorm:"index"`
+	ParentID  *uint `json:"parentId" gorm:"index"`
+	// RootID is the top-level comment of the thread of a reply, so that a
+	// whole thread is loaded at once. It is nil for top-level comments.
+	RootID      *uint         `json:"rootId" gorm:"index"`
+	Depth       uint          `json:"depth" gorm:"not null;default:0"`
+	AuthorName  string        `json:"authorName" gorm:"type:varchar(100)"`
+	AuthorEmail string        `json:"-" gorm:"type:varchar(255);index"`
+	Content     string        `json:"content" gorm:"type:text"`
+	Status      CommentStatus `json:"status" gorm:"type:varchar(20);default:pending;index" enums:"pending,approved,rejected,spam"`
+	IP          string        `json:"-" gorm:"type:varchar(45)"`
+	CreatedAt   time.Time     `json:"createdAt" gorm:"autoCreateTime;index"`
+	UpdatedAt   time.Time     `json:"updatedAt" gorm:"autoUpdateTime"`
+
+	// Replies are only set on threads.
+	Replies []*Comment `json:"replies,omitempty" gorm:"-"`
+}
+
+// CommentFilter narrows comment listings. Zero values do not filter.
+type CommentFilter struct {
+	ArticleID uint
+	Status    CommentStatus
+	// RootsOnly leaves out replies.
+	RootsOnly bool
+}
+
+// CommentCursor is the keyset position of a comment in the listing order
+// (created_at, id).
+type CommentCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uint      `json:"id"`
+}
+
+type CommentStoreRequest struct {
+	ParentID    *uint  `json:"parentId"`
+	AuthorName  string `json:"authorName" validate:"required,max=100"`
+	AuthorEmail string `json:"authorEmail" validate:"required,email,max=255"`
+	Content     string `json:"content" validate:"required,max=10000"`
+}
+
+type CommentStatusRequest struct {
+	Status CommentStatus `json:"status" validate:"required,oneof=pending approved rejected spam" enums:"pending,approved,rejected,spam"`
+}
+
+type CommentRepository interface {
+	Fetch(ctx context.Context, filter *CommentFilter, cursor *CommentCursor, size uint) ([]*Comment, *CommentCursor, error)
+	// FetchReplies returns the replies in the threads of rootIDs, oldest
+	// first.
+	FetchReplies(ctx context.Context, rootIDs []uint, status CommentStatus) ([]*Comment, error)
+	GetByID(ctx context.Context, id uint) (*Comment, error)
+	// CountApproved counts the approved comments written with email.
+	CountApproved(ctx context.Context, email string) (int64, error)
+	Store(ctx context.Context, comment *Comment) error
+	UpdateStatus(ctx context.Context, id uint, status CommentStatus) error
+	// DeleteByArticles removes the comments of the given articles. It is an
+	// ArticleDeleteHook.
+	DeleteByArticles(ctx context.Context, articleIDs []uint) error
+}
+
+type CommentService interface {
+	// FetchThreads returns the approved top-level comments of an article with
+	// their approved replies nested.
+	FetchThreads(ctx context.Context, articleID uint, cursor *CommentCursor, size uint) ([]*Comment, *CommentCursor, error)
+	Fetch(ctx context.Context, filter *CommentFilter, cursor *CommentCursor, size uint) ([]*Comment, *CommentCursor, error)
+	Store(ctx context.Context, comment *Comment) error
+	Moderate(ctx context.Context, id uint, status CommentStatus) (*Comment, error)
+}
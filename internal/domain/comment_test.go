@@ -0,0 +1,25 @@
+package domain
+
+import "testing"
+
+func TestCommentStatus_Valid(t *testing.T) {
+	tests := []struct {
+		name   string
+		status CommentStatus
+		want   bool
+	}{
+		{name: "pending", status: CommentStatusPending, want: true},
+		{name: "approved", status: CommentStatusApproved, want: true},
+		{name: "rejected", status: CommentStatusRejected, want: true},
+		{name: "spam", status: CommentStatusSpam, want: true},
+		{name: "all", status: CommentStatusAll, want: false},
+		{name: "empty", status: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Valid(); got != tt.want {
+				t.Errorf("Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
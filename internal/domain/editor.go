@@ -0,0 +1,17 @@
+package domain
+
+import "context"
+
+type editorKey struct{}
+
+// ContextWithEditor returns a copy of ctx carrying the name of the person
+// making the change, recorded in article revisions.
+func ContextWithEditor(ctx context.Context, editor string) context.Context {
+	return context.WithValue(ctx, editorKey{}, editor)
+}
+
+// EditorFromContext returns the editor stored in ctx, or an empty string.
+func EditorFromContext(ctx context.Context) string {
+	editor, _ := ctx.Value(editorKey{}).(string)
+	return editor
+}
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEditorFromContext(t *testing.T) {
+	assert.Equal(t, "", EditorFromContext(context.Background()))
+	assert.Equal(t, "jane", EditorFromContext(ContextWithEditor(context.Background(), "jane")))
+}
@@ -13,3 +13,16 @@ func NewError(code int, message string) Error {
 func (e Error) Error() string {
 	return e.Message
 }
+
+// FilterError reports an invalid filter expression in a listing.
+type FilterError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Filter  string `json:"filter"`
+	// Offset is the byte offset in the filter where the error was found.
+	Offset int `json:"offset"`
+}
+
+func (e FilterError) Error() string {
+	return e.Message
+}
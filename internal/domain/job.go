@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// JobLock keeps a background job from running on several instances at
+// once. The instance holding it runs the job, and others take it over once
+// it is released or LockedUntil has passed, when the holder stopped.
+type JobLock struct {
+	Name        string    `gorm:"primaryKey;type:varchar(64)"`
+	Holder      string    `gorm:"type:varchar(64);not null"`
+	LockedUntil time.Time `gorm:"not null"`
+}
+
+type JobLockRepository interface {
+	// Acquire takes the lock of a job for holder until the given time,
+	// unless another holder has it. It reports whether the lock was taken.
+	Acquire(ctx context.Context, name string, holder string, until time.Time) (bool, error)
+	// Release gives back the lock of a job taken by holder.
+	Release(ctx context.Context, name string, holder string) error
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RankingWindow is the period of time articles are ranked over.
+type RankingWindow string
+
+const (
+	RankingWindowDay   RankingWindow = "24h"
+	RankingWindowWeek  RankingWindow = "7d"
+	RankingWindowMonth RankingWindow = "30d"
+)
+
+// RankingWindows are the windows rankings are computed for.
+var RankingWindows = []RankingWindow{RankingWindowDay, RankingWindowWeek, RankingWindowMonth}
+
+// Valid reports whether w is one of the known ranking windows.
+func (w RankingWindow) Valid() bool {
+	return w.Duration() > 0
+}
+
+// Duration returns the length of the window, or 0 for unknown windows.
+func (w RankingWindow) Duration() time.Duration {
+	switch w {
+	case RankingWindowDay:
+		return 24 * time.Hour
+	case RankingWindowWeek:
+		return 7 * 24 * time.Hour
+	case RankingWindowMonth:
+		return 30 * 24 * time.Hour
+	}
+	return 0
+}
+
+// ArticleRanking is the place of an article in the last snapshot of the
+// rankings of a window. Only articles with engagement within the window are
+// ranked.
+type ArticleRanking struct {
+	// Window and Rank are stored as period and position, since WINDOW and
+	// RANK are reserved words of MySQL.
+	Window    RankingWindow `json:"window" gorm:"column:period;type:varchar(8);primaryKey;index:idx_article_ranking_position,priority:1" enums:"24h,7d,30d"`
+	ArticleID uint          `json:"articleId" gorm:"primaryKey;autoIncrement:false"`
+	AuthorID  uint          `json:"authorId" gorm:"index"`
+	// Rank is the place of the article among every article, from 1.
+	Rank  uint    `json:"rank" gorm:"column:position;not null;index:idx_article_ranking_position,priority:2"`
+	Score float64 `json:"score" gorm:"not null"`
+	// Views, Comments and Reactions are the engagement within the window,
+	// before it is weighed.
+	Views      uint64    `json:"views" gorm:"not null;default:0"`
+	Comments   uint64    `json:"comments" gorm:"not null;default:0"`
+	Reactions  uint64    `json:"reactions" gorm:"not null;default:0"`
+	ComputedAt time.Time `json:"computedAt"`
+
+	Article *Article `json:"article,omitempty" gorm:"foreignKey:ArticleID;constraint:-"`
+}
+
+// ArticleEngagement is engagement with a published article within the hour
+// starting At: its views, approved comments or reactions of that hour.
+type ArticleEngagement struct {
+	ArticleID uint
+	AuthorID  uint
+	At        time.Time
+	Views     uint64
+	Comments  uint64
+	Reactions uint64
+}
+
+type RankingRepository interface {
+	// FetchEngagement returns the engagement with published articles since
+	// the given time, summed by article and hour.
+	FetchEngagement(ctx context.Context, since time.Time) ([]*ArticleEngagement, error)
+	// Replace swaps the rankings of a window for new ones in one
+	// transaction.
+	Replace(ctx context.Context, window RankingWindow, rankings []*ArticleRanking) error
+	// Fetch returns the best ranked articles of a window that are still
+	// published, of a single author unless authorID is 0, with the article
+	// loaded.
+	Fetch(ctx context.Context, window RankingWindow, authorID uint, size uint) ([]*ArticleRanking, error)
+	// PruneViews removes the view buckets of the hours before the given
+	// time, which no window covers anymore.
+	PruneViews(ctx context.Context, before time.Time) (int64, error)
+	// DeleteByArticles removes the view buckets and rankings of the given
+	// articles. It is an ArticleDeleteHook.
+	DeleteByArticles(ctx context.Context, articleIDs []uint) error
+}
+
+type RankingService interface {
+	Trending(ctx context.Context, window RankingWindow, size uint) ([]*ArticleRanking, error)
+	TopByAuthor(ctx context.Context, authorID uint, window RankingWindow, size uint) ([]*ArticleRanking, error)
+	// Compute ranks the articles of every window as of now and returns the
+	// number of rankings stored.
+	Compute(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRankingWindow_Duration(t *testing.T) {
+	tests := []struct {
+		name   string
+		window RankingWindow
+		want   time.Duration
+	}{
+		{name: "day", window: RankingWindowDay, want: 24 * time.Hour},
+		{name: "week", window: RankingWindowWeek, want: 7 * 24 * time.Hour},
+		{name: "month", window: RankingWindowMonth, want: 30 * 24 * time.Hour},
+		{name: "unknown", window: "1y", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.Duration(); got != tt.want {
+				t.Errorf("Duration() = %v, want %v", got, tt.want)
+			}
+			if got := tt.window.Valid(); got != (tt.want > 0) {
+				t.Errorf("Valid() = %v, want %v", got, tt.want > 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type ReactionKind string
+
+const (
+	ReactionKindLike       ReactionKind = "like"
+	ReactionKindLove       ReactionKind = "love"
+	ReactionKindInsightful ReactionKind = "insightful"
+	ReactionKindFunny      ReactionKind = "funny"
+)
+
+// ReactionKinds are the kinds of reactions, in the order they are counted.
+var ReactionKinds = []ReactionKind{ReactionKindLike, ReactionKindLove, ReactionKindInsightful, ReactionKindFunny}
+
+// Valid reports whether k is one of the known reaction kinds.
+func (k ReactionKind) Valid() bool {
+	switch k {
+	case ReactionKindLike, ReactionKindLove, ReactionKindInsightful, ReactionKindFunny:
+		return true
+	}
+	return false
+}
+
+// Reaction is the reaction of a visitor to an article. A visitor reacts at
+// most once with each kind to an article.
+type Reaction struct {
+	ID        uint         `json:"id" gorm:"primaryKey;autoIncrement"`
+	ArticleID uint         `json:"articleId" gorm:"uniqueIndex:idx_reaction_visitor,priority:1"`
+	Kind      ReactionKind `json:"kind" gorm:"type:varchar(20);uniqueIndex:idx_reaction_visitor,priority:3" enums:"like,love,insightful,funny"`
+	// Visitor is a hash of the IP address and user agent of the visitor, so
+	// that neither is stored.
+	Visitor   string    `json:"-" gorm:"type:char(64);uniqueIndex:idx_reaction_visitor,priority:2"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime;index"`
+}
+
+// ReactionCount is the number of reactions of a kind to an article.
+type ReactionCount struct {
+	Kind  ReactionKind `json:"kind" enums:"like,love,insightful,funny"`
+	Count uint64       `json:"count"`
+}
+
+type ReactionRepository interface {
+	// Count returns the number of reactions of each kind to an article,
+	// leaving out kinds without reactions.
+	Count(ctx context.Context, articleID uint) ([]*ReactionCount, error)
+	// Store stores a reaction unless the visitor reacted with the same kind
+	// already.
+	Store(ctx context.Context, reaction *Reaction) error
+	Delete(ctx context.Context, articleID uint, visitor string, kind ReactionKind) error
+	// DeleteByArticles removes the reactions to the given articles. It is an
+	// ArticleDeleteHook.
+	DeleteByArticles(ctx context.Context, articleIDs []uint) error
+}
+
+type ReactionService interface {
+	// Count returns the number of reactions of every kind to an article.
+	Count(ctx context.Context, articleID uint) ([]*ReactionCount, error)
+	// React adds the reaction of a visitor to a published article and
+	// returns the new counts.
+	React(ctx context.Context, articleID uint, visitor string, kind ReactionKind) ([]*ReactionCount, error)
+	// Unreact removes the reaction of a visitor and returns the new counts.
+	Unreact(ctx context.Context, articleID uint, visitor string, kind ReactionKind) ([]*ReactionCount, error)
+}
@@ -0,0 +1,7 @@
+package domain
+
+// SortField orders a listing by one column.
+type SortField struct {
+	Column string
+	Desc   bool
+}
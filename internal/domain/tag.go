@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string    `json:"name" gorm:"type:varchar(64);uniqueIndex"`
+	CreatedAt time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TagUsage is a tag with the number of articles using it.
+type TagUsage struct {
+	ID           uint   `json:"id"`
+	Name         string `json:"name"`
+	ArticleCount int64  `json:"articleCount"`
+}
+
+type TagRepository interface {
+	FetchUsage(ctx context.Context, status ArticleStatus) ([]*TagUsage, error)
+	FirstOrCreate(ctx context.Context, names []string) ([]*Tag, error)
+}
+
+type TagService interface {
+	FetchUsage(ctx context.Context, status ArticleStatus) ([]*TagUsage, error)
+}
@@ -1,21 +1,44 @@
 package infrastructure
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"github.com/caarlos0/env/v10"
 	"go-clean-architecture/internal/article"
+	"go-clean-architecture/internal/attachment"
 	"go-clean-architecture/internal/author"
+	"go-clean-architecture/internal/blob"
+	"go-clean-architecture/internal/comment"
 	"go-clean-architecture/internal/config"
 	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/internal/job"
+	"go-clean-architecture/internal/ranking"
+	"go-clean-architecture/internal/reaction"
+	"go-clean-architecture/internal/tag"
 	"go-clean-architecture/pkg/xlogger"
 )
 
 var (
 	cfg config.Config
 
-	authorRepository  domain.AuthorRepository
-	articleRepository domain.ArticleRepository
+	authorRepository     domain.AuthorRepository
+	articleRepository    domain.ArticleRepository
+	tagRepository        domain.TagRepository
+	commentRepository    domain.CommentRepository
+	attachmentRepository domain.AttachmentRepository
+	rankingRepository    domain.RankingRepository
+	reactionRepository   domain.ReactionRepository
+	jobLockRepository    domain.JobLockRepository
+	searchIndex          domain.ArticleSearchIndex
+	blobStore            domain.BlobStore
 
-	articleService domain.ArticleService
+	authorService     domain.AuthorService
+	articleService    domain.ArticleService
+	tagService        domain.TagService
+	commentService    domain.CommentService
+	attachmentService domain.AttachmentService
+	rankingService    domain.RankingService
+	reactionService   domain.ReactionService
 )
 
 func init() {
@@ -23,10 +46,56 @@ func init() {
 		panic(err)
 	}
 	xlogger.Setup(cfg)
+	if cfg.Pagination.CursorSecret == "" {
+		cfg.Pagination.CursorSecret = randomSecret()
+		xlogger.Logger.Warn().Msg("PAGINATION_CURSOR_SECRET is not set, cursors will not survive a restart")
+	}
+	if cfg.Attachment.URLSecret == "" {
+		cfg.Attachment.URLSecret = randomSecret()
+		xlogger.Logger.Warn().Msg("ATTACHMENT_URL_SECRET is not set, download URLs will not survive a restart")
+	}
 	dbSetup()
+	storageSetup()
 
 	authorRepository = author.NewMysqlAuthorRepository(db)
-	articleRepository = article.NewMysqlArticleRepository(db)
+	articleRepository = article.NewMysqlArticleRepository(db, searchIndex)
+	tagRepository = tag.NewMysqlTagRepository(db)
+	commentRepository = comment.NewMysqlCommentRepository(db)
+	attachmentRepository = attachment.NewMysqlAttachmentRepository(db)
+	rankingRepository = ranking.NewMysqlRankingRepository(db)
+	reactionRepository = reaction.NewMysqlReactionRepository(db)
+	jobLockRepository = job.NewMysqlJobLockRepository(db)
+
+	authorService = author.NewAuthorService(authorRepository, articleRepository)
+	articleService = article.NewArticleService(articleRepository, authorRepository, tagRepository, cfg,
+		commentRepository.DeleteByArticles,
+		rankingRepository.DeleteByArticles,
+		reactionRepository.DeleteByArticles,
+	)
+	tagService = tag.NewTagService(tagRepository)
+	commentService = comment.NewCommentService(commentRepository, articleRepository, cfg)
+	attachmentService = attachment.NewAttachmentService(attachmentRepository, articleRepository, blobStore, cfg)
+	rankingService = ranking.NewRankingService(rankingRepository, authorRepository, jobLockRepository, cfg)
+	reactionService = reaction.NewReactionService(reactionRepository, articleRepository)
+}
+
+func randomSecret() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// storageSetup selects the blob store of attachments.
+func storageSetup() {
+	if cfg.Storage.Driver == "s3" {
+		blobStore = blob.NewS3BlobStore(cfg.Storage.S3)
+		return
+	}
 
-	articleService = article.NewArticleService(articleRepository, authorRepository)
+	var err error
+	if blobStore, err = blob.NewLocalBlobStore(cfg.Storage.LocalPath); err != nil {
+		panic(err)
+	}
 }
@@ -1,6 +1,7 @@
 package infrastructure
 
 import (
+	"context"
 	"fmt"
 	"github.com/gofiber/contrib/fiberzerolog"
 	"github.com/gofiber/fiber/v2"
@@ -8,10 +9,34 @@ import (
 	recover2 "github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"go-clean-architecture/internal/article"
+	"go-clean-architecture/internal/attachment"
+	"go-clean-architecture/internal/author"
+	"go-clean-architecture/internal/comment"
 	"go-clean-architecture/internal/docs"
+	"go-clean-architecture/internal/middleware/bodylimit"
+	"go-clean-architecture/internal/middleware/editor"
+	"go-clean-architecture/internal/middleware/timeout"
+	"go-clean-architecture/internal/ranking"
+	"go-clean-architecture/internal/reaction"
+	"go-clean-architecture/internal/tag"
 	"go-clean-architecture/pkg/xlogger"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 )
 
+// uploadOverhead leaves room for the multipart headers around an uploaded
+// file.
+const uploadOverhead = 64 << 10
+
+// bodyLimit returns the largest request body accepted, large enough for
+// attachments.
+func bodyLimit() int {
+	return max(fiber.DefaultBodyLimit, int(cfg.Attachment.MaxSize)+uploadOverhead)
+}
+
 func Run() {
 	logger := xlogger.Logger
 
@@ -19,6 +44,11 @@ func Run() {
 		ProxyHeader:           cfg.ProxyHeader,
 		DisableStartupMessage: true,
 		ErrorHandler:          defaultErrorHandler,
+		BodyLimit:             bodyLimit(),
+		// imports are decoded as they arrive; other bodies are still read
+		// whole by the bodylimit middleware
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
 	})
 
 	app.Use(fiberzerolog.New(fiberzerolog.Config{
@@ -26,16 +56,55 @@ func Run() {
 		Fields: cfg.LogFields,
 	}))
 	app.Use(recover2.New())
-	app.Use(etag.New())
+	app.Use(bodylimit.New(bodyLimit(), func(c *fiber.Ctx) bool {
+		return c.Method() == fiber.MethodPost && c.Path() == "/api/articles/import"
+	}))
+	app.Use(etag.New(etag.Config{
+		// exports and downloads are streamed, while an etag needs the whole
+		// body in memory
+		Next: func(c *fiber.Ctx) bool {
+			return strings.HasSuffix(c.Path(), "/export") || strings.HasSuffix(c.Path(), "/download")
+		},
+	}))
 	app.Use(requestid.New())
+	app.Use(timeout.New(cfg.RequestTimeout))
+	app.Use(editor.New())
 
 	api := app.Group("/api")
 	docs.NewHttpHandler(api.Group("/docs"))
-	article.NewHttpHandler(api.Group("/articles"), articleService)
+	ranking.NewHttpHandler(api, rankingService, cfg)
+	author.NewHttpHandler(api.Group("/authors"), authorService, articleService, cfg)
+	article.NewHttpHandler(api.Group("/articles"), articleService, cfg)
+	tag.NewHttpHandler(api.Group("/tags"), tagService)
+	comment.NewHttpHandler(api, commentService, cfg)
+	reaction.NewHttpHandler(api, reactionService)
+	attachment.NewHttpHandler(api, attachmentService, cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var wg sync.WaitGroup
+	startJobs(ctx, &wg)
+
+	go func() {
+		<-ctx.Done()
+		logger.Info().Msg("Server is shutting down")
+		if err := app.Shutdown(); err != nil {
+			logger.Error().Err(err).Msg("Server failed to shut down")
+		}
+	}()
 
 	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 	logger.Info().Msgf("Server is running on address: %s", addr)
 	if err := app.Listen(addr); err != nil {
 		logger.Fatal().Err(err).Msg("Server failed to start")
 	}
+
+	stop()
+	wg.Wait()
+
+	// the views recorded since the last flush would be lost otherwise
+	if err := flushViews(context.Background()); err != nil {
+		logger.Error().Err(err).Msg("Failed to flush article views")
+	}
 }
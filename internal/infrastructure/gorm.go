@@ -1,8 +1,10 @@
 package infrastructure
 
 import (
+	"context"
 	"github.com/glebarez/sqlite"
 	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/internal/search"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	gormLogger "gorm.io/gorm/logger"
@@ -19,10 +21,12 @@ func dbSetup() {
 		}), &gorm.Config{
 			Logger: l,
 		})
+		searchIndex = search.NewMysqlArticleSearchIndex(db)
 	} else {
 		db, err = gorm.Open(sqlite.Open(cfg.Database.DSN), &gorm.Config{
 			Logger: l,
 		})
+		searchIndex = search.NewSqliteArticleSearchIndex(db)
 	}
 
 	if err != nil {
@@ -33,9 +37,21 @@ func dbSetup() {
 		if err := db.AutoMigrate(
 			&domain.Author{},
 			&domain.Article{},
+			&domain.ArticleSlug{},
+			&domain.Tag{},
+			&domain.ArticleRevision{},
+			&domain.Comment{},
+			&domain.Reaction{},
+			&domain.Attachment{},
+			&domain.ArticleViewBucket{},
+			&domain.ArticleRanking{},
+			&domain.JobLock{},
 		); err != nil {
 			panic(err)
 		}
+		if err := searchIndex.Setup(context.Background()); err != nil {
+			panic(err)
+		}
 	}
 
 	var count int64
@@ -0,0 +1,97 @@
+package infrastructure
+
+import (
+	"context"
+	"go-clean-architecture/pkg/xlogger"
+	"sync"
+	"time"
+)
+
+// startJobs starts the background jobs. They stop when ctx is cancelled and
+// wg is released once every job has returned.
+func startJobs(ctx context.Context, wg *sync.WaitGroup) {
+	if cfg.Trash.Retention > 0 && cfg.Trash.PurgeInterval > 0 {
+		runEvery(ctx, wg, "trash purge", cfg.Trash.PurgeInterval, purgeTrash)
+	}
+	if cfg.Scheduler.Interval > 0 {
+		runEvery(ctx, wg, "article scheduler", cfg.Scheduler.Interval, runSchedule)
+	}
+	if cfg.Attachment.CleanupInterval > 0 {
+		runEvery(ctx, wg, "attachment cleanup", cfg.Attachment.CleanupInterval, cleanupAttachments)
+	}
+	if cfg.Views.FlushInterval > 0 {
+		runEvery(ctx, wg, "view flush", cfg.Views.FlushInterval, flushViews)
+	}
+	if cfg.Trending.Interval > 0 {
+		runEvery(ctx, wg, "trending rankings", cfg.Trending.Interval, computeRankings)
+	}
+}
+
+func runEvery(ctx context.Context, wg *sync.WaitGroup, name string, interval time.Duration, job func(ctx context.Context) error) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := job(ctx); err != nil && ctx.Err() == nil {
+				xlogger.Logger.Error().Err(err).Str("job", name).Msg("Background job failed")
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func purgeTrash(ctx context.Context) error {
+	count, err := articleService.PurgeTrash(ctx, cfg.Trash.Retention)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		xlogger.Logger.Info().Int64("count", count).Msg("Purged deleted articles")
+	}
+	return nil
+}
+
+func runSchedule(ctx context.Context) error {
+	published, unpublished, err := articleService.RunSchedule(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if published > 0 || unpublished > 0 {
+		xlogger.Logger.Info().Int64("published", published).Int64("unpublished", unpublished).Msg("Applied article schedule")
+	}
+	return nil
+}
+
+func cleanupAttachments(ctx context.Context) error {
+	count, err := attachmentService.Cleanup(ctx)
+	if count > 0 {
+		xlogger.Logger.Info().Int64("count", count).Msg("Removed orphaned attachments and blobs")
+	}
+	return err
+}
+
+func flushViews(ctx context.Context) error {
+	count, err := articleService.FlushViews(ctx)
+	if count > 0 {
+		xlogger.Logger.Debug().Int64("count", count).Msg("Flushed article views")
+	}
+	return err
+}
+
+func computeRankings(ctx context.Context) error {
+	count, err := rankingService.Compute(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	xlogger.Logger.Debug().Int64("count", count).Msg("Computed article rankings")
+	return nil
+}
@@ -0,0 +1,22 @@
+package infrastructure
+
+import (
+	"context"
+	"go-clean-architecture/pkg/xlogger"
+)
+
+// Reindex creates the article search index when it is missing and rebuilds
+// it from the stored articles.
+func Reindex() {
+	logger := xlogger.Logger
+	ctx := context.Background()
+
+	if err := searchIndex.Setup(ctx); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to set up the search index")
+	}
+	if err := searchIndex.Reindex(ctx); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to rebuild the search index")
+	}
+
+	logger.Info().Msg("Search index rebuilt")
+}
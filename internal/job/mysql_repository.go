@@ -0,0 +1,45 @@
+package job
+
+import (
+	"context"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type mysqlJobLockRepository struct {
+	db *gorm.DB
+}
+
+func NewMysqlJobLockRepository(db *gorm.DB) domain.JobLockRepository {
+	return &mysqlJobLockRepository{db: db}
+}
+
+func (r *mysqlJobLockRepository) Acquire(ctx context.Context, name string, holder string, until time.Time) (bool, error) {
+	db := r.db.WithContext(ctx)
+
+	// the lock of a job is stored the first time it runs
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.JobLock{Name: name, Holder: holder, LockedUntil: until})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// the update checks the lock and takes it at once, so only one holder
+	// takes a lock that was released or expired
+	result = db.Model(&domain.JobLock{}).
+		Where("name = ? AND (locked_until < ? OR holder = ?)", name, time.Now(), holder).
+		Updates(map[string]any{"holder": holder, "locked_until": until})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Release lets the lock expire now, unless another holder took it over.
+func (r *mysqlJobLockRepository) Release(ctx context.Context, name string, holder string) error {
+	return r.db.WithContext(ctx).Model(&domain.JobLock{}).
+		Where("name = ? AND holder = ?", name, holder).
+		Update("locked_until", time.Now()).Error
+}
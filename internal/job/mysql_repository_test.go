@@ -0,0 +1,153 @@
+package job
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func mockDBConnection() (*gorm.DB, sqlmock.Sqlmock, error) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gdb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Info),
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gdb, mock, nil
+}
+
+const (
+	insertQuery = "INSERT INTO `job_locks` (`name`,`holder`,`locked_until`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `name`=`name`"
+	updateQuery = "UPDATE `job_locks` SET `holder`=?,`locked_until`=? WHERE name = ? AND (locked_until < ? OR holder = ?)"
+)
+
+func TestMysqlJobLockRepository_Acquire(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	until := time.Now().Add(time.Minute)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+		WithArgs("rankings", "a", until).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlJobLockRepository(db)
+
+	acquired, err := repo.Acquire(context.Background(), "rankings", "a", until)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlJobLockRepository_Acquire_Stored(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	until := time.Now().Add(time.Minute)
+	for _, rows := range []int64{1, 0} {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+			WithArgs("rankings", "a", until).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+			WithArgs("a", until, "rankings", sqlmock.AnyArg(), "a").
+			WillReturnResult(sqlmock.NewResult(0, rows))
+		mock.ExpectCommit()
+	}
+
+	repo := NewMysqlJobLockRepository(db)
+
+	// released or expired
+	acquired, err := repo.Acquire(context.Background(), "rankings", "a", until)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// held by another instance
+	acquired, err = repo.Acquire(context.Background(), "rankings", "a", until)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlJobLockRepository_Release(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "UPDATE `job_locks` SET `locked_until`=? WHERE name = ? AND holder = ?"
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(sqlmock.AnyArg(), "rankings", "a").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlJobLockRepository(db)
+
+	assert.NoError(t, repo.Release(context.Background(), "rankings", "a"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlJobLockRepository_Sqlite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Silent),
+	})
+	assert.NoError(t, err)
+
+	// every connection to :memory: opens a database of its own
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	assert.NoError(t, db.AutoMigrate(&domain.JobLock{}))
+
+	ctx := context.Background()
+	repo := NewMysqlJobLockRepository(db)
+	until := time.Now().Add(time.Minute)
+
+	for _, step := range []struct {
+		holder   string
+		acquired bool
+	}{{"a", true}, {"b", false}, {"a", true}} {
+		acquired, err := repo.Acquire(ctx, "rankings", step.holder, until)
+		assert.NoError(t, err)
+		assert.Equal(t, step.acquired, acquired, step.holder)
+	}
+
+	// releasing the lock of another holder changes nothing
+	assert.NoError(t, repo.Release(ctx, "rankings", "b"))
+	acquired, err := repo.Acquire(ctx, "rankings", "b", until)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+
+	assert.NoError(t, repo.Release(ctx, "rankings", "a"))
+	acquired, err = repo.Acquire(ctx, "rankings", "b", until)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// an expired lock is taken over
+	acquired, err = repo.Acquire(ctx, "other", "a", time.Now().Add(-time.Second))
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	acquired, err = repo.Acquire(ctx, "other", "b", until)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+}
@@ -0,0 +1,49 @@
+package bodylimit
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"io"
+)
+
+// New rejects request bodies larger than limit with 413. It is meant for a
+// server that streams request bodies: such a server no longer rejects large
+// or chunked bodies itself, so every route but the ones next returns true
+// for gets its body read here, up to the limit. A nil next checks every
+// route.
+func New(limit int, next func(c *fiber.Ctx) bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if next != nil && next(c) {
+			return c.Next()
+		}
+
+		length := c.Request().Header.ContentLength()
+		if length > limit {
+			return tooLarge(c)
+		}
+
+		stream := c.Context().RequestBodyStream()
+		if length >= 0 || stream == nil {
+			return c.Next()
+		}
+
+		// the length of a chunked body is only known once it is read
+		body, err := io.ReadAll(io.LimitReader(stream, int64(limit)+1))
+		if err != nil {
+			return fiber.ErrBadRequest
+		}
+		if len(body) > limit {
+			return tooLarge(c)
+		}
+		c.Request().ResetBody()
+		c.Request().SetBody(body)
+		c.Request().Header.SetContentLength(len(body))
+		return c.Next()
+	}
+}
+
+// tooLarge closes the connection after the response, since the rest of the
+// body is left unread on it.
+func tooLarge(c *fiber.Ctx) error {
+	c.Context().SetConnectionClose()
+	return fiber.ErrRequestEntityTooLarge
+}
@@ -0,0 +1,68 @@
+package bodylimit
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newApp(next func(c *fiber.Ctx) bool) *fiber.App {
+	app := fiber.New(fiber.Config{StreamRequestBody: true, BodyLimit: 4})
+	app.Use(New(8, next))
+	app.Post("/", func(c *fiber.Ctx) error {
+		return c.Send(c.Body())
+	})
+	return app
+}
+
+func TestBodyLimit_Allowed(t *testing.T) {
+	resp, err := newApp(nil).Test(httptest.NewRequest("POST", "/", strings.NewReader("12345678")))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "12345678", string(body))
+}
+
+func TestBodyLimit_TooLarge(t *testing.T) {
+	resp, err := newApp(nil).Test(httptest.NewRequest("POST", "/", strings.NewReader("123456789")))
+	assert.NoError(t, err)
+	assert.Equal(t, 413, resp.StatusCode)
+}
+
+func TestBodyLimit_Chunked(t *testing.T) {
+	t.Run("allowed", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader("1234567"))
+		req.ContentLength = -1
+		req.TransferEncoding = []string{"chunked"}
+
+		resp, err := newApp(nil).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "1234567", string(body))
+	})
+
+	t.Run("too-large", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader("123456789"))
+		req.ContentLength = -1
+		req.TransferEncoding = []string{"chunked"}
+
+		resp, err := newApp(nil).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 413, resp.StatusCode)
+	})
+}
+
+func TestBodyLimit_Next(t *testing.T) {
+	app := newApp(func(c *fiber.Ctx) bool { return true })
+	resp, err := app.Test(httptest.NewRequest("POST", "/", strings.NewReader("123456789")))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
@@ -0,0 +1,33 @@
+package editor
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/domain"
+	"strings"
+)
+
+const (
+	HeaderEditor = "X-Editor"
+
+	// maxLength matches the size of the editor column of article revisions.
+	maxLength = 255
+)
+
+// New stores the name sent in the X-Editor header in the request context, so
+// the repositories can record who made a change. There is no authentication
+// yet, so the header is taken as is.
+func New() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		name := strings.TrimSpace(c.Get(HeaderEditor))
+		if name == "" {
+			return c.Next()
+		}
+
+		if runes := []rune(name); len(runes) > maxLength {
+			name = string(runes[:maxLength])
+		}
+
+		c.SetUserContext(domain.ContextWithEditor(c.UserContext(), name))
+		return c.Next()
+	}
+}
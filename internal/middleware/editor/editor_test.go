@@ -0,0 +1,44 @@
+package editor
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	app := fiber.New()
+	app.Use(New())
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(domain.EditorFromContext(c.UserContext()))
+	})
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "with editor", header: " jane ", expected: "jane"},
+		{name: "without editor", header: "", expected: ""},
+		{name: "too long", header: strings.Repeat("é", 300), expected: strings.Repeat("é", 255)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			if tt.header != "" {
+				req.Header.Set(HeaderEditor, tt.header)
+			}
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(body))
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+// New attaches a context with the given deadline to every request, so
+// handlers can pass c.UserContext() down to the service and repository
+// layers. A non-positive duration disables the deadline.
+func New(duration time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if duration <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), duration)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fiber.ErrRequestTimeout
+		}
+		return err
+	}
+}
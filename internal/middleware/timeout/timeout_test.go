@@ -0,0 +1,51 @@
+package timeout
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeout_SetsDeadline(t *testing.T) {
+	app := fiber.New()
+	app.Use(New(time.Second))
+	app.Get("/", func(c *fiber.Ctx) error {
+		_, ok := c.UserContext().Deadline()
+		assert.True(t, ok)
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestTimeout_Exceeded(t *testing.T) {
+	app := fiber.New()
+	app.Use(New(10 * time.Millisecond))
+	app.Get("/", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.UserContext().Err()
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 408, resp.StatusCode)
+}
+
+func TestTimeout_Disabled(t *testing.T) {
+	app := fiber.New()
+	app.Use(New(0))
+	app.Get("/", func(c *fiber.Ctx) error {
+		_, ok := c.UserContext().Deadline()
+		assert.False(t, ok)
+		return context.Canceled
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 500, resp.StatusCode)
+}
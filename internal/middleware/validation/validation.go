@@ -6,22 +6,15 @@ import (
 	"go-clean-architecture/internal/domain"
 )
 
+var validate = validator.New(validator.WithRequiredStructEnabled())
+
 func New[V any]() fiber.Handler {
-	validate := validator.New(validator.WithRequiredStructEnabled())
 	return func(c *fiber.Ctx) error {
 		var v V
 		if err := c.BodyParser(&v); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
-		if err := validate.Struct(v); err != nil {
-			var errors []string
-			for _, err := range err.(validator.ValidationErrors) {
-				message := err.Field() + " is " + err.Tag()
-				if err.Param() != "" {
-					message += " " + err.Param()
-				}
-				errors = append(errors, message)
-			}
+		if errors := Validate(v); errors != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
 				Code:    fiber.StatusBadRequest,
 				Errors:  errors,
@@ -32,3 +25,27 @@ func New[V any]() fiber.Handler {
 		return c.Next()
 	}
 }
+
+// Validate checks v against its validate struct tags and returns one message
+// per failing field, or nil when v is valid.
+func Validate(v any) []string {
+	err := validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return []string{err.Error()}
+	}
+
+	var errors []string
+	for _, err := range validationErrors {
+		message := err.Field() + " is " + err.Tag()
+		if err.Param() != "" {
+			message += " " + err.Param()
+		}
+		errors = append(errors, message)
+	}
+	return errors
+}
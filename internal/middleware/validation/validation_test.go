@@ -59,3 +59,17 @@ func TestValidation_InvalidBodyPost(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 400, resp.StatusCode)
 }
+
+func TestValidate(t *testing.T) {
+	type Payload struct {
+		Name string `json:"name" validate:"required,min=5"`
+	}
+
+	assert.Nil(t, Validate(Payload{Name: "John Doe"}))
+	assert.Equal(t, []string{"Name is required"}, Validate(Payload{}))
+	assert.Equal(t, []string{"Name is min 5"}, Validate(Payload{Name: "John"}))
+}
+
+func TestValidate_InvalidValue(t *testing.T) {
+	assert.Len(t, Validate("not a struct"), 1)
+}
@@ -0,0 +1,120 @@
+package ranking
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+)
+
+type HttpRankingHandler struct {
+	rankingSvc domain.RankingService
+	cfg        config.Config
+}
+
+// NewHttpHandler registers the ranking routes. They have to be registered
+// before the article routes, which would take /articles/trending for the
+// article with ID "trending".
+func NewHttpHandler(r fiber.Router, rankingSvc domain.RankingService, cfg config.Config) {
+	handler := &HttpRankingHandler{
+		rankingSvc: rankingSvc,
+		cfg:        cfg,
+	}
+	r.Get("/articles/trending", handler.Trending)
+	r.Get("/authors/:id/top", handler.TopByAuthor)
+}
+
+// Trending used to get the trending articles
+//
+//	@Summary		Get trending articles
+//	@Description	Get the published articles with the most engagement within a window, best first. Views,
+//	@Description	approved comments and reactions weigh less as they age. Rankings are computed in the background,
+//	@Description	so they may lag behind by TRENDING_INTERVAL.
+//	@Tags			articles
+//	@Accept			json
+//	@Produce		json
+//	@Param			window	query		string					false	"Window of the ranking (default 24h)"	Enums(24h, 7d, 30d)
+//	@Param			size	query		int						false	"Number of articles (default 10)"
+//	@Success		200		{array}		domain.ArticleRanking	"List of rankings"
+//	@Failure		400		{object}	domain.Error			"Bad Request"
+//	@Failure		500		{object}	domain.Error			"Internal Server Error"
+//	@Router			/articles/trending [get]
+func (h *HttpRankingHandler) Trending(c *fiber.Ctx) error {
+	window, size, err := h.parseQuery(c, domain.RankingWindowDay)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	rankings, err := h.rankingSvc.Trending(c.UserContext(), window, size)
+	if err != nil {
+		return err
+	}
+	if rankings == nil {
+		return c.JSON([]domain.ArticleRanking{})
+	}
+	return c.JSON(rankings)
+}
+
+// TopByAuthor used to get the top articles of an author
+//
+//	@Summary		Get top articles of an author
+//	@Description	Get the published articles of an author with the most engagement within a window, best first.
+//	@Description	The rank of each article is its place among the articles of every author.
+//	@Tags			authors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Author ID"
+//	@Param			window	query		string					false	"Window of the ranking (default 30d)"	Enums(24h, 7d, 30d)
+//	@Param			size	query		int						false	"Number of articles (default 10)"
+//	@Success		200		{array}		domain.ArticleRanking	"List of rankings"
+//	@Failure		400		{object}	domain.Error			"Bad Request"
+//	@Failure		404		{object}	domain.Error			"Not Found"
+//	@Failure		500		{object}	domain.Error			"Internal Server Error"
+//	@Router			/authors/{id}/top [get]
+func (h *HttpRankingHandler) TopByAuthor(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	window, size, err := h.parseQuery(c, domain.RankingWindowMonth)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	rankings, err := h.rankingSvc.TopByAuthor(c.UserContext(), uint(id), window, size)
+	if err != nil {
+		return err
+	}
+	if rankings == nil {
+		return c.JSON([]domain.ArticleRanking{})
+	}
+	return c.JSON(rankings)
+}
+
+// parseQuery reads the window and the number of rankings asked for.
+func (h *HttpRankingHandler) parseQuery(c *fiber.Ctx, defaultWindow domain.RankingWindow) (domain.RankingWindow, uint, error) {
+	window := domain.RankingWindow(c.Query("window", string(defaultWindow)))
+	if !window.Valid() {
+		return "", 0, errors.New("window must be one of 24h, 7d or 30d")
+	}
+
+	size := c.QueryInt("size", 10)
+	if size <= 0 {
+		return "", 0, errors.New("size must be a positive integer")
+	}
+	if maxSize := h.cfg.Pagination.MaxSize; maxSize > 0 && size > maxSize {
+		return "", 0, fmt.Errorf("size must not be greater than %d", maxSize)
+	}
+	return window, uint(size), nil
+}
@@ -0,0 +1,117 @@
+package ranking
+
+import (
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHttpRankingHandler_Trending(t *testing.T) {
+	mockService := new(mocks.RankingService)
+	cfg := config.Config{Pagination: config.Pagination{MaxSize: 50}}
+
+	t.Run("success", func(t *testing.T) {
+		rankings := []*domain.ArticleRanking{
+			{Window: domain.RankingWindowWeek, ArticleID: 2, Rank: 1, Score: 7, Article: &domain.Article{ID: 2, Title: "Trending"}},
+		}
+		mockService.On("Trending", mock.Anything, domain.RankingWindowWeek, uint(5)).
+			Return(rankings, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/articles/trending?window=7d&size=5", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var result []domain.ArticleRanking
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, uint(1), result[0].Rank)
+			assert.Equal(t, "Trending", result[0].Article.Title)
+		}
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-default", func(t *testing.T) {
+		mockService.On("Trending", mock.Anything, domain.RankingWindowDay, uint(10)).
+			Return(nil, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/articles/trending", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var result []domain.ArticleRanking
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.NotNil(t, result)
+		assert.Empty(t, result)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-window", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/articles/trending?window=1y", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+
+	t.Run("error-size", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/articles/trending?size=51", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+}
+
+func TestHttpRankingHandler_TopByAuthor(t *testing.T) {
+	mockService := new(mocks.RankingService)
+	cfg := config.Config{Pagination: config.Pagination{MaxSize: 50}}
+
+	t.Run("success", func(t *testing.T) {
+		rankings := []*domain.ArticleRanking{{Window: domain.RankingWindowMonth, ArticleID: 3, AuthorID: 1, Rank: 4}}
+		mockService.On("TopByAuthor", mock.Anything, uint(1), domain.RankingWindowMonth, uint(10)).
+			Return(rankings, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/authors/1/top", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var result []domain.ArticleRanking
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, uint(4), result[0].Rank)
+		}
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("TopByAuthor", mock.Anything, uint(2), domain.RankingWindowDay, uint(10)).
+			Return(nil, fiber.NewError(fiber.StatusNotFound, "author not found")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/authors/2/top?window=24h", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService, cfg)
+		resp, err := app.Test(httptest.NewRequest("GET", "/authors/abc/top", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+}
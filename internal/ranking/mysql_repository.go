@@ -0,0 +1,140 @@
+package ranking
+
+import (
+	"context"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"time"
+)
+
+// insertBatchSize is the number of rankings inserted by a single statement.
+const insertBatchSize = 500
+
+type mysqlRankingRepository struct {
+	db *gorm.DB
+}
+
+func NewMysqlRankingRepository(db *gorm.DB) domain.RankingRepository {
+	return &mysqlRankingRepository{db: db}
+}
+
+// hourlyCount is the number of rows about an article within an hour,
+// counted from the start of the engagement.
+type hourlyCount struct {
+	ArticleID uint
+	AuthorID  uint
+	Hour      int64
+	Count     uint64
+}
+
+// FetchEngagement sums the engagement with published articles since the
+// given time by article and by hour: the views of each hour, followed by the
+// approved comments and the reactions of each hour.
+func (r *mysqlRankingRepository) FetchEngagement(ctx context.Context, since time.Time) ([]*domain.ArticleEngagement, error) {
+	var engagement []*domain.ArticleEngagement
+	if err := r.db.WithContext(ctx).Model(&domain.ArticleViewBucket{}).
+		Select("article_view_buckets.article_id, articles.author_id, article_view_buckets.hour AS at, SUM(article_view_buckets.views) AS views").
+		Joins("JOIN articles ON articles.id = article_view_buckets.article_id").
+		Where("article_view_buckets.hour >= ? AND articles.status = ? AND articles.deleted_at IS NULL", since, domain.ArticleStatusPublished).
+		Group("article_view_buckets.article_id, articles.author_id, article_view_buckets.hour").
+		Scan(&engagement).Error; err != nil {
+		return nil, err
+	}
+
+	comments, err := r.countByHour(ctx, "comments", since, "comments.status = ?", domain.CommentStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comments {
+		engagement = append(engagement, &domain.ArticleEngagement{
+			ArticleID: c.ArticleID, AuthorID: c.AuthorID, At: since.Add(time.Duration(c.Hour) * time.Hour), Comments: c.Count,
+		})
+	}
+
+	reactions, err := r.countByHour(ctx, "reactions", since, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range reactions {
+		engagement = append(engagement, &domain.ArticleEngagement{
+			ArticleID: c.ArticleID, AuthorID: c.AuthorID, At: since.Add(time.Duration(c.Hour) * time.Hour), Reactions: c.Count,
+		})
+	}
+	return engagement, nil
+}
+
+// countByHour counts the rows of table about published articles created
+// since the given time, and matching the condition unless it is empty, by
+// article and by hour from since.
+func (r *mysqlRankingRepository) countByHour(ctx context.Context, table string, since time.Time, condition string, args ...any) ([]*hourlyCount, error) {
+	query := r.db.WithContext(ctx).Table(table).
+		Select(table+".article_id, articles.author_id, "+r.hoursSince(table+".created_at")+" AS hour, COUNT(*) AS count", since).
+		Joins("JOIN articles ON articles.id = "+table+".article_id").
+		Where(table+".created_at >= ? AND articles.status = ? AND articles.deleted_at IS NULL", since, domain.ArticleStatusPublished)
+	if condition != "" {
+		query = query.Where(condition, args...)
+	}
+
+	var counts []*hourlyCount
+	if err := query.Group(table + ".article_id, articles.author_id, hour").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// hoursSince returns the SQL expression of the number of whole hours from
+// the time given as its argument to column, which MySQL and SQLite write
+// differently.
+func (r *mysqlRankingRepository) hoursSince(column string) string {
+	if r.db.Dialector.Name() == "sqlite" {
+		return "(CAST(strftime('%s', " + column + ") AS INTEGER) - CAST(strftime('%s', ?) AS INTEGER)) / 3600"
+	}
+	return "TIMESTAMPDIFF(HOUR, ?, " + column + ")"
+}
+
+func (r *mysqlRankingRepository) Replace(ctx context.Context, window domain.RankingWindow, rankings []*domain.ArticleRanking) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("period = ?", window).Delete(&domain.ArticleRanking{}).Error; err != nil {
+			return err
+		}
+		if len(rankings) == 0 {
+			return nil
+		}
+		return tx.Omit("Article").CreateInBatches(rankings, insertBatchSize).Error
+	})
+}
+
+// Fetch returns the rankings best first. Articles that were unpublished or
+// deleted since the rankings were computed are left out, which leaves gaps
+// in the ranks until the next computation.
+func (r *mysqlRankingRepository) Fetch(ctx context.Context, window domain.RankingWindow, authorID uint, size uint) ([]*domain.ArticleRanking, error) {
+	query := r.db.WithContext(ctx).
+		Select("article_rankings.*").
+		Joins("JOIN articles ON articles.id = article_rankings.article_id").
+		Where("article_rankings.period = ? AND articles.status = ? AND articles.deleted_at IS NULL", window, domain.ArticleStatusPublished)
+	if authorID != 0 {
+		query = query.Where("article_rankings.author_id = ?", authorID)
+	}
+
+	var rankings []*domain.ArticleRanking
+	if err := query.Preload("Article").
+		Order("article_rankings.position").Limit(int(size)).
+		Find(&rankings).Error; err != nil {
+		return nil, err
+	}
+	return rankings, nil
+}
+
+func (r *mysqlRankingRepository) PruneViews(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("hour < ?", before).Delete(&domain.ArticleViewBucket{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *mysqlRankingRepository) DeleteByArticles(ctx context.Context, articleIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("article_id IN ?", articleIDs).Delete(&domain.ArticleViewBucket{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("article_id IN ?", articleIDs).Delete(&domain.ArticleRanking{}).Error
+	})
+}
@@ -0,0 +1,233 @@
+package ranking
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func mockDBConnection() (*gorm.DB, sqlmock.Sqlmock, error) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gdb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Info),
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gdb, mock, nil
+}
+
+func TestMysqlRankingRepository_FetchEngagement(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	since := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	viewQuery := "SELECT article_view_buckets.article_id, articles.author_id, article_view_buckets.hour AS at, SUM(article_view_buckets.views) AS views " +
+		"FROM `article_view_buckets` JOIN articles ON articles.id = article_view_buckets.article_id " +
+		"WHERE article_view_buckets.hour >= ? AND articles.status = ? AND articles.deleted_at IS NULL " +
+		"GROUP BY article_view_buckets.article_id, articles.author_id, article_view_buckets.hour"
+	commentQuery := "SELECT comments.article_id, articles.author_id, TIMESTAMPDIFF(HOUR, ?, comments.created_at) AS hour, COUNT(*) AS count " +
+		"FROM `comments` JOIN articles ON articles.id = comments.article_id " +
+		"WHERE (comments.created_at >= ? AND articles.status = ? AND articles.deleted_at IS NULL) AND comments.status = ? " +
+		"GROUP BY comments.article_id, articles.author_id, hour"
+	reactionQuery := "SELECT reactions.article_id, articles.author_id, TIMESTAMPDIFF(HOUR, ?, reactions.created_at) AS hour, COUNT(*) AS count " +
+		"FROM `reactions` JOIN articles ON articles.id = reactions.article_id " +
+		"WHERE reactions.created_at >= ? AND articles.status = ? AND articles.deleted_at IS NULL " +
+		"GROUP BY reactions.article_id, articles.author_id, hour"
+
+	mock.ExpectQuery(regexp.QuoteMeta(viewQuery)).
+		WithArgs(since, domain.ArticleStatusPublished).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "author_id", "at", "views"}).
+			AddRow(1, 2, since, 5))
+	mock.ExpectQuery(regexp.QuoteMeta(commentQuery)).
+		WithArgs(since, since, domain.ArticleStatusPublished, domain.CommentStatusApproved).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "author_id", "hour", "count"}).
+			AddRow(1, 2, 1, 3))
+	mock.ExpectQuery(regexp.QuoteMeta(reactionQuery)).
+		WithArgs(since, since, domain.ArticleStatusPublished).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "author_id", "hour", "count"}).
+			AddRow(1, 2, 2, 4))
+
+	repo := NewMysqlRankingRepository(db)
+
+	engagement, err := repo.FetchEngagement(context.Background(), since)
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.ArticleEngagement{
+		{ArticleID: 1, AuthorID: 2, At: since, Views: 5},
+		{ArticleID: 1, AuthorID: 2, At: since.Add(time.Hour), Comments: 3},
+		{ArticleID: 1, AuthorID: 2, At: since.Add(2 * time.Hour), Reactions: 4},
+	}, engagement)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlRankingRepository_FetchEngagement_Sqlite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Silent),
+	})
+	assert.NoError(t, err)
+
+	// every connection to :memory: opens a database of its own
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	assert.NoError(t, db.AutoMigrate(&domain.Author{}, &domain.Article{}, &domain.ArticleViewBucket{}, &domain.Comment{}, &domain.Reaction{}))
+
+	since := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, db.Create(&domain.Article{ID: 1, AuthorID: 2, Slug: "a", Status: domain.ArticleStatusPublished}).Error)
+	assert.NoError(t, db.Create(&domain.Article{ID: 2, AuthorID: 2, Slug: "b", Status: domain.ArticleStatusDraft}).Error)
+	assert.NoError(t, db.Create(&domain.ArticleViewBucket{ArticleID: 1, Hour: since, Views: 5}).Error)
+	assert.NoError(t, db.Create(&domain.ArticleViewBucket{ArticleID: 1, Hour: since.Add(-time.Hour), Views: 7}).Error)
+	for _, comment := range []*domain.Comment{
+		{ArticleID: 1, Status: domain.CommentStatusApproved, CreatedAt: since.Add(time.Hour)},
+		{ArticleID: 1, Status: domain.CommentStatusApproved, CreatedAt: since.Add(time.Hour + 59*time.Minute)},
+		{ArticleID: 1, Status: domain.CommentStatusApproved, CreatedAt: since.Add(2 * time.Hour)},
+		{ArticleID: 1, Status: domain.CommentStatusPending, CreatedAt: since.Add(time.Hour)},
+		{ArticleID: 2, Status: domain.CommentStatusApproved, CreatedAt: since.Add(time.Hour)},
+	} {
+		assert.NoError(t, db.Create(comment).Error)
+	}
+	for i, visitor := range []string{"a", "b"} {
+		reaction := &domain.Reaction{ArticleID: 1, Kind: domain.ReactionKindLike, Visitor: visitor, CreatedAt: since.Add(time.Duration(i) * time.Minute)}
+		assert.NoError(t, db.Create(reaction).Error)
+	}
+
+	repo := NewMysqlRankingRepository(db)
+
+	engagement, err := repo.FetchEngagement(context.Background(), since)
+	assert.NoError(t, err)
+	for _, e := range engagement {
+		e.At = e.At.UTC()
+	}
+	assert.ElementsMatch(t, []*domain.ArticleEngagement{
+		{ArticleID: 1, AuthorID: 2, At: since, Views: 5},
+		{ArticleID: 1, AuthorID: 2, At: since.Add(time.Hour), Comments: 2},
+		{ArticleID: 1, AuthorID: 2, At: since.Add(2 * time.Hour), Comments: 1},
+		{ArticleID: 1, AuthorID: 2, At: since, Reactions: 2},
+	}, engagement)
+}
+
+func TestMysqlRankingRepository_Replace(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_rankings` WHERE period = ?")).
+		WithArgs(domain.RankingWindowDay).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `article_rankings` (`period`,`article_id`,`author_id`,`position`,`score`,`views`,`comments`,`reactions`,`computed_at`) VALUES (?,?,?,?,?,?,?,?,?),(?,?,?,?,?,?,?,?,?)")).
+		WithArgs(domain.RankingWindowDay, 2, 1, 1, 7.0, 2, 1, 0, now, domain.RankingWindowDay, 1, 1, 2, 3.0, 3, 0, 0, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	repo := NewMysqlRankingRepository(db)
+
+	err = repo.Replace(context.Background(), domain.RankingWindowDay, []*domain.ArticleRanking{
+		{Window: domain.RankingWindowDay, ArticleID: 2, AuthorID: 1, Rank: 1, Score: 7, Views: 2, Comments: 1, ComputedAt: now},
+		{Window: domain.RankingWindowDay, ArticleID: 1, AuthorID: 1, Rank: 2, Score: 3, Views: 3, ComputedAt: now},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlRankingRepository_Replace_Empty(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_rankings` WHERE period = ?")).
+		WithArgs(domain.RankingWindowMonth).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	repo := NewMysqlRankingRepository(db)
+
+	assert.NoError(t, repo.Replace(context.Background(), domain.RankingWindowMonth, nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlRankingRepository_Fetch(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	now := time.Now()
+	query := "SELECT article_rankings.* FROM `article_rankings` JOIN articles ON articles.id = article_rankings.article_id " +
+		"WHERE (article_rankings.period = ? AND articles.status = ? AND articles.deleted_at IS NULL) AND article_rankings.author_id = ? " +
+		"ORDER BY article_rankings.position LIMIT ?"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(domain.RankingWindowWeek, domain.ArticleStatusPublished, 1, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"period", "article_id", "author_id", "position", "score", "views", "comments", "computed_at"}).
+			AddRow(domain.RankingWindowWeek, 3, 1, 2, 4.5, 4, 1, now))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `articles` WHERE `articles`.`id` = ? AND `articles`.`deleted_at` IS NULL")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "status"}).AddRow(3, "Trending", domain.ArticleStatusPublished))
+
+	repo := NewMysqlRankingRepository(db)
+
+	rankings, err := repo.Fetch(context.Background(), domain.RankingWindowWeek, 1, 5)
+	assert.NoError(t, err)
+	if assert.Len(t, rankings, 1) {
+		assert.Equal(t, uint(2), rankings[0].Rank)
+		assert.Equal(t, 4.5, rankings[0].Score)
+		if assert.NotNil(t, rankings[0].Article) {
+			assert.Equal(t, "Trending", rankings[0].Article.Title)
+		}
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlRankingRepository_PruneViews(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	before := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_view_buckets` WHERE hour < ?")).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+
+	repo := NewMysqlRankingRepository(db)
+
+	count, err := repo.PruneViews(context.Background(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlRankingRepository_DeleteByArticles(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_view_buckets` WHERE article_id IN (?,?)")).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `article_rankings` WHERE article_id IN (?,?)")).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	repo := NewMysqlRankingRepository(db)
+
+	err = repo.DeleteByArticles(context.Background(), []uint{1, 2})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,152 @@
+package ranking
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/pkg/xrank"
+	"gorm.io/gorm"
+	"slices"
+	"time"
+)
+
+const (
+	// computeLock is the job lock held while rankings are computed, so that
+	// instances do not replace the rankings or prune the views of one
+	// another.
+	computeLock = "rankings"
+	// computeLockTTL is longer than any computation. The lock of an instance
+	// that stopped while computing is taken over after it.
+	computeLockTTL = 10 * time.Minute
+)
+
+type rankingService struct {
+	rankingRepo domain.RankingRepository
+	authorRepo  domain.AuthorRepository
+	lockRepo    domain.JobLockRepository
+	cfg         config.Config
+	scorer      xrank.Scorer
+	// holder tells the locks of this instance apart.
+	holder string
+}
+
+func NewRankingService(ranking domain.RankingRepository, author domain.AuthorRepository, lock domain.JobLockRepository, cfg config.Config) domain.RankingService {
+	return &rankingService{
+		rankingRepo: ranking,
+		authorRepo:  author,
+		lockRepo:    lock,
+		cfg:         cfg,
+		scorer:      xrank.Scorer{Decay: cfg.Trending.Decay, HalfLife: cfg.Trending.HalfLife},
+		holder:      randomHolder(),
+	}
+}
+
+func randomHolder() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func (s *rankingService) Trending(ctx context.Context, window domain.RankingWindow, size uint) ([]*domain.ArticleRanking, error) {
+	return s.rankingRepo.Fetch(ctx, window, 0, size)
+}
+
+func (s *rankingService) TopByAuthor(ctx context.Context, authorID uint, window domain.RankingWindow, size uint) ([]*domain.ArticleRanking, error) {
+	if _, err := s.authorRepo.GetByID(ctx, authorID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "author not found")
+		}
+		return nil, err
+	}
+	return s.rankingRepo.Fetch(ctx, window, authorID, size)
+}
+
+// Compute reads the engagement of the longest window once and ranks every
+// window from it. View buckets older than the longest window are removed
+// afterwards. Nothing is computed while another instance holds the lock.
+func (s *rankingService) Compute(ctx context.Context, now time.Time) (count int64, err error) {
+	acquired, err := s.lockRepo.Acquire(ctx, computeLock, s.holder, now.Add(computeLockTTL))
+	if err != nil || !acquired {
+		return 0, err
+	}
+	defer func() {
+		// the lock is released even when the job is stopped
+		err = errors.Join(err, s.lockRepo.Release(context.WithoutCancel(ctx), computeLock, s.holder))
+	}()
+
+	var longest time.Duration
+	for _, window := range domain.RankingWindows {
+		longest = max(longest, window.Duration())
+	}
+
+	// the bucket of the oldest hour starts before the window does
+	since := now.Add(-longest).Truncate(time.Hour)
+	engagement, err := s.rankingRepo.FetchEngagement(ctx, since)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, window := range domain.RankingWindows {
+		rankings := s.rank(engagement, window, now)
+		if err := s.rankingRepo.Replace(ctx, window, rankings); err != nil {
+			return count, err
+		}
+		count += int64(len(rankings))
+	}
+
+	if _, err := s.rankingRepo.PruneViews(ctx, since); err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// rank scores the articles with engagement within window, best first. Ties
+// go to the article with the most engagement, then to the oldest article.
+func (s *rankingService) rank(engagement []*domain.ArticleEngagement, window domain.RankingWindow, now time.Time) []*domain.ArticleRanking {
+	duration := window.Duration()
+	byArticle := make(map[uint]*domain.ArticleRanking)
+	var rankings []*domain.ArticleRanking
+
+	for _, e := range engagement {
+		age := now.Sub(e.At)
+		if age > duration {
+			continue
+		}
+
+		ranking, ok := byArticle[e.ArticleID]
+		if !ok {
+			ranking = &domain.ArticleRanking{Window: window, ArticleID: e.ArticleID, AuthorID: e.AuthorID, ComputedAt: now}
+			byArticle[e.ArticleID] = ranking
+			rankings = append(rankings, ranking)
+		}
+
+		value := s.cfg.Trending.ViewWeight*float64(e.Views) +
+			s.cfg.Trending.CommentWeight*float64(e.Comments) +
+			s.cfg.Trending.ReactionWeight*float64(e.Reactions)
+		ranking.Score += s.scorer.Weight(age, duration) * value
+		ranking.Views += e.Views
+		ranking.Comments += e.Comments
+		ranking.Reactions += e.Reactions
+	}
+
+	slices.SortFunc(rankings, func(a, b *domain.ArticleRanking) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Views+b.Comments+b.Reactions, a.Views+a.Comments+a.Reactions); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ArticleID, b.ArticleID)
+	})
+	for i, ranking := range rankings {
+		ranking.Rank = uint(i + 1)
+	}
+	return rankings
+}
@@ -0,0 +1,215 @@
+package ranking
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/config"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/mocks"
+	"go-clean-architecture/pkg/xrank"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+var trendingConfig = config.Config{Trending: config.Trending{ViewWeight: 1, CommentWeight: 5, ReactionWeight: 2, Decay: xrank.None}}
+
+func TestRankingService_Trending(t *testing.T) {
+	mockRankingRepository := new(mocks.RankingRepository)
+	rankings := []*domain.ArticleRanking{{Window: domain.RankingWindowWeek, ArticleID: 1, Rank: 1}}
+	mockRankingRepository.On("Fetch", mock.Anything, domain.RankingWindowWeek, uint(0), uint(10)).
+		Return(rankings, nil).Once()
+
+	rankingSvc := NewRankingService(mockRankingRepository, nil, nil, trendingConfig)
+	result, err := rankingSvc.Trending(context.Background(), domain.RankingWindowWeek, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, rankings, result)
+	mockRankingRepository.AssertExpectations(t)
+}
+
+func TestRankingService_TopByAuthor(t *testing.T) {
+	mockRankingRepository := new(mocks.RankingRepository)
+	mockAuthorRepository := new(mocks.AuthorRepository)
+
+	t.Run("success", func(t *testing.T) {
+		rankings := []*domain.ArticleRanking{{Window: domain.RankingWindowMonth, ArticleID: 3, AuthorID: 1, Rank: 4}}
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(1)).
+			Return(&domain.Author{ID: 1}, nil).Once()
+		mockRankingRepository.On("Fetch", mock.Anything, domain.RankingWindowMonth, uint(1), uint(5)).
+			Return(rankings, nil).Once()
+
+		rankingSvc := NewRankingService(mockRankingRepository, mockAuthorRepository, nil, trendingConfig)
+		result, err := rankingSvc.TopByAuthor(context.Background(), 1, domain.RankingWindowMonth, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, rankings, result)
+		mockRankingRepository.AssertExpectations(t)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockAuthorRepository.On("GetByID", mock.Anything, uint(2)).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		rankingSvc := NewRankingService(mockRankingRepository, mockAuthorRepository, nil, trendingConfig)
+		_, err := rankingSvc.TopByAuthor(context.Background(), 2, domain.RankingWindowMonth, 5)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusNotFound, fiberErr.Code)
+		mockAuthorRepository.AssertExpectations(t)
+	})
+}
+
+// lockedJob returns a lock repository expecting the rankings lock to be
+// acquired, and released when it is.
+func lockedJob(t *testing.T, acquired bool) *mocks.JobLockRepository {
+	mockJobLockRepository := new(mocks.JobLockRepository)
+	mockJobLockRepository.On("Acquire", mock.Anything, computeLock, mock.Anything, mock.Anything).
+		Return(acquired, nil).Once()
+	if acquired {
+		mockJobLockRepository.On("Release", mock.Anything, computeLock, mock.Anything).
+			Return(nil).Once()
+	}
+	t.Cleanup(func() { mockJobLockRepository.AssertExpectations(t) })
+	return mockJobLockRepository
+}
+
+func TestRankingService_Compute(t *testing.T) {
+	mockRankingRepository := new(mocks.RankingRepository)
+	mockJobLockRepository := lockedJob(t, true)
+	now := time.Date(2024, 5, 31, 12, 30, 0, 0, time.UTC)
+	since := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	engagement := []*domain.ArticleEngagement{
+		// article 1 was read a lot last week
+		{ArticleID: 1, AuthorID: 1, At: now.Add(-3 * 24 * time.Hour), Views: 10},
+		// article 2 was read, commented on and reacted to today
+		{ArticleID: 2, AuthorID: 2, At: now.Add(-time.Hour), Views: 2},
+		{ArticleID: 2, AuthorID: 2, At: now.Add(-2 * time.Hour), Comments: 1},
+		{ArticleID: 2, AuthorID: 2, At: now.Add(-3 * time.Hour), Reactions: 1},
+		// article 3 has as much engagement as article 1 today
+		{ArticleID: 3, AuthorID: 1, At: now.Add(-time.Hour), Views: 3},
+		{ArticleID: 1, AuthorID: 1, At: now.Add(-time.Hour), Views: 3},
+	}
+	mockRankingRepository.On("FetchEngagement", mock.Anything, since).
+		Return(engagement, nil).Once()
+
+	stored := make(map[domain.RankingWindow][]*domain.ArticleRanking)
+	mockRankingRepository.On("Replace", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			stored[args.Get(1).(domain.RankingWindow)] = args.Get(2).([]*domain.ArticleRanking)
+		}).
+		Return(nil).Times(3)
+	mockRankingRepository.On("PruneViews", mock.Anything, since).
+		Return(int64(4), nil).Once()
+
+	rankingSvc := NewRankingService(mockRankingRepository, nil, mockJobLockRepository, trendingConfig)
+	count, err := rankingSvc.Compute(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), count)
+
+	day := stored[domain.RankingWindowDay]
+	if assert.Len(t, day, 3) {
+		assert.Equal(t, uint(2), day[0].ArticleID)
+		assert.Equal(t, uint(1), day[0].Rank)
+		assert.InDelta(t, 9, day[0].Score, 1e-9)
+		assert.Equal(t, uint64(2), day[0].Views)
+		assert.Equal(t, uint64(1), day[0].Comments)
+		assert.Equal(t, uint64(1), day[0].Reactions)
+		assert.Equal(t, now, day[0].ComputedAt)
+		// ties go to the oldest article
+		assert.Equal(t, uint(1), day[1].ArticleID)
+		assert.Equal(t, uint(3), day[2].ArticleID)
+		assert.Equal(t, uint(3), day[2].Rank)
+	}
+
+	week := stored[domain.RankingWindowWeek]
+	if assert.Len(t, week, 3) {
+		assert.Equal(t, uint(1), week[0].ArticleID)
+		assert.Equal(t, uint(1), week[0].AuthorID)
+		assert.InDelta(t, 13, week[0].Score, 1e-9)
+		assert.Equal(t, domain.RankingWindowWeek, week[0].Window)
+	}
+	mockRankingRepository.AssertExpectations(t)
+}
+
+func TestRankingService_Compute_Decay(t *testing.T) {
+	mockRankingRepository := new(mocks.RankingRepository)
+	mockJobLockRepository := lockedJob(t, true)
+	now := time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC)
+	cfg := config.Config{Trending: config.Trending{ViewWeight: 1, CommentWeight: 5, Decay: xrank.Linear}}
+
+	// the same views weigh less the older they are
+	engagement := []*domain.ArticleEngagement{
+		{ArticleID: 1, At: now.Add(-18 * time.Hour), Views: 4},
+		{ArticleID: 2, At: now.Add(-6 * time.Hour), Views: 4},
+	}
+	mockRankingRepository.On("FetchEngagement", mock.Anything, mock.Anything).
+		Return(engagement, nil).Once()
+
+	var day []*domain.ArticleRanking
+	mockRankingRepository.On("Replace", mock.Anything, domain.RankingWindowDay, mock.Anything).
+		Run(func(args mock.Arguments) {
+			day = args.Get(2).([]*domain.ArticleRanking)
+		}).
+		Return(nil).Once()
+	mockRankingRepository.On("Replace", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Twice()
+	mockRankingRepository.On("PruneViews", mock.Anything, mock.Anything).
+		Return(int64(0), nil).Once()
+
+	rankingSvc := NewRankingService(mockRankingRepository, nil, mockJobLockRepository, cfg)
+	_, err := rankingSvc.Compute(context.Background(), now)
+	assert.NoError(t, err)
+	if assert.Len(t, day, 2) {
+		assert.Equal(t, uint(2), day[0].ArticleID)
+		assert.InDelta(t, 3, day[0].Score, 1e-9)
+		assert.InDelta(t, 1, day[1].Score, 1e-9)
+	}
+	mockRankingRepository.AssertExpectations(t)
+}
+
+func TestRankingService_Compute_Error(t *testing.T) {
+	mockRankingRepository := new(mocks.RankingRepository)
+	mockJobLockRepository := lockedJob(t, true)
+	mockRankingRepository.On("FetchEngagement", mock.Anything, mock.Anything).
+		Return(nil, nil).Once()
+	mockRankingRepository.On("Replace", mock.Anything, domain.RankingWindowDay, mock.Anything).
+		Return(assert.AnError).Once()
+
+	rankingSvc := NewRankingService(mockRankingRepository, nil, mockJobLockRepository, trendingConfig)
+	_, err := rankingSvc.Compute(context.Background(), time.Now())
+	assert.ErrorIs(t, err, assert.AnError)
+	mockRankingRepository.AssertExpectations(t)
+}
+
+func TestRankingService_Compute_Locked(t *testing.T) {
+	mockRankingRepository := new(mocks.RankingRepository)
+	// another instance is computing the rankings
+	mockJobLockRepository := lockedJob(t, false)
+
+	rankingSvc := NewRankingService(mockRankingRepository, nil, mockJobLockRepository, trendingConfig)
+	count, err := rankingSvc.Compute(context.Background(), time.Now())
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	mockRankingRepository.AssertExpectations(t)
+}
+
+func TestRankingService_Compute_Release(t *testing.T) {
+	mockRankingRepository := new(mocks.RankingRepository)
+	mockJobLockRepository := new(mocks.JobLockRepository)
+	now := time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC)
+	mockJobLockRepository.On("Acquire", mock.Anything, computeLock, mock.Anything, now.Add(computeLockTTL)).
+		Return(true, nil).Once()
+	mockRankingRepository.On("FetchEngagement", mock.Anything, mock.Anything).
+		Return(nil, assert.AnError).Once()
+	mockJobLockRepository.On("Release", mock.Anything, computeLock, mock.Anything).
+		Return(assert.AnError).Once()
+
+	rankingSvc := NewRankingService(mockRankingRepository, nil, mockJobLockRepository, trendingConfig)
+	_, err := rankingSvc.Compute(context.Background(), now)
+	assert.ErrorIs(t, err, assert.AnError)
+	mockRankingRepository.AssertExpectations(t)
+	mockJobLockRepository.AssertExpectations(t)
+}
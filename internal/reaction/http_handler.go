@@ -0,0 +1,130 @@
+package reaction
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/domain"
+)
+
+type HttpReactionHandler struct {
+	reactionSvc domain.ReactionService
+}
+
+func NewHttpHandler(r fiber.Router, reactionSvc domain.ReactionService) {
+	handler := &HttpReactionHandler{
+		reactionSvc: reactionSvc,
+	}
+	r.Get("/articles/:id/reactions", handler.Count)
+	r.Put("/articles/:id/reactions/:kind", handler.React)
+	r.Delete("/articles/:id/reactions/:kind", handler.Unreact)
+}
+
+// Count used to get the reactions to an article
+//
+//	@Summary		Get reactions of an article
+//	@Description	Get the number of reactions of every kind to an article.
+//	@Tags			reactions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int						true	"Article ID"
+//	@Success		200	{array}		domain.ReactionCount	"Reactions of each kind"
+//	@Failure		400	{object}	domain.Error			"Bad Request"
+//	@Failure		404	{object}	domain.Error			"Not Found"
+//	@Failure		500	{object}	domain.Error			"Internal Server Error"
+//	@Router			/articles/{id}/reactions [get]
+func (h *HttpReactionHandler) Count(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	counts, err := h.reactionSvc.Count(c.UserContext(), uint(id))
+	if err != nil {
+		return err
+	}
+	return c.JSON(counts)
+}
+
+// React used to react to an article
+//
+//	@Summary		React to article
+//	@Description	React to a published article. Visitors, told apart by IP address and user agent, react at most
+//	@Description	once with each kind, so reacting again changes nothing. Reactions count towards trending articles.
+//	@Tags			reactions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Article ID"
+//	@Param			kind	path		string					true	"Kind of reaction"	Enums(like, love, insightful, funny)
+//	@Success		200		{array}		domain.ReactionCount	"Reactions of each kind"
+//	@Failure		400		{object}	domain.Error			"Bad Request"
+//	@Failure		404		{object}	domain.Error			"Not Found"
+//	@Failure		409		{object}	domain.Error			"Article is not published"
+//	@Failure		500		{object}	domain.Error			"Internal Server Error"
+//	@Router			/articles/{id}/reactions/{kind} [put]
+func (h *HttpReactionHandler) React(c *fiber.Ctx) error {
+	id, kind, err := parseParams(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	counts, err := h.reactionSvc.React(c.UserContext(), id, visitor(c), kind)
+	if err != nil {
+		return err
+	}
+	return c.JSON(counts)
+}
+
+// Unreact used to take back a reaction to an article
+//
+//	@Summary		Delete reaction
+//	@Description	Take back the reaction of the visitor to an article. Taking back a reaction that was never made
+//	@Description	changes nothing.
+//	@Tags			reactions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Article ID"
+//	@Param			kind	path		string					true	"Kind of reaction"	Enums(like, love, insightful, funny)
+//	@Success		200		{array}		domain.ReactionCount	"Reactions of each kind"
+//	@Failure		400		{object}	domain.Error			"Bad Request"
+//	@Failure		404		{object}	domain.Error			"Not Found"
+//	@Failure		500		{object}	domain.Error			"Internal Server Error"
+//	@Router			/articles/{id}/reactions/{kind} [delete]
+func (h *HttpReactionHandler) Unreact(c *fiber.Ctx) error {
+	id, kind, err := parseParams(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.Error{
+			Code:    fiber.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	counts, err := h.reactionSvc.Unreact(c.UserContext(), id, visitor(c), kind)
+	if err != nil {
+		return err
+	}
+	return c.JSON(counts)
+}
+
+// parseParams reads the article ID and the kind of reaction of the path.
+func parseParams(c *fiber.Ctx) (uint, domain.ReactionKind, error) {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return 0, "", err
+	}
+	kind := domain.ReactionKind(c.Params("kind"))
+	if !kind.Valid() {
+		return 0, "", errors.New("kind must be one of like, love, insightful or funny")
+	}
+	return uint(id), kind, nil
+}
+
+// visitor tells visitors apart the way views do.
+func visitor(c *fiber.Ctx) string {
+	return c.IP() + " " + c.Get(fiber.HeaderUserAgent)
+}
@@ -0,0 +1,93 @@
+package reaction
+
+import (
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHttpReactionHandler_Count(t *testing.T) {
+	mockService := new(mocks.ReactionService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("Count", mock.Anything, uint(1)).Return(counts, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService)
+		resp, err := app.Test(httptest.NewRequest("GET", "/articles/1/reactions", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var result []*domain.ReactionCount
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, counts, result)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-found", func(t *testing.T) {
+		mockService.On("Count", mock.Anything, uint(1)).Return(nil, fiber.NewError(fiber.StatusNotFound, "article not found")).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService)
+		resp, err := app.Test(httptest.NewRequest("GET", "/articles/1/reactions", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 404, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestHttpReactionHandler_React(t *testing.T) {
+	mockService := new(mocks.ReactionService)
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("React", mock.Anything, uint(1), "0.0.0.0 test", domain.ReactionKindLike).Return(counts, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService)
+		req := httptest.NewRequest("PUT", "/articles/1/reactions/like", nil)
+		req.Header.Set(fiber.HeaderUserAgent, "test")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-unknown-kind", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService)
+		resp, err := app.Test(httptest.NewRequest("PUT", "/articles/1/reactions/angry", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+
+		var result domain.Error
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		assert.Equal(t, "kind must be one of like, love, insightful or funny", result.Message)
+	})
+
+	t.Run("error-invalid-id", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService)
+		resp, err := app.Test(httptest.NewRequest("PUT", "/articles/abc/reactions/like", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+	})
+}
+
+func TestHttpReactionHandler_Unreact(t *testing.T) {
+	mockService := new(mocks.ReactionService)
+	mockService.On("Unreact", mock.Anything, uint(1), "0.0.0.0 test", domain.ReactionKindLike).Return(counts, nil).Once()
+
+	app := fiber.New()
+	NewHttpHandler(app, mockService)
+	req := httptest.NewRequest("DELETE", "/articles/1/reactions/like", nil)
+	req.Header.Set(fiber.HeaderUserAgent, "test")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
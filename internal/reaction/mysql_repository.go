@@ -0,0 +1,44 @@
+package reaction
+
+import (
+	"context"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mysqlReactionRepository struct {
+	db *gorm.DB
+}
+
+func NewMysqlReactionRepository(db *gorm.DB) domain.ReactionRepository {
+	return &mysqlReactionRepository{db: db}
+}
+
+func (r *mysqlReactionRepository) Count(ctx context.Context, articleID uint) ([]*domain.ReactionCount, error) {
+	var counts []*domain.ReactionCount
+	if err := r.db.WithContext(ctx).Model(&domain.Reaction{}).
+		Select("kind, COUNT(*) AS count").
+		Where("article_id = ?", articleID).
+		Group("kind").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// Store leaves the reaction stored already in place when the visitor reacts
+// again, so that reacting twice is not an error.
+func (r *mysqlReactionRepository) Store(ctx context.Context, reaction *domain.Reaction) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(reaction).Error
+}
+
+func (r *mysqlReactionRepository) Delete(ctx context.Context, articleID uint, visitor string, kind domain.ReactionKind) error {
+	return r.db.WithContext(ctx).
+		Where("article_id = ? AND visitor = ? AND kind = ?", articleID, visitor, kind).
+		Delete(&domain.Reaction{}).Error
+}
+
+func (r *mysqlReactionRepository) DeleteByArticles(ctx context.Context, articleIDs []uint) error {
+	return r.db.WithContext(ctx).Where("article_id IN ?", articleIDs).Delete(&domain.Reaction{}).Error
+}
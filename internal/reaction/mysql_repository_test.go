@@ -0,0 +1,110 @@
+package reaction
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+	"regexp"
+	"testing"
+)
+
+func mockDBConnection() (*gorm.DB, sqlmock.Sqlmock, error) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gdb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Info),
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gdb, mock, nil
+}
+
+// visitorKey is the hash of a visitor as stored.
+const visitorKey = "4d4b4fb4f4d4d4b1b2a6e7a2f0e0b0f3f9d3c1d7a2b4e6f8a0c2e4f6a8b0c2d4"
+
+func TestMysqlReactionRepository_Count(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT kind, COUNT(*) AS count FROM `reactions` WHERE article_id = ? GROUP BY `kind`"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"kind", "count"}).
+			AddRow("like", 3).
+			AddRow("funny", 1))
+
+	repo := NewMysqlReactionRepository(db)
+
+	counts, err := repo.Count(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.ReactionCount{
+		{Kind: domain.ReactionKindLike, Count: 3},
+		{Kind: domain.ReactionKindFunny, Count: 1},
+	}, counts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlReactionRepository_Store(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "INSERT INTO `reactions` (`article_id`,`kind`,`visitor`,`created_at`) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE `id`=`id`"
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(1, domain.ReactionKindLike, visitorKey, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlReactionRepository(db)
+
+	reaction := &domain.Reaction{ArticleID: 1, Kind: domain.ReactionKindLike, Visitor: visitorKey}
+	assert.NoError(t, repo.Store(context.Background(), reaction))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlReactionRepository_Delete(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "DELETE FROM `reactions` WHERE article_id = ? AND visitor = ? AND kind = ?"
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(1, visitorKey, domain.ReactionKindLike).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := NewMysqlReactionRepository(db)
+
+	assert.NoError(t, repo.Delete(context.Background(), 1, visitorKey, domain.ReactionKindLike))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlReactionRepository_DeleteByArticles(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "DELETE FROM `reactions` WHERE article_id IN (?,?)"
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(query)).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	repo := NewMysqlReactionRepository(db)
+
+	assert.NoError(t, repo.DeleteByArticles(context.Background(), []uint{1, 2}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
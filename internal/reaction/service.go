@@ -0,0 +1,95 @@
+package reaction
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+)
+
+type reactionService struct {
+	reactionRepo domain.ReactionRepository
+	articleRepo  domain.ArticleRepository
+}
+
+func NewReactionService(reaction domain.ReactionRepository, article domain.ArticleRepository) domain.ReactionService {
+	return &reactionService{
+		reactionRepo: reaction,
+		articleRepo:  article,
+	}
+}
+
+func (s *reactionService) Count(ctx context.Context, articleID uint) ([]*domain.ReactionCount, error) {
+	if _, err := s.article(ctx, articleID); err != nil {
+		return nil, err
+	}
+	return s.count(ctx, articleID)
+}
+
+func (s *reactionService) React(ctx context.Context, articleID uint, visitor string, kind domain.ReactionKind) ([]*domain.ReactionCount, error) {
+	article, err := s.article(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if article.Status != domain.ArticleStatusPublished {
+		return nil, fiber.NewError(fiber.StatusConflict, "reactions are only allowed on published articles")
+	}
+
+	reaction := &domain.Reaction{ArticleID: articleID, Visitor: visitorHash(visitor), Kind: kind}
+	if err := s.reactionRepo.Store(ctx, reaction); err != nil {
+		return nil, err
+	}
+	return s.count(ctx, articleID)
+}
+
+func (s *reactionService) Unreact(ctx context.Context, articleID uint, visitor string, kind domain.ReactionKind) ([]*domain.ReactionCount, error) {
+	if _, err := s.article(ctx, articleID); err != nil {
+		return nil, err
+	}
+	if err := s.reactionRepo.Delete(ctx, articleID, visitorHash(visitor), kind); err != nil {
+		return nil, err
+	}
+	return s.count(ctx, articleID)
+}
+
+// count returns the counts of every kind, in the order of
+// domain.ReactionKinds, including kinds without reactions.
+func (s *reactionService) count(ctx context.Context, articleID uint) ([]*domain.ReactionCount, error) {
+	stored, err := s.reactionRepo.Count(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]*domain.ReactionCount, len(domain.ReactionKinds))
+	for i, kind := range domain.ReactionKinds {
+		counts[i] = &domain.ReactionCount{Kind: kind}
+		for _, count := range stored {
+			if count.Kind == kind {
+				counts[i].Count = count.Count
+			}
+		}
+	}
+	return counts, nil
+}
+
+// article returns the article reactions belong to. Articles in the trash
+// have no reactions until they are restored.
+func (s *reactionService) article(ctx context.Context, id uint) (*domain.Article, error) {
+	article, err := s.articleRepo.GetByID(ctx, id, &domain.ArticleView{Columns: []string{"id", "status"}})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "article not found")
+		}
+		return nil, err
+	}
+	return article, nil
+}
+
+// visitorHash hashes the visitor the way it is stored.
+func visitorHash(visitor string) string {
+	sum := sha256.Sum256([]byte(visitor))
+	return hex.EncodeToString(sum[:])
+}
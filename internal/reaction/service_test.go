@@ -0,0 +1,115 @@
+package reaction
+
+import (
+	"context"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/mocks"
+	"gorm.io/gorm"
+	"testing"
+)
+
+// articleView is the view used to check the article of reactions.
+var articleView = &domain.ArticleView{Columns: []string{"id", "status"}}
+
+// counts are the counts of every kind when only likes are stored.
+var counts = []*domain.ReactionCount{
+	{Kind: domain.ReactionKindLike, Count: 2},
+	{Kind: domain.ReactionKindLove},
+	{Kind: domain.ReactionKindInsightful},
+	{Kind: domain.ReactionKindFunny},
+}
+
+func TestReactionService_Count(t *testing.T) {
+	mockReactionRepository := new(mocks.ReactionRepository)
+	mockArticleRepository := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusPublished}, nil).Once()
+		mockReactionRepository.On("Count", mock.Anything, uint(1)).
+			Return([]*domain.ReactionCount{{Kind: domain.ReactionKindLike, Count: 2}}, nil).Once()
+
+		reactionSvc := NewReactionService(mockReactionRepository, mockArticleRepository)
+		result, err := reactionSvc.Count(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, counts, result)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockReactionRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-article-not-found", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).
+			Return(nil, gorm.ErrRecordNotFound).Once()
+
+		reactionSvc := NewReactionService(mockReactionRepository, mockArticleRepository)
+		_, err := reactionSvc.Count(context.Background(), 1)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusNotFound, fiberErr.Code)
+
+		mockArticleRepository.AssertExpectations(t)
+	})
+}
+
+func TestReactionService_React(t *testing.T) {
+	mockReactionRepository := new(mocks.ReactionRepository)
+	mockArticleRepository := new(mocks.ArticleRepository)
+
+	t.Run("success", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusPublished}, nil).Once()
+		mockReactionRepository.On("Store", mock.Anything, &domain.Reaction{
+			ArticleID: 1,
+			Kind:      domain.ReactionKindLike,
+			Visitor:   visitorHash("127.0.0.1 test"),
+		}).Return(nil).Once()
+		mockReactionRepository.On("Count", mock.Anything, uint(1)).
+			Return([]*domain.ReactionCount{{Kind: domain.ReactionKindLike, Count: 2}}, nil).Once()
+
+		reactionSvc := NewReactionService(mockReactionRepository, mockArticleRepository)
+		result, err := reactionSvc.React(context.Background(), 1, "127.0.0.1 test", domain.ReactionKindLike)
+		assert.NoError(t, err)
+		assert.Equal(t, counts, result)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockReactionRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-not-published", func(t *testing.T) {
+		mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).
+			Return(&domain.Article{ID: 1, Status: domain.ArticleStatusDraft}, nil).Once()
+
+		reactionSvc := NewReactionService(mockReactionRepository, mockArticleRepository)
+		_, err := reactionSvc.React(context.Background(), 1, "127.0.0.1 test", domain.ReactionKindLike)
+		var fiberErr *fiber.Error
+		assert.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusConflict, fiberErr.Code)
+
+		mockArticleRepository.AssertExpectations(t)
+		mockReactionRepository.AssertExpectations(t)
+	})
+}
+
+func TestReactionService_Unreact(t *testing.T) {
+	mockReactionRepository := new(mocks.ReactionRepository)
+	mockArticleRepository := new(mocks.ArticleRepository)
+
+	mockArticleRepository.On("GetByID", mock.Anything, uint(1), articleView).
+		Return(&domain.Article{ID: 1, Status: domain.ArticleStatusArchived}, nil).Once()
+	mockReactionRepository.On("Delete", mock.Anything, uint(1), visitorHash("127.0.0.1 test"), domain.ReactionKindLove).
+		Return(nil).Once()
+	mockReactionRepository.On("Count", mock.Anything, uint(1)).
+		Return([]*domain.ReactionCount{{Kind: domain.ReactionKindLike, Count: 2}}, nil).Once()
+
+	reactionSvc := NewReactionService(mockReactionRepository, mockArticleRepository)
+	result, err := reactionSvc.Unreact(context.Background(), 1, "127.0.0.1 test", domain.ReactionKindLove)
+	assert.NoError(t, err)
+	assert.Equal(t, counts, result)
+
+	mockArticleRepository.AssertExpectations(t)
+	mockReactionRepository.AssertExpectations(t)
+}
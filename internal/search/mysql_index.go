@@ -0,0 +1,84 @@
+package search
+
+import (
+	"context"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/pkg/xsearch"
+	"gorm.io/gorm"
+	"strings"
+)
+
+const mysqlIndexName = "idx_articles_search"
+
+type mysqlArticleSearchIndex struct {
+	db *gorm.DB
+}
+
+// NewMysqlArticleSearchIndex returns a search index backed by a FULLTEXT
+// index on articles. Words shorter than innodb_ft_min_token_size and stop
+// words are not indexed by MySQL.
+func NewMysqlArticleSearchIndex(db *gorm.DB) domain.ArticleSearchIndex {
+	return &mysqlArticleSearchIndex{db: db}
+}
+
+func (i *mysqlArticleSearchIndex) Setup(ctx context.Context) error {
+	exists, err := i.exists(ctx)
+	if err != nil || exists {
+		return err
+	}
+	return i.create(ctx)
+}
+
+// Reindex drops and recreates the FULLTEXT index, which indexes every stored
+// article again.
+func (i *mysqlArticleSearchIndex) Reindex(ctx context.Context) error {
+	exists, err := i.exists(ctx)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err := i.db.WithContext(ctx).Exec("ALTER TABLE articles DROP INDEX " + mysqlIndexName).Error; err != nil {
+			return err
+		}
+	}
+	return i.create(ctx)
+}
+
+func (i *mysqlArticleSearchIndex) Match(query *gorm.DB, terms string) *gorm.DB {
+	words := xsearch.Terms(terms)
+	if len(words) == 0 {
+		return query.Where("1 = 0")
+	}
+	return query.Where("MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE)", strings.Join(words, " "))
+}
+
+func (i *mysqlArticleSearchIndex) Rank(query *gorm.DB, terms string) *gorm.DB {
+	words := xsearch.Terms(terms)
+	if len(words) == 0 {
+		return query
+	}
+	return query.
+		Select(selected(query)+", MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AS relevance", strings.Join(words, " ")).
+		Order("relevance DESC")
+}
+
+// selected returns the columns that query already selects, so that ranking
+// keeps them, or every column of articles.
+func selected(query *gorm.DB) string {
+	if len(query.Statement.Selects) > 0 {
+		return strings.Join(query.Statement.Selects, ", ")
+	}
+	return "articles.*"
+}
+
+func (i *mysqlArticleSearchIndex) exists(ctx context.Context) (bool, error) {
+	var count int64
+	err := i.db.WithContext(ctx).
+		Raw("SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?", "articles", mysqlIndexName).
+		Scan(&count).Error
+	return count > 0, err
+}
+
+func (i *mysqlArticleSearchIndex) create(ctx context.Context) error {
+	return i.db.WithContext(ctx).Exec("ALTER TABLE articles ADD FULLTEXT INDEX " + mysqlIndexName + " (title, content)").Error
+}
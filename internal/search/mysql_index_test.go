@@ -0,0 +1,178 @@
+package search
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+	"regexp"
+	"testing"
+)
+
+const existsQuery = "SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?"
+
+func mockDBConnection() (*gorm.DB, sqlmock.Sqlmock, error) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gdb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Info),
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gdb, mock, nil
+}
+
+func TestMysqlArticleSearchIndex_Setup(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(existsQuery)).
+		WithArgs("articles", "idx_articles_search").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE articles ADD FULLTEXT INDEX idx_articles_search (title, content)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	index := NewMysqlArticleSearchIndex(db)
+
+	err = index.Setup(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleSearchIndex_Setup_Exists(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(existsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	index := NewMysqlArticleSearchIndex(db)
+
+	err = index.Setup(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleSearchIndex_Setup_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(existsQuery)).
+		WillReturnError(assert.AnError)
+
+	index := NewMysqlArticleSearchIndex(db)
+
+	err = index.Setup(context.Background())
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestMysqlArticleSearchIndex_Reindex(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(existsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE articles DROP INDEX idx_articles_search")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE articles ADD FULLTEXT INDEX idx_articles_search (title, content)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	index := NewMysqlArticleSearchIndex(db)
+
+	err = index.Reindex(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleSearchIndex_Reindex_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	index := NewMysqlArticleSearchIndex(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(existsQuery)).
+		WillReturnError(assert.AnError)
+
+	err = index.Reindex(context.Background())
+	assert.ErrorIs(t, err, assert.AnError)
+
+	mock.ExpectQuery(regexp.QuoteMeta(existsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE articles DROP INDEX idx_articles_search")).
+		WillReturnError(assert.AnError)
+
+	err = index.Reindex(context.Background())
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleSearchIndex_MatchAndRank(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT articles.*, MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AS relevance FROM `articles` " +
+		"WHERE MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AND `articles`.`deleted_at` IS NULL ORDER BY relevance DESC,created_at DESC"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("clean go", "clean go").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "relevance"}).AddRow(1, 0.5))
+
+	index := NewMysqlArticleSearchIndex(db)
+
+	var articles []*domain.Article
+	terms := `"Clean" +go*`
+	err = index.Rank(index.Match(db, terms), terms).Order("created_at DESC").Find(&articles).Error
+	assert.NoError(t, err)
+	assert.Len(t, articles, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleSearchIndex_Rank_SelectedColumns(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT articles.id, articles.title, MATCH (title, content) AGAINST (? IN NATURAL LANGUAGE MODE) AS relevance FROM `articles` " +
+		"WHERE `articles`.`deleted_at` IS NULL ORDER BY relevance DESC"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs("go").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "relevance"}).AddRow(1, "Go", 0.5))
+
+	index := NewMysqlArticleSearchIndex(db)
+
+	var articles []*domain.Article
+	err = index.Rank(db.Select([]string{"articles.id", "articles.title"}), "go").Find(&articles).Error
+	assert.NoError(t, err)
+	assert.Len(t, articles, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlArticleSearchIndex_MatchAndRank_NoTerms(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT * FROM `articles` WHERE 1 = 0 AND `articles`.`deleted_at` IS NULL"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	index := NewMysqlArticleSearchIndex(db)
+
+	var articles []*domain.Article
+	err = index.Rank(index.Match(db, "?"), "?").Find(&articles).Error
+	assert.NoError(t, err)
+	assert.Empty(t, articles)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,89 @@
+package search
+
+import (
+	"context"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/pkg/xsearch"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// sqliteSetup creates an FTS5 table that reads its content from articles and
+// the triggers that keep it in sync.
+var sqliteSetup = []string{
+	"CREATE VIRTUAL TABLE IF NOT EXISTS articles_fts USING fts5(title, content, content='articles', content_rowid='id')",
+	"CREATE TRIGGER IF NOT EXISTS articles_fts_insert AFTER INSERT ON articles BEGIN " +
+		"INSERT INTO articles_fts (rowid, title, content) VALUES (new.id, new.title, new.content); END",
+	"CREATE TRIGGER IF NOT EXISTS articles_fts_delete AFTER DELETE ON articles BEGIN " +
+		"INSERT INTO articles_fts (articles_fts, rowid, title, content) VALUES ('delete', old.id, old.title, old.content); END",
+	"CREATE TRIGGER IF NOT EXISTS articles_fts_update AFTER UPDATE OF title, content ON articles BEGIN " +
+		"INSERT INTO articles_fts (articles_fts, rowid, title, content) VALUES ('delete', old.id, old.title, old.content); " +
+		"INSERT INTO articles_fts (rowid, title, content) VALUES (new.id, new.title, new.content); END",
+}
+
+type sqliteArticleSearchIndex struct {
+	db *gorm.DB
+}
+
+// NewSqliteArticleSearchIndex returns a search index backed by an FTS5 table.
+func NewSqliteArticleSearchIndex(db *gorm.DB) domain.ArticleSearchIndex {
+	return &sqliteArticleSearchIndex{db: db}
+}
+
+func (i *sqliteArticleSearchIndex) Setup(ctx context.Context) error {
+	return i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'articles_fts'").Scan(&count).Error; err != nil {
+			return err
+		}
+
+		for _, statement := range sqliteSetup {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		// articles stored before the table existed are not indexed yet
+		if count == 0 {
+			return rebuild(tx)
+		}
+		return nil
+	})
+}
+
+func (i *sqliteArticleSearchIndex) Reindex(ctx context.Context) error {
+	return rebuild(i.db.WithContext(ctx))
+}
+
+func (i *sqliteArticleSearchIndex) Match(query *gorm.DB, terms string) *gorm.DB {
+	expression := ftsExpression(terms)
+	if expression == "" {
+		return query.Where("1 = 0")
+	}
+	return query.Where("id IN (SELECT rowid FROM articles_fts WHERE articles_fts MATCH ?)", expression)
+}
+
+// Rank orders by bm25, where a lower score is a better match.
+func (i *sqliteArticleSearchIndex) Rank(query *gorm.DB, terms string) *gorm.DB {
+	expression := ftsExpression(terms)
+	if expression == "" {
+		return query
+	}
+	return query.
+		Select(selected(query)+", (SELECT bm25(articles_fts) FROM articles_fts WHERE articles_fts MATCH ? AND rowid = articles.id) AS relevance", expression).
+		Order("relevance")
+}
+
+func rebuild(tx *gorm.DB) error {
+	return tx.Exec("INSERT INTO articles_fts (articles_fts) VALUES ('rebuild')").Error
+}
+
+// ftsExpression quotes each term so that the query cannot use FTS5 syntax,
+// and matches any of them like MySQL's natural language mode does.
+func ftsExpression(terms string) string {
+	words := xsearch.Terms(terms)
+	for i, word := range words {
+		words[i] = `"` + word + `"`
+	}
+	return strings.Join(words, " OR ")
+}
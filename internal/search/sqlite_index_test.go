@@ -0,0 +1,96 @@
+package search
+
+import (
+	"context"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+	"testing"
+)
+
+func sqliteDBConnection(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Silent),
+	})
+	assert.NoError(t, err)
+
+	// every connection to :memory: opens a database of its own
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	assert.NoError(t, db.AutoMigrate(&domain.Author{}, &domain.Article{}))
+	return db
+}
+
+func search(t *testing.T, db *gorm.DB, index domain.ArticleSearchIndex, terms string) []uint {
+	var articles []*domain.Article
+	query := index.Rank(index.Match(db, terms), terms)
+	assert.NoError(t, query.Order("id").Find(&articles).Error)
+
+	ids := make([]uint, 0, len(articles))
+	for _, article := range articles {
+		ids = append(ids, article.ID)
+	}
+	return ids
+}
+
+func TestSqliteArticleSearchIndex(t *testing.T) {
+	db := sqliteDBConnection(t)
+	index := NewSqliteArticleSearchIndex(db)
+
+	// stored before the index exists
+	assert.NoError(t, db.Create(&domain.Article{Title: "Clean architecture", Slug: "a", Content: "Layers in Go"}).Error)
+	assert.NoError(t, index.Setup(context.Background()))
+	assert.NoError(t, index.Setup(context.Background()))
+
+	assert.NoError(t, db.Create(&domain.Article{Title: "Fiber", Slug: "b", Content: "Go web framework, in Go for Go"}).Error)
+	assert.NoError(t, db.Create(&domain.Article{Title: "Gorm", Slug: "c", Content: "An ORM"}).Error)
+
+	t.Run("match and rank", func(t *testing.T) {
+		assert.Equal(t, []uint{2, 1}, search(t, db, index, "go"))
+		assert.Equal(t, []uint{3, 1}, search(t, db, index, "CLEAN orm"))
+	})
+
+	t.Run("query syntax is ignored", func(t *testing.T) {
+		assert.Equal(t, []uint{3}, search(t, db, index, `"orm" NEAR(`))
+	})
+
+	t.Run("no terms", func(t *testing.T) {
+		assert.Empty(t, search(t, db, index, "?!"))
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+		assert.NoError(t, db.Model(&domain.Article{ID: 3}).Update("content", "Database access for Go").Error)
+		assert.Empty(t, search(t, db, index, "orm"))
+		assert.Len(t, search(t, db, index, "database"), 1)
+
+		assert.NoError(t, db.Unscoped().Delete(&domain.Article{}, 3).Error)
+		assert.Empty(t, search(t, db, index, "database"))
+	})
+
+	t.Run("soft deleted", func(t *testing.T) {
+		assert.NoError(t, db.Delete(&domain.Article{}, 2).Error)
+		assert.Equal(t, []uint{1}, search(t, db, index, "go"))
+	})
+
+	t.Run("reindex", func(t *testing.T) {
+		// bypass the triggers so that the index is out of date
+		assert.NoError(t, db.Exec("DROP TRIGGER articles_fts_update").Error)
+		assert.NoError(t, db.Model(&domain.Article{ID: 1}).Update("title", "Hexagonal").Error)
+		assert.Empty(t, search(t, db, index, "hexagonal"))
+
+		assert.NoError(t, index.Reindex(context.Background()))
+		assert.Equal(t, []uint{1}, search(t, db, index, "hexagonal"))
+	})
+}
+
+func TestSqliteArticleSearchIndex_SetupError(t *testing.T) {
+	db := sqliteDBConnection(t)
+	assert.NoError(t, db.Migrator().DropTable(&domain.Article{}))
+
+	err := NewSqliteArticleSearchIndex(db).Setup(context.Background())
+	assert.Error(t, err)
+}
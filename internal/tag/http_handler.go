@@ -0,0 +1,49 @@
+package tag
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/internal/utilities"
+)
+
+type HttpTagHandler struct {
+	tagSvc domain.TagService
+}
+
+func NewHttpHandler(r fiber.Router, tagSvc domain.TagService) {
+	handler := &HttpTagHandler{
+		tagSvc: tagSvc,
+	}
+	r.Get("/", handler.Fetch)
+}
+
+// Fetch used to get list of tags
+//
+//	@Summary		Get list of tags
+//	@Description	Get every tag with the number of articles using it, most used first
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			status	query		string			false	"Count only articles in this status (default published). Other statuses need the X-Editor header"	Enums(draft, review, published, archived, all)
+//	@Success		200		{array}		domain.TagUsage	"List of tags"
+//	@Failure		400		{object}	domain.Error	"Bad Request"
+//	@Failure		403		{object}	domain.Error	"Status other than published without the X-Editor header"
+//	@Failure		500		{object}	domain.Error	"Internal Server Error"
+//	@Router			/tags [get]
+func (h *HttpTagHandler) Fetch(c *fiber.Ctx) error {
+	status, err := utilities.QueryStatus(c)
+	if err != nil {
+		statusErr := err.(domain.Error)
+		return c.Status(statusErr.Code).JSON(statusErr)
+	}
+
+	tags, err := h.tagSvc.FetchUsage(c.UserContext(), status)
+	if err != nil {
+		return err
+	}
+
+	if tags == nil {
+		return c.JSON([]domain.TagUsage{})
+	}
+	return c.JSON(tags)
+}
@@ -0,0 +1,92 @@
+package tag
+
+import (
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/internal/middleware/editor"
+	"go-clean-architecture/mocks"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHttpTagHandler_Fetch(t *testing.T) {
+	mockService := new(mocks.TagService)
+	mockTags := []*domain.TagUsage{
+		{ID: 1, Name: "go", ArticleCount: 2},
+		{ID: 2, Name: "web", ArticleCount: 0},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockService.On("FetchUsage", mock.Anything, domain.ArticleStatusPublished).
+			Return(mockTags, nil).Once()
+
+		app := fiber.New()
+		NewHttpHandler(app, mockService)
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var tags []domain.TagUsage
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&tags))
+		assert.Len(t, tags, 2)
+		assert.Equal(t, int64(2), tags[0].ArticleCount)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("success-all-statuses", func(t *testing.T) {
+		mockService.On("FetchUsage", mock.Anything, domain.ArticleStatus("")).
+			Return(nil, nil).Once()
+
+		app := fiber.New()
+		app.Use(editor.New())
+		NewHttpHandler(app, mockService)
+		req := httptest.NewRequest("GET", "/?status=all", nil)
+		req.Header.Set(editor.HeaderEditor, "jane")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+
+		var tags []domain.TagUsage
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&tags))
+		assert.NotNil(t, tags)
+		assert.Empty(t, tags)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-invalid-status", func(t *testing.T) {
+		app := fiber.New()
+		NewHttpHandler(app, mockService)
+		resp, err := app.Test(httptest.NewRequest("GET", "/?status=deleted", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 400, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error-not-editor", func(t *testing.T) {
+		app := fiber.New()
+		app.Use(editor.New())
+		NewHttpHandler(app, mockService)
+		resp, err := app.Test(httptest.NewRequest("GET", "/?status=draft", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 403, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mockService.On("FetchUsage", mock.Anything, domain.ArticleStatusDraft).
+			Return(nil, assert.AnError).Once()
+
+		app := fiber.New()
+		app.Use(editor.New())
+		NewHttpHandler(app, mockService)
+		req := httptest.NewRequest("GET", "/?status=draft", nil)
+		req.Header.Set(editor.HeaderEditor, "jane")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, 500, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,76 @@
+package tag
+
+import (
+	"context"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mysqlTagRepository struct {
+	db *gorm.DB
+}
+
+func NewMysqlTagRepository(db *gorm.DB) domain.TagRepository {
+	return &mysqlTagRepository{db: db}
+}
+
+// FetchUsage returns every tag with the number of non-deleted articles using
+// it, limited to articles in status unless status is empty.
+func (r *mysqlTagRepository) FetchUsage(ctx context.Context, status domain.ArticleStatus) ([]*domain.TagUsage, error) {
+	articleJoin := "LEFT JOIN articles ON articles.id = article_tags.article_id AND articles.deleted_at IS NULL"
+	var joinArgs []any
+	if status != "" {
+		articleJoin += " AND articles.status = ?"
+		joinArgs = append(joinArgs, status)
+	}
+
+	var usages []*domain.TagUsage
+	if err := r.db.WithContext(ctx).Model(&domain.Tag{}).
+		Select("tags.id, tags.name, COUNT(articles.id) AS article_count").
+		Joins("LEFT JOIN article_tags ON article_tags.tag_id = tags.id").
+		Joins(articleJoin, joinArgs...).
+		Group("tags.id, tags.name").
+		Order("article_count DESC, tags.name").
+		Scan(&usages).Error; err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
+
+// FirstOrCreate returns the tags with the given names, creating the missing
+// ones. The tags are returned in the order of names.
+func (r *mysqlTagRepository) FirstOrCreate(ctx context.Context, names []string) ([]*domain.Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	tags := make([]*domain.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, &domain.Tag{Name: name})
+	}
+
+	var stored []*domain.Tag
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+			return err
+		}
+		return tx.Where("name IN ?", names).Find(&stored).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*domain.Tag, len(stored))
+	for _, tag := range stored {
+		byName[tag.Name] = tag
+	}
+
+	tags = tags[:0]
+	for _, name := range names {
+		if tag, ok := byName[name]; ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
@@ -0,0 +1,146 @@
+package tag
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+	"regexp"
+	"testing"
+)
+
+func mockDBConnection() (*gorm.DB, sqlmock.Sqlmock, error) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gdb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: gormLogger.Default.LogMode(gormLogger.Info),
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gdb, mock, nil
+}
+
+func TestMysqlTagRepository_FetchUsage(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "SELECT tags.id, tags.name, COUNT(articles.id) AS article_count FROM `tags` " +
+		"LEFT JOIN article_tags ON article_tags.tag_id = tags.id " +
+		"LEFT JOIN articles ON articles.id = article_tags.article_id AND articles.deleted_at IS NULL AND articles.status = ? " +
+		"GROUP BY tags.id, tags.name ORDER BY article_count DESC, tags.name"
+
+	rows := sqlmock.NewRows([]string{"id", "name", "article_count"}).
+		AddRow(1, "go", 2).
+		AddRow(2, "web", 0)
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(domain.ArticleStatusPublished).
+		WillReturnRows(rows)
+
+	repo := NewMysqlTagRepository(db)
+
+	tags, err := repo.FetchUsage(context.Background(), domain.ArticleStatusPublished)
+	assert.NoError(t, err)
+	assert.Len(t, tags, 2)
+	assert.Equal(t, "go", tags[0].Name)
+	assert.Equal(t, int64(2), tags[0].ArticleCount)
+}
+
+func TestMysqlTagRepository_FetchUsage_AllStatuses(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	query := "LEFT JOIN articles ON articles.id = article_tags.article_id AND articles.deleted_at IS NULL GROUP BY"
+
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithoutArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "article_count"}))
+
+	repo := NewMysqlTagRepository(db)
+
+	tags, err := repo.FetchUsage(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestMysqlTagRepository_FetchUsage_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT tags.id, tags.name")).
+		WillReturnError(assert.AnError)
+
+	repo := NewMysqlTagRepository(db)
+
+	tags, err := repo.FetchUsage(context.Background(), domain.ArticleStatusPublished)
+	assert.Error(t, err)
+	assert.Nil(t, tags)
+}
+
+func TestMysqlTagRepository_FirstOrCreate(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	insertQuery := "INSERT INTO `tags` (`name`,`created_at`) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE `id`=`id`"
+	selectQuery := "SELECT * FROM `tags` WHERE name IN (?,?)"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+		WithArgs("web", sqlmock.AnyArg(), "go", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+		WithArgs("web", "go").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "go").AddRow(5, "web"))
+	mock.ExpectCommit()
+
+	repo := NewMysqlTagRepository(db)
+
+	tags, err := repo.FirstOrCreate(context.Background(), []string{"web", "go"})
+	assert.NoError(t, err)
+	assert.Len(t, tags, 2)
+	assert.Equal(t, "web", tags[0].Name)
+	assert.Equal(t, uint(5), tags[0].ID)
+	assert.Equal(t, uint(3), tags[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlTagRepository_FirstOrCreate_Empty(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	repo := NewMysqlTagRepository(db)
+
+	tags, err := repo.FirstOrCreate(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMysqlTagRepository_FirstOrCreate_Error(t *testing.T) {
+	db, mock, err := mockDBConnection()
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `tags`")).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	repo := NewMysqlTagRepository(db)
+
+	tags, err := repo.FirstOrCreate(context.Background(), []string{"go"})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
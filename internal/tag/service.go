@@ -0,0 +1,25 @@
+package tag
+
+import (
+	"context"
+	"go-clean-architecture/internal/domain"
+)
+
+type tagService struct {
+	tagRepo domain.TagRepository
+}
+
+func NewTagService(tag domain.TagRepository) domain.TagService {
+	return &tagService{
+		tagRepo: tag,
+	}
+}
+
+func (t *tagService) FetchUsage(ctx context.Context, status domain.ArticleStatus) ([]*domain.TagUsage, error) {
+	tags, err := t.tagRepo.FetchUsage(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
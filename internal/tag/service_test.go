@@ -0,0 +1,41 @@
+package tag
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/mocks"
+	"testing"
+)
+
+func TestTagService_FetchUsage(t *testing.T) {
+	mockTagRepository := new(mocks.TagRepository)
+	mockTags := []*domain.TagUsage{
+		{ID: 1, Name: "go", ArticleCount: 2},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockTagRepository.On("FetchUsage", mock.Anything, domain.ArticleStatusPublished).
+			Return(mockTags, nil).Once()
+
+		tagSvc := NewTagService(mockTagRepository)
+		tags, err := tagSvc.FetchUsage(context.Background(), domain.ArticleStatusPublished)
+		assert.NoError(t, err)
+		assert.Equal(t, mockTags, tags)
+
+		mockTagRepository.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockTagRepository.On("FetchUsage", mock.Anything, domain.ArticleStatusPublished).
+			Return(nil, assert.AnError).Once()
+
+		tagSvc := NewTagService(mockTagRepository)
+		tags, err := tagSvc.FetchUsage(context.Background(), domain.ArticleStatusPublished)
+		assert.Error(t, err)
+		assert.Nil(t, tags)
+
+		mockTagRepository.AssertExpectations(t)
+	})
+}
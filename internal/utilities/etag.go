@@ -0,0 +1,65 @@
+package utilities
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatETag returns the strong entity tag of a resource version.
+func FormatETag(version uint) string {
+	return `"` + strconv.FormatUint(uint64(version), 10) + `"`
+}
+
+// ParseETag returns the version of a strong entity tag made by FormatETag.
+// Weak and malformed tags are rejected.
+func ParseETag(tag string) (uint, bool) {
+	if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return 0, false
+	}
+
+	version, err := strconv.ParseUint(tag[1:len(tag)-1], 10, 0)
+	if err != nil || version == 0 {
+		return 0, false
+	}
+	return uint(version), true
+}
+
+// ParseIfMatch reads the entity tags of an If-Match header, a comma
+// separated list of tags or "*", and returns the versions of the strong tags
+// made by FormatETag. If-Match uses the strong comparison, so weak and
+// malformed tags are left out: they match no version.
+func ParseIfMatch(header string) (versions []uint, wildcard bool) {
+	for header != "" {
+		header = strings.TrimLeft(header, " \t,")
+		if header == "" {
+			break
+		}
+
+		var tag string
+		switch {
+		case header[0] == '*':
+			wildcard = true
+			header = header[1:]
+			continue
+		case strings.HasPrefix(header, `W/"`), header[0] == '"':
+			// the tag ends at the next quote, commas may be part of it
+			start := strings.IndexByte(header, '"')
+			end := strings.IndexByte(header[start+1:], '"')
+			if end < 0 {
+				return versions, wildcard
+			}
+			tag, header = header[:start+end+2], header[start+end+2:]
+		default:
+			end := strings.IndexByte(header, ',')
+			if end < 0 {
+				end = len(header)
+			}
+			tag, header = header[:end], header[end:]
+		}
+
+		if version, ok := ParseETag(tag); ok {
+			versions = append(versions, version)
+		}
+	}
+	return versions, wildcard
+}
@@ -0,0 +1,60 @@
+package utilities
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFormatETag(t *testing.T) {
+	assert.Equal(t, `"3"`, FormatETag(3))
+}
+
+func TestParseETag(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected uint
+		ok       bool
+	}{
+		{tag: `"3"`, expected: 3, ok: true},
+		{tag: `W/"3"`},
+		{tag: `3`},
+		{tag: `"`},
+		{tag: `"abc"`},
+		{tag: `"0"`},
+		{tag: `"-1"`},
+		{tag: `"1", "2"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			version, ok := ParseETag(tt.tag)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, version)
+		})
+	}
+}
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header   string
+		versions []uint
+		wildcard bool
+	}{
+		{header: `"3"`, versions: []uint{3}},
+		{header: `"1", "2",W/"4" ,"5"`, versions: []uint{1, 2, 5}},
+		{header: `*`, wildcard: true},
+		{header: `W/"3"`},
+		{header: `"a,b", "7"`, versions: []uint{7}},
+		{header: `3, "4"`, versions: []uint{4}},
+		{header: `"4`},
+		{header: ` , `},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			versions, wildcard := ParseIfMatch(tt.header)
+			assert.Equal(t, tt.versions, versions)
+			assert.Equal(t, tt.wildcard, wildcard)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package utilities
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ParseList parses a comma separated list of names, each of which must be a
+// key of allowed. name describes the names in errors, such as "field".
+func ParseList[V any](value string, name string, allowed map[string]V) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if _, ok := allowed[item]; !ok {
+			keys := make([]string, 0, len(allowed))
+			for key := range allowed {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			return nil, fmt.Errorf("%s %q must be one of %s", name, item, strings.Join(keys, ", "))
+		}
+		if seen[item] {
+			return nil, fmt.Errorf("%s %q is repeated", name, item)
+		}
+		seen[item] = true
+
+		names = append(names, item)
+	}
+
+	return names, nil
+}
+
+// PickFields returns the JSON form of v, an object or a list of objects, with
+// only the given fields of each object.
+func PickFields(v any, fields []string) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// numbers are kept as written, as float64 would round large integers
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	switch decoded := decoded.(type) {
+	case map[string]any:
+		return pick(decoded, fields), nil
+	case []any:
+		for i, item := range decoded {
+			if object, ok := item.(map[string]any); ok {
+				decoded[i] = pick(object, fields)
+			}
+		}
+	}
+	return decoded, nil
+}
+
+func pick(object map[string]any, fields []string) map[string]any {
+	picked := make(map[string]any, len(fields))
+	for _, field := range fields {
+		if value, ok := object[field]; ok {
+			picked[field] = value
+		}
+	}
+	return picked
+}
@@ -0,0 +1,70 @@
+package utilities
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseList(t *testing.T) {
+	allowed := map[string]bool{"id": true, "title": true, "author": true}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+		err      string
+	}{
+		{name: "names", value: "title,id", expected: []string{"title", "id"}},
+		{name: "spaces and empty names", value: " author ,, ", expected: []string{"author"}},
+		{name: "empty", value: ""},
+		{name: "unknown name", value: "id,content", err: `field "content" must be one of author, id, title`},
+		{name: "repeated name", value: "id,title,id", err: `field "id" is repeated`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := ParseList(tt.value, "field", allowed)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestPickFields(t *testing.T) {
+	type item struct {
+		ID    uint   `json:"id"`
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}
+
+	t.Run("object", func(t *testing.T) {
+		picked, err := PickFields(&item{ID: 1, Title: "a", Body: "b"}, []string{"title", "missing"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"title": "a"}, picked)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		picked, err := PickFields([]*item{{ID: 1, Title: "a"}, {ID: 2, Title: "b"}}, []string{"id"})
+		assert.NoError(t, err)
+		assert.Equal(t, []any{map[string]any{"id": json.Number("1")}, map[string]any{"id": json.Number("2")}}, picked)
+	})
+
+	t.Run("large-number", func(t *testing.T) {
+		picked, err := PickFields(&item{ID: 1<<53 + 1}, []string{"id"})
+		assert.NoError(t, err)
+
+		data, err := json.Marshal(picked)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":9007199254740993}`, string(data))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := PickFields(make(chan int), []string{"id"})
+		assert.Error(t, err)
+	})
+}
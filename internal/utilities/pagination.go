@@ -0,0 +1,38 @@
+package utilities
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"net/url"
+	"strconv"
+)
+
+func SetPaginationHeaders(c *fiber.Ctx, nextPage uint, totalItem int64, size int) {
+	maxPage := int(totalItem) / size
+
+	if nextPage > 0 && nextPage <= uint(maxPage) {
+		c.Set("X-Cursor", strconv.Itoa(int(nextPage)))
+		SetNextLink(c, "page", strconv.Itoa(int(nextPage)))
+	}
+	c.Set("X-Total-Count", strconv.Itoa(int(totalItem)))
+	c.Set("X-Max-Page", strconv.Itoa(maxPage))
+}
+
+func SetCursorHeaders(c *fiber.Ctx, nextCursor string) {
+	c.Set("X-Cursor", nextCursor)
+	SetNextLink(c, "cursor", nextCursor)
+}
+
+// SetNextLink sets an RFC 8288 Link header pointing to the current URL with
+// the given query parameter replaced.
+func SetNextLink(c *fiber.Ctx, key string, value string) {
+	u, err := url.Parse(c.OriginalURL())
+	if err != nil {
+		return
+	}
+
+	query := u.Query()
+	query.Set(key, value)
+	u.RawQuery = query.Encode()
+
+	c.Set(fiber.HeaderLink, "<"+c.BaseURL()+u.String()+`>; rel="next"`)
+}
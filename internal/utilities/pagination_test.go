@@ -0,0 +1,64 @@
+package utilities
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetPaginationHeaders(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		SetPaginationHeaders(c, 2, 20, 10)
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/?page=1&size=10", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "2", resp.Header.Get("X-Cursor"))
+	assert.Equal(t, "20", resp.Header.Get("X-Total-Count"))
+	assert.Equal(t, "2", resp.Header.Get("X-Max-Page"))
+	assert.Equal(t, `<http://example.com/?page=2&size=10>; rel="next"`, resp.Header.Get("Link"))
+}
+
+func TestSetPaginationHeaders_LastPage(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		SetPaginationHeaders(c, 3, 20, 10)
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Header.Get("X-Cursor"))
+	assert.Empty(t, resp.Header.Get("Link"))
+	assert.Equal(t, "20", resp.Header.Get("X-Total-Count"))
+	assert.Equal(t, "2", resp.Header.Get("X-Max-Page"))
+}
+
+func TestSetCursorHeaders(t *testing.T) {
+	app := fiber.New()
+	app.Get("/articles", func(c *fiber.Ctx) error {
+		SetCursorHeaders(c, "abc.def")
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/articles?cursor=&size=5", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "abc.def", resp.Header.Get("X-Cursor"))
+	assert.Equal(t, `<http://example.com/articles?cursor=abc.def&size=5>; rel="next"`, resp.Header.Get("Link"))
+}
+
+func TestSetNextLink_InvalidURL(t *testing.T) {
+	app := fiber.New()
+	app.Get("/*", func(c *fiber.Ctx) error {
+		c.Request().SetRequestURI("/%zz")
+		SetNextLink(c, "page", "2")
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/x", nil))
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Header.Get("Link"))
+}
@@ -0,0 +1,53 @@
+package utilities
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"go-clean-architecture/internal/domain"
+	"go-clean-architecture/pkg/xsearch"
+	"time"
+)
+
+// QueryTime returns the RFC 3339 time in the query parameter key, or nil when
+// the parameter is not set.
+func QueryTime(c *fiber.Ctx, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 time", key)
+	}
+	return &t, nil
+}
+
+// QueryStatus returns the article status in the status query parameter,
+// published when it is not set and an empty status for all. Only editors, who
+// send the X-Editor header, may ask for any other status than published.
+// Errors are returned as a domain.Error.
+func QueryStatus(c *fiber.Ctx) (domain.ArticleStatus, error) {
+	status := domain.ArticleStatus(c.Query("status", string(domain.ArticleStatusPublished)))
+	if status != domain.ArticleStatusAll && !status.Valid() {
+		return "", domain.NewError(fiber.StatusBadRequest, "status must be one of draft, review, published, archived or all")
+	}
+	if status != domain.ArticleStatusPublished && domain.EditorFromContext(c.UserContext()) == "" {
+		return "", domain.NewError(fiber.StatusForbidden, "only editors may see articles that are not published")
+	}
+
+	if status == domain.ArticleStatusAll {
+		return "", nil
+	}
+	return status, nil
+}
+
+// QuerySearch returns the full-text search in the q query parameter. A search
+// made only of punctuation has no words to match and is rejected.
+func QuerySearch(c *fiber.Ctx) (string, error) {
+	query := c.Query("q")
+	if query != "" && len(xsearch.Terms(query)) == 0 {
+		return "", fmt.Errorf("q must contain a letter or a digit")
+	}
+	return query, nil
+}
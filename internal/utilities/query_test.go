@@ -0,0 +1,129 @@
+package utilities
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestQueryTime(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		from, err := QueryTime(c, "from")
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+		}
+		if from == nil {
+			return c.SendString("none")
+		}
+		return c.SendString(from.UTC().Format(time.RFC3339))
+	})
+
+	tests := []struct {
+		name     string
+		url      string
+		status   int
+		expected string
+	}{
+		{name: "utc", url: "/?from=2024-01-02T03:04:05Z", status: 200, expected: "2024-01-02T03:04:05Z"},
+		{name: "offset", url: "/?from=2024-01-02T10:04:05%2B07:00", status: 200, expected: "2024-01-02T03:04:05Z"},
+		{name: "missing", url: "/", status: 200, expected: "none"},
+		{name: "invalid", url: "/?from=2024-01-02", status: 400, expected: "from must be an RFC 3339 time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest("GET", tt.url, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(body))
+		})
+	}
+}
+
+func TestQueryStatus(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		if c.Get("X-Editor") != "" {
+			c.SetUserContext(domain.ContextWithEditor(c.UserContext(), c.Get("X-Editor")))
+		}
+		status, err := QueryStatus(c)
+		if err != nil {
+			statusErr := err.(domain.Error)
+			return c.Status(statusErr.Code).SendString(statusErr.Message)
+		}
+		return c.SendString(string(status))
+	})
+
+	tests := []struct {
+		name     string
+		url      string
+		editor   string
+		status   int
+		expected string
+	}{
+		{name: "default", url: "/", status: 200, expected: "published"},
+		{name: "published", url: "/?status=published", status: 200, expected: "published"},
+		{name: "editor-draft", url: "/?status=draft", editor: "jane", status: 200, expected: "draft"},
+		{name: "editor-all", url: "/?status=all", editor: "jane", status: 200, expected: ""},
+		{name: "not-editor", url: "/?status=all", status: 403, expected: "only editors may see articles that are not published"},
+		{name: "invalid", url: "/?status=deleted", editor: "jane", status: 400, expected: "status must be one of draft, review, published, archived or all"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.editor != "" {
+				req.Header.Set("X-Editor", tt.editor)
+			}
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(body))
+		})
+	}
+}
+
+func TestQuerySearch(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		query, err := QuerySearch(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+		}
+		return c.SendString(query)
+	})
+
+	tests := []struct {
+		name     string
+		url      string
+		status   int
+		expected string
+	}{
+		{name: "words", url: "/?q=clean+architecture", status: 200, expected: "clean architecture"},
+		{name: "missing", url: "/", status: 200, expected: ""},
+		{name: "punctuation", url: "/?q=%3F%21+--", status: 400, expected: "q must contain a letter or a digit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest("GET", tt.url, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(body))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package utilities
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+// maxSlugLength leaves room for a uniqueness suffix in a varchar(255) column.
+const maxSlugLength = 200
+
+// Slugify turns s into a lowercase, hyphen separated ASCII slug. Accents are
+// stripped and any other character that is not a letter or digit becomes a
+// separator, e.g. "Héllo, Wörld!" becomes "hello-world".
+func Slugify(s string) string {
+	var b strings.Builder
+	separate := false
+
+	for _, r := range norm.NFKD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if separate && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			separate = false
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			separate = true
+		}
+
+		if b.Len() >= maxSlugLength {
+			break
+		}
+	}
+
+	return strings.TrimRight(b.String()[:min(b.Len(), maxSlugLength)], "-")
+}
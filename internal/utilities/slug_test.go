@@ -0,0 +1,31 @@
+package utilities
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Hello World":            "hello-world",
+		"  Hello,   World!  ":    "hello-world",
+		"Héllo Wörld":            "hello-world",
+		"Go 1.21 released":       "go-1-21-released",
+		"already-a-slug":         "already-a-slug",
+		"Crème brûlée & café":    "creme-brulee-cafe",
+		"日本語":                    "",
+		"--":                     "",
+		"Mixed_CASE_with_unders": "mixed-case-with-unders",
+	}
+
+	for input, expected := range tests {
+		assert.Equal(t, expected, Slugify(input), input)
+	}
+}
+
+func TestSlugify_MaxLength(t *testing.T) {
+	slug := Slugify(strings.Repeat("word ", 100))
+	assert.LessOrEqual(t, len(slug), maxSlugLength)
+	assert.False(t, strings.HasSuffix(slug, "-"))
+}
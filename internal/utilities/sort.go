@@ -0,0 +1,44 @@
+package utilities
+
+import (
+	"fmt"
+	"go-clean-architecture/internal/domain"
+	"sort"
+	"strings"
+)
+
+// ParseSort parses a comma separated list of fields, each optionally prefixed
+// with - for descending order, such as "title,-createdAt". columns maps the
+// fields that may be sorted by to their database column.
+func ParseSort(value string, columns map[string]string) ([]domain.SortField, error) {
+	var fields []domain.SortField
+	seen := make(map[string]bool)
+
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+
+		column, ok := columns[name]
+		if !ok {
+			names := make([]string, 0, len(columns))
+			for name := range columns {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("sort field %q must be one of %s", name, strings.Join(names, ", "))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("sort field %q is repeated", name)
+		}
+		seen[name] = true
+
+		fields = append(fields, domain.SortField{Column: column, Desc: desc})
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,55 @@
+package utilities
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go-clean-architecture/internal/domain"
+	"testing"
+)
+
+func TestParseSort(t *testing.T) {
+	columns := map[string]string{"title": "title", "createdAt": "created_at"}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected []domain.SortField
+		err      string
+	}{
+		{
+			name:     "fields",
+			value:    "title,-createdAt",
+			expected: []domain.SortField{{Column: "title"}, {Column: "created_at", Desc: true}},
+		},
+		{
+			name:     "spaces and empty fields",
+			value:    " -title ,, ",
+			expected: []domain.SortField{{Column: "title", Desc: true}},
+		},
+		{
+			name:  "empty",
+			value: "",
+		},
+		{
+			name:  "unknown field",
+			value: "title,-content",
+			err:   `sort field "content" must be one of createdAt, title`,
+		},
+		{
+			name:  "repeated field",
+			value: "title,-title",
+			err:   `sort field "title" is repeated`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := ParseSort(tt.value, columns)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, fields)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package utilities
+
+import "strings"
+
+// NormalizeTags trims and lowercases tag names, dropping empty and duplicate
+// names while keeping the original order.
+func NormalizeTags(names []string) []string {
+	var tags []string
+	seen := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, name)
+	}
+
+	return tags
+}
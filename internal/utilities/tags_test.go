@@ -0,0 +1,12 @@
+package utilities
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	assert.Equal(t, []string{"go", "web dev"}, NormalizeTags([]string{" Go ", "", "web dev", "GO"}))
+	assert.Nil(t, NormalizeTags(nil))
+	assert.Nil(t, NormalizeTags([]string{" "}))
+}
@@ -1,20 +1,22 @@
 package mocks
 
 import (
+	"context"
 	"github.com/stretchr/testify/mock"
 	"go-clean-architecture/internal/domain"
+	"time"
 )
 
 type ArticleRepository struct {
 	mock.Mock
 }
 
-func (m *ArticleRepository) Fetch(page uint, size uint, filter *domain.Article) ([]*domain.Article, uint, error) {
-	ret := m.Called(page, size, filter)
+func (m *ArticleRepository) Fetch(ctx context.Context, page uint, size uint, filter *domain.ArticleFilter) ([]*domain.Article, uint, error) {
+	ret := m.Called(ctx, page, size, filter)
 
 	var r0 []*domain.Article
-	if rf, ok := ret.Get(0).(func(page uint, size uint, filter *domain.Article) []*domain.Article); ok {
-		r0 = rf(page, size, filter)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, page uint, size uint, filter *domain.ArticleFilter) []*domain.Article); ok {
+		r0 = rf(ctx, page, size, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Article)
@@ -22,15 +24,15 @@ func (m *ArticleRepository) Fetch(page uint, size uint, filter *domain.Article)
 	}
 
 	var r1 uint
-	if rf, ok := ret.Get(1).(func(page uint, size uint, filter *domain.Article) uint); ok {
-		r1 = rf(page, size, filter)
+	if rf, ok := ret.Get(1).(func(ctx context.Context, page uint, size uint, filter *domain.ArticleFilter) uint); ok {
+		r1 = rf(ctx, page, size, filter)
 	} else {
 		r1 = ret.Get(1).(uint)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(page uint, size uint, filter *domain.Article) error); ok {
-		r2 = rf(page, size, filter)
+	if rf, ok := ret.Get(2).(func(ctx context.Context, page uint, size uint, filter *domain.ArticleFilter) error); ok {
+		r2 = rf(ctx, page, size, filter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -38,12 +40,91 @@ func (m *ArticleRepository) Fetch(page uint, size uint, filter *domain.Article)
 	return r0, r1, r2
 }
 
-func (m *ArticleRepository) GetByID(id uint) (*domain.Article, error) {
-	ret := m.Called(id)
+func (m *ArticleRepository) FetchByCursor(ctx context.Context, cursor *domain.ArticleCursor, size uint, filter *domain.ArticleFilter) ([]*domain.Article, *domain.ArticleCursor, error) {
+	ret := m.Called(ctx, cursor, size, filter)
+
+	var r0 []*domain.Article
+	if rf, ok := ret.Get(0).(func(ctx context.Context, cursor *domain.ArticleCursor, size uint, filter *domain.ArticleFilter) []*domain.Article); ok {
+		r0 = rf(ctx, cursor, size, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Article)
+		}
+	}
+
+	var r1 *domain.ArticleCursor
+	if rf, ok := ret.Get(1).(func(ctx context.Context, cursor *domain.ArticleCursor, size uint, filter *domain.ArticleFilter) *domain.ArticleCursor); ok {
+		r1 = rf(ctx, cursor, size, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ArticleCursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, cursor *domain.ArticleCursor, size uint, filter *domain.ArticleFilter) error); ok {
+		r2 = rf(ctx, cursor, size, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *ArticleRepository) Export(ctx context.Context, filter *domain.ArticleFilter, fn func(articles []*domain.Article) error) error {
+	ret := m.Called(ctx, filter, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, filter *domain.ArticleFilter, fn func(articles []*domain.Article) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ArticleRepository) GetByID(ctx context.Context, id uint, view *domain.ArticleView) (*domain.Article, error) {
+	ret := m.Called(ctx, id, view)
+
+	var r0 *domain.Article
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint, view *domain.ArticleView) *domain.Article); ok {
+		r0 = rf(ctx, id, view)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Article)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, id uint, view *domain.ArticleView) error); ok {
+		r1 = rf(ctx, id, view)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleRepository) SaveRendered(ctx context.Context, article *domain.Article) error {
+	ret := m.Called(ctx, article)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, article *domain.Article) error); ok {
+		r0 = rf(ctx, article)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ArticleRepository) GetBySlug(ctx context.Context, slug string, view *domain.ArticleView) (*domain.Article, error) {
+	ret := m.Called(ctx, slug, view)
 
 	var r0 *domain.Article
-	if rf, ok := ret.Get(0).(func(id uint) *domain.Article); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, slug string, view *domain.ArticleView) *domain.Article); ok {
+		r0 = rf(ctx, slug, view)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Article)
@@ -51,8 +132,8 @@ func (m *ArticleRepository) GetByID(id uint) (*domain.Article, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(id uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(ctx context.Context, slug string, view *domain.ArticleView) error); ok {
+		r1 = rf(ctx, slug, view)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,19 +141,61 @@ func (m *ArticleRepository) GetByID(id uint) (*domain.Article, error) {
 	return r0, r1
 }
 
-func (m *ArticleRepository) Count(filter *domain.Article) (int64, error) {
-	ret := m.Called(filter)
+func (m *ArticleRepository) SlugOwner(ctx context.Context, slug string) (uint, error) {
+	ret := m.Called(ctx, slug)
+
+	var r0 uint
+	if rf, ok := ret.Get(0).(func(ctx context.Context, slug string) uint); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, slug string) error); ok {
+		r1 = rf(ctx, slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleRepository) Count(ctx context.Context, filter *domain.ArticleFilter) (int64, error) {
+	ret := m.Called(ctx, filter)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(filter *domain.Article) int64); ok {
-		r0 = rf(filter)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, filter *domain.ArticleFilter) int64); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(filter *domain.Article) error); ok {
-		r1 = rf(filter)
+	if rf, ok := ret.Get(1).(func(ctx context.Context, filter *domain.ArticleFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleRepository) GetByAuthorID(ctx context.Context, authorID uint) ([]*domain.Article, error) {
+	ret := m.Called(ctx, authorID)
+
+	var r0 []*domain.Article
+	if rf, ok := ret.Get(0).(func(ctx context.Context, authorID uint) []*domain.Article); ok {
+		r0 = rf(ctx, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Article)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, authorID uint) error); ok {
+		r1 = rf(ctx, authorID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -80,12 +203,12 @@ func (m *ArticleRepository) Count(filter *domain.Article) (int64, error) {
 	return r0, r1
 }
 
-func (m *ArticleRepository) GetByAuthorID(authorID uint) ([]*domain.Article, error) {
-	ret := m.Called(authorID)
+func (m *ArticleRepository) GetByTitle(ctx context.Context, title string) ([]*domain.Article, error) {
+	ret := m.Called(ctx, title)
 
 	var r0 []*domain.Article
-	if rf, ok := ret.Get(0).(func(authorID uint) []*domain.Article); ok {
-		r0 = rf(authorID)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, title string) []*domain.Article); ok {
+		r0 = rf(ctx, title)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Article)
@@ -93,8 +216,104 @@ func (m *ArticleRepository) GetByAuthorID(authorID uint) ([]*domain.Article, err
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(authorID uint) error); ok {
-		r1 = rf(authorID)
+	if rf, ok := ret.Get(1).(func(ctx context.Context, title string) error); ok {
+		r1 = rf(ctx, title)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleRepository) Store(ctx context.Context, article *domain.Article) error {
+	ret := m.Called(ctx, article)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, article *domain.Article) error); ok {
+		r0 = rf(ctx, article)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ArticleRepository) StoreMany(ctx context.Context, articles []*domain.Article) error {
+	ret := m.Called(ctx, articles)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articles []*domain.Article) error); ok {
+		r0 = rf(ctx, articles)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ArticleRepository) Update(ctx context.Context, article *domain.Article) error {
+	ret := m.Called(ctx, article)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, article *domain.Article) error); ok {
+		r0 = rf(ctx, article)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ArticleRepository) Delete(ctx context.Context, id uint, version uint) error {
+	ret := m.Called(ctx, id, version)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint, version uint) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ArticleRepository) DeleteMany(ctx context.Context, ids []uint, atomic bool) ([]uint, error) {
+	ret := m.Called(ctx, ids, atomic)
+
+	var r0 []uint
+	if rf, ok := ret.Get(0).(func(ctx context.Context, ids []uint, atomic bool) []uint); ok {
+		r0 = rf(ctx, ids, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, ids []uint, atomic bool) error); ok {
+		r1 = rf(ctx, ids, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleRepository) ReassignMany(ctx context.Context, ids []uint, authorID uint, atomic bool) ([]uint, error) {
+	ret := m.Called(ctx, ids, authorID, atomic)
+
+	var r0 []uint
+	if rf, ok := ret.Get(0).(func(ctx context.Context, ids []uint, authorID uint, atomic bool) []uint); ok {
+		r0 = rf(ctx, ids, authorID, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, ids []uint, authorID uint, atomic bool) error); ok {
+		r1 = rf(ctx, ids, authorID, atomic)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -102,21 +321,96 @@ func (m *ArticleRepository) GetByAuthorID(authorID uint) ([]*domain.Article, err
 	return r0, r1
 }
 
-func (m *ArticleRepository) GetByTitle(title string) ([]*domain.Article, error) {
-	ret := m.Called(title)
+func (m *ArticleRepository) FetchTrash(ctx context.Context, page uint, size uint) ([]*domain.Article, uint, error) {
+	ret := m.Called(ctx, page, size)
 
 	var r0 []*domain.Article
-	if rf, ok := ret.Get(0).(func(title string) []*domain.Article); ok {
-		r0 = rf(title)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, page uint, size uint) []*domain.Article); ok {
+		r0 = rf(ctx, page, size)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Article)
 		}
 	}
 
+	var r1 uint
+	if rf, ok := ret.Get(1).(func(ctx context.Context, page uint, size uint) uint); ok {
+		r1 = rf(ctx, page, size)
+	} else {
+		r1 = ret.Get(1).(uint)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, page uint, size uint) error); ok {
+		r2 = rf(ctx, page, size)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *ArticleRepository) CountTrash(ctx context.Context) (int64, error) {
+	ret := m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleRepository) Restore(ctx context.Context, id uint) error {
+	ret := m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ArticleRepository) DeletePermanent(ctx context.Context, id uint, version uint) error {
+	ret := m.Called(ctx, id, version)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint, version uint) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ArticleRepository) Purge(ctx context.Context, deletedBefore time.Time) ([]uint, error) {
+	ret := m.Called(ctx, deletedBefore)
+
+	var r0 []uint
+	if rf, ok := ret.Get(0).(func(ctx context.Context, deletedBefore time.Time) []uint); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
 	var r1 error
-	if rf, ok := ret.Get(1).(func(title string) error); ok {
-		r1 = rf(title)
+	if rf, ok := ret.Get(1).(func(ctx context.Context, deletedBefore time.Time) error); ok {
+		r1 = rf(ctx, deletedBefore)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -124,12 +418,12 @@ func (m *ArticleRepository) GetByTitle(title string) ([]*domain.Article, error)
 	return r0, r1
 }
 
-func (m *ArticleRepository) Store(article *domain.Article) error {
-	ret := m.Called(article)
+func (m *ArticleRepository) UpdateStatus(ctx context.Context, article *domain.Article, from domain.ArticleStatus) error {
+	ret := m.Called(ctx, article, from)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(article *domain.Article) error); ok {
-		r0 = rf(article)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, article *domain.Article, from domain.ArticleStatus) error); ok {
+		r0 = rf(ctx, article, from)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -137,12 +431,12 @@ func (m *ArticleRepository) Store(article *domain.Article) error {
 	return r0
 }
 
-func (m *ArticleRepository) Update(article *domain.Article) error {
-	ret := m.Called(article)
+func (m *ArticleRepository) UpdateSchedule(ctx context.Context, article *domain.Article) error {
+	ret := m.Called(ctx, article)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(article *domain.Article) error); ok {
-		r0 = rf(article)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, article *domain.Article) error); ok {
+		r0 = rf(ctx, article)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -150,15 +444,126 @@ func (m *ArticleRepository) Update(article *domain.Article) error {
 	return r0
 }
 
-func (m *ArticleRepository) Delete(id uint) error {
-	ret := m.Called(id)
+func (m *ArticleRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	ret := m.Called(ctx, now)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context, now time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, now time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleRepository) UnpublishDue(ctx context.Context, now time.Time) (int64, error) {
+	ret := m.Called(ctx, now)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context, now time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, now time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleRepository) AddViews(ctx context.Context, views map[uint]uint64, at time.Time) error {
+	ret := m.Called(ctx, views, at)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(id uint) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, views map[uint]uint64, at time.Time) error); ok {
+		r0 = rf(ctx, views, at)
 	} else {
 		r0 = ret.Error(0)
 	}
 
 	return r0
 }
+
+func (m *ArticleRepository) FetchRevisions(ctx context.Context, articleID uint, page uint, size uint) ([]*domain.ArticleRevision, uint, error) {
+	ret := m.Called(ctx, articleID, page, size)
+
+	var r0 []*domain.ArticleRevision
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint, page uint, size uint) []*domain.ArticleRevision); ok {
+		r0 = rf(ctx, articleID, page, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ArticleRevision)
+		}
+	}
+
+	var r1 uint
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint, page uint, size uint) uint); ok {
+		r1 = rf(ctx, articleID, page, size)
+	} else {
+		r1 = ret.Get(1).(uint)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, articleID uint, page uint, size uint) error); ok {
+		r2 = rf(ctx, articleID, page, size)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *ArticleRepository) CountRevisions(ctx context.Context, articleID uint) (int64, error) {
+	ret := m.Called(ctx, articleID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint) int64); ok {
+		r0 = rf(ctx, articleID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint) error); ok {
+		r1 = rf(ctx, articleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleRepository) GetRevision(ctx context.Context, articleID uint, revision uint) (*domain.ArticleRevision, error) {
+	ret := m.Called(ctx, articleID, revision)
+
+	var r0 *domain.ArticleRevision
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint, revision uint) *domain.ArticleRevision); ok {
+		r0 = rf(ctx, articleID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ArticleRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint, revision uint) error); ok {
+		r1 = rf(ctx, articleID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -1,20 +1,22 @@
 package mocks
 
 import (
+	"context"
 	"github.com/stretchr/testify/mock"
 	"go-clean-architecture/internal/domain"
+	"time"
 )
 
 type ArticleService struct {
 	mock.Mock
 }
 
-func (m *ArticleService) Fetch(page uint, size uint, filter *domain.Article) ([]*domain.Article, uint, error) {
-	ret := m.Called(page, size, filter)
+func (m *ArticleService) Fetch(ctx context.Context, page uint, size uint, filter *domain.ArticleFilter) ([]*domain.Article, uint, error) {
+	ret := m.Called(ctx, page, size, filter)
 
 	var r0 []*domain.Article
-	if rf, ok := ret.Get(0).(func(page uint, size uint, filter *domain.Article) []*domain.Article); ok {
-		r0 = rf(page, size, filter)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, page uint, size uint, filter *domain.ArticleFilter) []*domain.Article); ok {
+		r0 = rf(ctx, page, size, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Article)
@@ -22,15 +24,15 @@ func (m *ArticleService) Fetch(page uint, size uint, filter *domain.Article) ([]
 	}
 
 	var r1 uint
-	if rf, ok := ret.Get(1).(func(page uint, size uint, filter *domain.Article) uint); ok {
-		r1 = rf(page, size, filter)
+	if rf, ok := ret.Get(1).(func(ctx context.Context, page uint, size uint, filter *domain.ArticleFilter) uint); ok {
+		r1 = rf(ctx, page, size, filter)
 	} else {
 		r1 = ret.Get(1).(uint)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(page uint, size uint, filter *domain.Article) error); ok {
-		r2 = rf(page, size, filter)
+	if rf, ok := ret.Get(2).(func(ctx context.Context, page uint, size uint, filter *domain.ArticleFilter) error); ok {
+		r2 = rf(ctx, page, size, filter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -38,12 +40,78 @@ func (m *ArticleService) Fetch(page uint, size uint, filter *domain.Article) ([]
 	return r0, r1, r2
 }
 
-func (m *ArticleService) GetByID(id uint) (*domain.Article, error) {
-	ret := m.Called(id)
+func (m *ArticleService) FetchByCursor(ctx context.Context, cursor *domain.ArticleCursor, size uint, filter *domain.ArticleFilter) ([]*domain.Article, *domain.ArticleCursor, error) {
+	ret := m.Called(ctx, cursor, size, filter)
+
+	var r0 []*domain.Article
+	if rf, ok := ret.Get(0).(func(ctx context.Context, cursor *domain.ArticleCursor, size uint, filter *domain.ArticleFilter) []*domain.Article); ok {
+		r0 = rf(ctx, cursor, size, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Article)
+		}
+	}
+
+	var r1 *domain.ArticleCursor
+	if rf, ok := ret.Get(1).(func(ctx context.Context, cursor *domain.ArticleCursor, size uint, filter *domain.ArticleFilter) *domain.ArticleCursor); ok {
+		r1 = rf(ctx, cursor, size, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.ArticleCursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, cursor *domain.ArticleCursor, size uint, filter *domain.ArticleFilter) error); ok {
+		r2 = rf(ctx, cursor, size, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *ArticleService) Export(ctx context.Context, filter *domain.ArticleFilter, fn func(articles []*domain.Article) error) error {
+	ret := m.Called(ctx, filter, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, filter *domain.ArticleFilter, fn func(articles []*domain.Article) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ArticleService) GetByID(ctx context.Context, id uint, view *domain.ArticleView) (*domain.Article, error) {
+	ret := m.Called(ctx, id, view)
+
+	var r0 *domain.Article
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint, view *domain.ArticleView) *domain.Article); ok {
+		r0 = rf(ctx, id, view)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Article)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, id uint, view *domain.ArticleView) error); ok {
+		r1 = rf(ctx, id, view)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) GetBySlug(ctx context.Context, slug string, view *domain.ArticleView) (*domain.Article, error) {
+	ret := m.Called(ctx, slug, view)
 
 	var r0 *domain.Article
-	if rf, ok := ret.Get(0).(func(id uint) *domain.Article); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, slug string, view *domain.ArticleView) *domain.Article); ok {
+		r0 = rf(ctx, slug, view)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Article)
@@ -51,8 +119,8 @@ func (m *ArticleService) GetByID(id uint) (*domain.Article, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(id uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(ctx context.Context, slug string, view *domain.ArticleView) error); ok {
+		r1 = rf(ctx, slug, view)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,19 +128,19 @@ func (m *ArticleService) GetByID(id uint) (*domain.Article, error) {
 	return r0, r1
 }
 
-func (m *ArticleService) Count(filter *domain.Article) (int64, error) {
-	ret := m.Called(filter)
+func (m *ArticleService) Count(ctx context.Context, filter *domain.ArticleFilter) (int64, error) {
+	ret := m.Called(ctx, filter)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(filter *domain.Article) int64); ok {
-		r0 = rf(filter)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, filter *domain.ArticleFilter) int64); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(filter *domain.Article) error); ok {
-		r1 = rf(filter)
+	if rf, ok := ret.Get(1).(func(ctx context.Context, filter *domain.ArticleFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -80,12 +148,12 @@ func (m *ArticleService) Count(filter *domain.Article) (int64, error) {
 	return r0, r1
 }
 
-func (m *ArticleService) GetByTitle(title string) ([]*domain.Article, error) {
-	ret := m.Called(title)
+func (m *ArticleService) GetByTitle(ctx context.Context, title string) ([]*domain.Article, error) {
+	ret := m.Called(ctx, title)
 
 	var r0 []*domain.Article
-	if rf, ok := ret.Get(0).(func(title string) []*domain.Article); ok {
-		r0 = rf(title)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, title string) []*domain.Article); ok {
+		r0 = rf(ctx, title)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Article)
@@ -93,8 +161,8 @@ func (m *ArticleService) GetByTitle(title string) ([]*domain.Article, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(title string) error); ok {
-		r1 = rf(title)
+	if rf, ok := ret.Get(1).(func(ctx context.Context, title string) error); ok {
+		r1 = rf(ctx, title)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -102,12 +170,12 @@ func (m *ArticleService) GetByTitle(title string) ([]*domain.Article, error) {
 	return r0, r1
 }
 
-func (m *ArticleService) GetByAuthorID(authorID uint) ([]*domain.Article, error) {
-	ret := m.Called(authorID)
+func (m *ArticleService) GetByAuthorID(ctx context.Context, authorID uint) ([]*domain.Article, error) {
+	ret := m.Called(ctx, authorID)
 
 	var r0 []*domain.Article
-	if rf, ok := ret.Get(0).(func(authorID uint) []*domain.Article); ok {
-		r0 = rf(authorID)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, authorID uint) []*domain.Article); ok {
+		r0 = rf(ctx, authorID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Article)
@@ -115,8 +183,8 @@ func (m *ArticleService) GetByAuthorID(authorID uint) ([]*domain.Article, error)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(authorID uint) error); ok {
-		r1 = rf(authorID)
+	if rf, ok := ret.Get(1).(func(ctx context.Context, authorID uint) error); ok {
+		r1 = rf(ctx, authorID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -124,12 +192,12 @@ func (m *ArticleService) GetByAuthorID(authorID uint) ([]*domain.Article, error)
 	return r0, r1
 }
 
-func (m *ArticleService) Store(article *domain.Article) error {
-	ret := m.Called(article)
+func (m *ArticleService) Store(ctx context.Context, article *domain.Article) error {
+	ret := m.Called(ctx, article)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(article *domain.Article) error); ok {
-		r0 = rf(article)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, article *domain.Article) error); ok {
+		r0 = rf(ctx, article)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -137,12 +205,175 @@ func (m *ArticleService) Store(article *domain.Article) error {
 	return r0
 }
 
-func (m *ArticleService) Update(article *domain.Article) error {
-	ret := m.Called(article)
+func (m *ArticleService) StoreBulk(ctx context.Context, articles []*domain.Article, atomic bool) ([]domain.BulkResult, error) {
+	ret := m.Called(ctx, articles, atomic)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articles []*domain.Article, atomic bool) []domain.BulkResult); ok {
+		r0 = rf(ctx, articles, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articles []*domain.Article, atomic bool) error); ok {
+		r1 = rf(ctx, articles, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) Update(ctx context.Context, article *domain.Article) error {
+	ret := m.Called(ctx, article)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, article *domain.Article) error); ok {
+		r0 = rf(ctx, article)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ArticleService) Delete(ctx context.Context, id uint, version uint) error {
+	ret := m.Called(ctx, id, version)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint, version uint) error); ok {
+		r0 = rf(ctx, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ArticleService) DeleteBulk(ctx context.Context, ids []uint, atomic bool) ([]domain.BulkResult, error) {
+	ret := m.Called(ctx, ids, atomic)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(ctx context.Context, ids []uint, atomic bool) []domain.BulkResult); ok {
+		r0 = rf(ctx, ids, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, ids []uint, atomic bool) error); ok {
+		r1 = rf(ctx, ids, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) ReassignBulk(ctx context.Context, ids []uint, authorID uint, atomic bool) ([]domain.BulkResult, error) {
+	ret := m.Called(ctx, ids, authorID, atomic)
+
+	var r0 []domain.BulkResult
+	if rf, ok := ret.Get(0).(func(ctx context.Context, ids []uint, authorID uint, atomic bool) []domain.BulkResult); ok {
+		r0 = rf(ctx, ids, authorID, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, ids []uint, authorID uint, atomic bool) error); ok {
+		r1 = rf(ctx, ids, authorID, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) FetchTrash(ctx context.Context, page uint, size uint) ([]*domain.Article, uint, error) {
+	ret := m.Called(ctx, page, size)
+
+	var r0 []*domain.Article
+	if rf, ok := ret.Get(0).(func(ctx context.Context, page uint, size uint) []*domain.Article); ok {
+		r0 = rf(ctx, page, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Article)
+		}
+	}
+
+	var r1 uint
+	if rf, ok := ret.Get(1).(func(ctx context.Context, page uint, size uint) uint); ok {
+		r1 = rf(ctx, page, size)
+	} else {
+		r1 = ret.Get(1).(uint)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, page uint, size uint) error); ok {
+		r2 = rf(ctx, page, size)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *ArticleService) CountTrash(ctx context.Context) (int64, error) {
+	ret := m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) Restore(ctx context.Context, id uint) (*domain.Article, error) {
+	ret := m.Called(ctx, id)
+
+	var r0 *domain.Article
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint) *domain.Article); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Article)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, id uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) DeletePermanent(ctx context.Context, id uint, version uint) error {
+	ret := m.Called(ctx, id, version)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(article *domain.Article) error); ok {
-		r0 = rf(article)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint, version uint) error); ok {
+		r0 = rf(ctx, id, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -150,15 +381,241 @@ func (m *ArticleService) Update(article *domain.Article) error {
 	return r0
 }
 
-func (m *ArticleService) Delete(id uint) error {
-	ret := m.Called(id)
+func (m *ArticleService) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	ret := m.Called(ctx, retention)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context, retention time.Duration) int64); ok {
+		r0 = rf(ctx, retention)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, retention time.Duration) error); ok {
+		r1 = rf(ctx, retention)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) Transition(ctx context.Context, id uint, status domain.ArticleStatus) (*domain.Article, error) {
+	ret := m.Called(ctx, id, status)
+
+	var r0 *domain.Article
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint, status domain.ArticleStatus) *domain.Article); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Article)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, id uint, status domain.ArticleStatus) error); ok {
+		r1 = rf(ctx, id, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) Schedule(ctx context.Context, id uint, publishAt *time.Time, unpublishAt *time.Time) (*domain.Article, error) {
+	ret := m.Called(ctx, id, publishAt, unpublishAt)
+
+	var r0 *domain.Article
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint, publishAt *time.Time, unpublishAt *time.Time) *domain.Article); ok {
+		r0 = rf(ctx, id, publishAt, unpublishAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Article)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, id uint, publishAt *time.Time, unpublishAt *time.Time) error); ok {
+		r1 = rf(ctx, id, publishAt, unpublishAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) RunSchedule(ctx context.Context, now time.Time) (int64, int64, error) {
+	ret := m.Called(ctx, now)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context, now time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(ctx context.Context, now time.Time) int64); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, now time.Time) error); ok {
+		r2 = rf(ctx, now)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *ArticleService) RecordView(ctx context.Context, id uint, visitor string) error {
+	ret := m.Called(ctx, id, visitor)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(id uint) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint, visitor string) error); ok {
+		r0 = rf(ctx, id, visitor)
 	} else {
 		r0 = ret.Error(0)
 	}
 
 	return r0
 }
+
+func (m *ArticleService) FlushViews(ctx context.Context) (int64, error) {
+	ret := m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) FetchRevisions(ctx context.Context, articleID uint, page uint, size uint) ([]*domain.ArticleRevision, uint, error) {
+	ret := m.Called(ctx, articleID, page, size)
+
+	var r0 []*domain.ArticleRevision
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint, page uint, size uint) []*domain.ArticleRevision); ok {
+		r0 = rf(ctx, articleID, page, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ArticleRevision)
+		}
+	}
+
+	var r1 uint
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint, page uint, size uint) uint); ok {
+		r1 = rf(ctx, articleID, page, size)
+	} else {
+		r1 = ret.Get(1).(uint)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, articleID uint, page uint, size uint) error); ok {
+		r2 = rf(ctx, articleID, page, size)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *ArticleService) CountRevisions(ctx context.Context, articleID uint) (int64, error) {
+	ret := m.Called(ctx, articleID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint) int64); ok {
+		r0 = rf(ctx, articleID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint) error); ok {
+		r1 = rf(ctx, articleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) GetRevision(ctx context.Context, articleID uint, revision uint) (*domain.ArticleRevision, error) {
+	ret := m.Called(ctx, articleID, revision)
+
+	var r0 *domain.ArticleRevision
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint, revision uint) *domain.ArticleRevision); ok {
+		r0 = rf(ctx, articleID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ArticleRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint, revision uint) error); ok {
+		r1 = rf(ctx, articleID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) DiffRevisions(ctx context.Context, articleID uint, from uint, to uint) (*domain.ArticleRevisionDiff, error) {
+	ret := m.Called(ctx, articleID, from, to)
+
+	var r0 *domain.ArticleRevisionDiff
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint, from uint, to uint) *domain.ArticleRevisionDiff); ok {
+		r0 = rf(ctx, articleID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ArticleRevisionDiff)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint, from uint, to uint) error); ok {
+		r1 = rf(ctx, articleID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ArticleService) Revert(ctx context.Context, articleID uint, revision uint) (*domain.Article, error) {
+	ret := m.Called(ctx, articleID, revision)
+
+	var r0 *domain.Article
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint, revision uint) *domain.Article); ok {
+		r0 = rf(ctx, articleID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Article)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint, revision uint) error); ok {
+		r1 = rf(ctx, articleID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,160 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+)
+
+type AttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *AttachmentRepository) FetchByArticle(ctx context.Context, articleID uint) ([]*domain.Attachment, error) {
+	ret := m.Called(ctx, articleID)
+
+	var r0 []*domain.Attachment
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint) []*domain.Attachment); ok {
+		r0 = rf(ctx, articleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Attachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint) error); ok {
+		r1 = rf(ctx, articleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttachmentRepository) FetchOrphans(ctx context.Context, size uint) ([]*domain.Attachment, error) {
+	ret := m.Called(ctx, size)
+
+	var r0 []*domain.Attachment
+	if rf, ok := ret.Get(0).(func(ctx context.Context, size uint) []*domain.Attachment); ok {
+		r0 = rf(ctx, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Attachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, size uint) error); ok {
+		r1 = rf(ctx, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttachmentRepository) GetByID(ctx context.Context, id uint) (*domain.Attachment, error) {
+	ret := m.Called(ctx, id)
+
+	var r0 *domain.Attachment
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint) *domain.Attachment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, id uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttachmentRepository) GetByChecksum(ctx context.Context, articleID uint, checksum string) (*domain.Attachment, error) {
+	ret := m.Called(ctx, articleID, checksum)
+
+	var r0 *domain.Attachment
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint, checksum string) *domain.Attachment); ok {
+		r0 = rf(ctx, articleID, checksum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint, checksum string) error); ok {
+		r1 = rf(ctx, articleID, checksum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttachmentRepository) UnusedChecksums(ctx context.Context, checksums []string) ([]string, error) {
+	ret := m.Called(ctx, checksums)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(ctx context.Context, checksums []string) []string); ok {
+		r0 = rf(ctx, checksums)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, checksums []string) error); ok {
+		r1 = rf(ctx, checksums)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttachmentRepository) Store(ctx context.Context, attachment *domain.Attachment, checkBlob func() error) error {
+	ret := m.Called(ctx, attachment, checkBlob)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, attachment *domain.Attachment, checkBlob func() error) error); ok {
+		r0 = rf(ctx, attachment, checkBlob)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *AttachmentRepository) Delete(ctx context.Context, attachment *domain.Attachment, deleteBlob func() error) error {
+	ret := m.Called(ctx, attachment, deleteBlob)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, attachment *domain.Attachment, deleteBlob func() error) error); ok {
+		r0 = rf(ctx, attachment, deleteBlob)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *AttachmentRepository) DeleteBlob(ctx context.Context, checksum string, deleteBlob func() error) error {
+	ret := m.Called(ctx, checksum, deleteBlob)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, checksum string, deleteBlob func() error) error); ok {
+		r0 = rf(ctx, checksum, deleteBlob)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
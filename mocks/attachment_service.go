@@ -0,0 +1,149 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+	"io"
+)
+
+type AttachmentService struct {
+	mock.Mock
+}
+
+func (m *AttachmentService) Fetch(ctx context.Context, articleID uint) ([]*domain.Attachment, error) {
+	ret := m.Called(ctx, articleID)
+
+	var r0 []*domain.Attachment
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint) []*domain.Attachment); ok {
+		r0 = rf(ctx, articleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Attachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint) error); ok {
+		r1 = rf(ctx, articleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttachmentService) Store(ctx context.Context, attachment *domain.Attachment, body io.ReadSeeker) (bool, error) {
+	ret := m.Called(ctx, attachment, body)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(ctx context.Context, attachment *domain.Attachment, body io.ReadSeeker) bool); ok {
+		r0 = rf(ctx, attachment, body)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, attachment *domain.Attachment, body io.ReadSeeker) error); ok {
+		r1 = rf(ctx, attachment, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttachmentService) Open(ctx context.Context, id uint) (*domain.Attachment, io.ReadCloser, error) {
+	ret := m.Called(ctx, id)
+
+	var r0 *domain.Attachment
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint) *domain.Attachment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	var r1 io.ReadCloser
+	if rf, ok := ret.Get(1).(func(ctx context.Context, id uint) io.ReadCloser); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, id uint) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *AttachmentService) OpenVariant(ctx context.Context, id uint, name string) (*domain.AttachmentVariant, io.ReadCloser, error) {
+	ret := m.Called(ctx, id, name)
+
+	var r0 *domain.AttachmentVariant
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint, name string) *domain.AttachmentVariant); ok {
+		r0 = rf(ctx, id, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AttachmentVariant)
+		}
+	}
+
+	var r1 io.ReadCloser
+	if rf, ok := ret.Get(1).(func(ctx context.Context, id uint, name string) io.ReadCloser); ok {
+		r1 = rf(ctx, id, name)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, id uint, name string) error); ok {
+		r2 = rf(ctx, id, name)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *AttachmentService) Delete(ctx context.Context, articleID uint, id uint) error {
+	ret := m.Called(ctx, articleID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint, id uint) error); ok {
+		r0 = rf(ctx, articleID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *AttachmentService) Cleanup(ctx context.Context) (int64, error) {
+	ret := m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"context"
 	"github.com/stretchr/testify/mock"
 	"go-clean-architecture/internal/domain"
 )
@@ -9,12 +10,41 @@ type AuthorRepository struct {
 	mock.Mock
 }
 
-func (m *AuthorRepository) GetByID(id uint) (*domain.Author, error) {
-	ret := m.Called(id)
+func (m *AuthorRepository) Fetch(ctx context.Context, page uint, size uint, filter *domain.Author) ([]*domain.Author, uint, error) {
+	ret := m.Called(ctx, page, size, filter)
+
+	var r0 []*domain.Author
+	if rf, ok := ret.Get(0).(func(ctx context.Context, page uint, size uint, filter *domain.Author) []*domain.Author); ok {
+		r0 = rf(ctx, page, size, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Author)
+		}
+	}
+
+	var r1 uint
+	if rf, ok := ret.Get(1).(func(ctx context.Context, page uint, size uint, filter *domain.Author) uint); ok {
+		r1 = rf(ctx, page, size, filter)
+	} else {
+		r1 = ret.Get(1).(uint)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, page uint, size uint, filter *domain.Author) error); ok {
+		r2 = rf(ctx, page, size, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *AuthorRepository) GetByID(ctx context.Context, id uint) (*domain.Author, error) {
+	ret := m.Called(ctx, id)
 
 	var r0 *domain.Author
-	if rf, ok := ret.Get(0).(func(uint) *domain.Author); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint) *domain.Author); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Author)
@@ -22,8 +52,8 @@ func (m *AuthorRepository) GetByID(id uint) (*domain.Author, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(ctx context.Context, id uint) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -31,19 +61,19 @@ func (m *AuthorRepository) GetByID(id uint) (*domain.Author, error) {
 	return r0, r1
 }
 
-func (m *AuthorRepository) Count() (int64, error) {
-	ret := m.Called()
+func (m *AuthorRepository) Count(ctx context.Context, filter *domain.Author) (int64, error) {
+	ret := m.Called(ctx, filter)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(ctx context.Context, filter *domain.Author) int64); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(ctx context.Context, filter *domain.Author) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,12 +81,38 @@ func (m *AuthorRepository) Count() (int64, error) {
 	return r0, r1
 }
 
-func (m *AuthorRepository) Store(author *domain.Author) error {
-	ret := m.Called(author)
+func (m *AuthorRepository) Store(ctx context.Context, author *domain.Author) error {
+	ret := m.Called(ctx, author)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, author *domain.Author) error); ok {
+		r0 = rf(ctx, author)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *AuthorRepository) Update(ctx context.Context, author *domain.Author) error {
+	ret := m.Called(ctx, author)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, author *domain.Author) error); ok {
+		r0 = rf(ctx, author)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *AuthorRepository) Delete(ctx context.Context, id uint) error {
+	ret := m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*domain.Author) error); ok {
-		r0 = rf(author)
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
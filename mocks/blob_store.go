@@ -0,0 +1,106 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+	"io"
+)
+
+type BlobStore struct {
+	mock.Mock
+}
+
+func (m *BlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	ret := m.Called(ctx, key, body, size, contentType)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, key string, body io.Reader, size int64, contentType string) error); ok {
+		r0 = rf(ctx, key, body, size, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	ret := m.Called(ctx, key)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(ctx context.Context, key string) io.ReadCloser); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, key string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *BlobStore) Exists(ctx context.Context, key string) (bool, error) {
+	ret := m.Called(ctx, key)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(ctx context.Context, key string) bool); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, key string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *BlobStore) Delete(ctx context.Context, key string) error {
+	ret := m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, key string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *BlobStore) DeleteDir(ctx context.Context, dir string) error {
+	ret := m.Called(ctx, dir)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, dir string) error); ok {
+		r0 = rf(ctx, dir)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *BlobStore) List(ctx context.Context, prefix string, fn func(blobs []*domain.BlobInfo) error) error {
+	ret := m.Called(ctx, prefix, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, prefix string, fn func(blobs []*domain.BlobInfo) error) error); ok {
+		r0 = rf(ctx, prefix, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,145 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+)
+
+type CommentRepository struct {
+	mock.Mock
+}
+
+func (m *CommentRepository) Fetch(ctx context.Context, filter *domain.CommentFilter, cursor *domain.CommentCursor, size uint) ([]*domain.Comment, *domain.CommentCursor, error) {
+	ret := m.Called(ctx, filter, cursor, size)
+
+	var r0 []*domain.Comment
+	if rf, ok := ret.Get(0).(func(ctx context.Context, filter *domain.CommentFilter, cursor *domain.CommentCursor, size uint) []*domain.Comment); ok {
+		r0 = rf(ctx, filter, cursor, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Comment)
+		}
+	}
+
+	var r1 *domain.CommentCursor
+	if rf, ok := ret.Get(1).(func(ctx context.Context, filter *domain.CommentFilter, cursor *domain.CommentCursor, size uint) *domain.CommentCursor); ok {
+		r1 = rf(ctx, filter, cursor, size)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.CommentCursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, filter *domain.CommentFilter, cursor *domain.CommentCursor, size uint) error); ok {
+		r2 = rf(ctx, filter, cursor, size)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *CommentRepository) FetchReplies(ctx context.Context, rootIDs []uint, status domain.CommentStatus) ([]*domain.Comment, error) {
+	ret := m.Called(ctx, rootIDs, status)
+
+	var r0 []*domain.Comment
+	if rf, ok := ret.Get(0).(func(ctx context.Context, rootIDs []uint, status domain.CommentStatus) []*domain.Comment); ok {
+		r0 = rf(ctx, rootIDs, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Comment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, rootIDs []uint, status domain.CommentStatus) error); ok {
+		r1 = rf(ctx, rootIDs, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *CommentRepository) GetByID(ctx context.Context, id uint) (*domain.Comment, error) {
+	ret := m.Called(ctx, id)
+
+	var r0 *domain.Comment
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint) *domain.Comment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Comment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, id uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *CommentRepository) CountApproved(ctx context.Context, email string) (int64, error) {
+	ret := m.Called(ctx, email)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context, email string) int64); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, email string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *CommentRepository) Store(ctx context.Context, comment *domain.Comment) error {
+	ret := m.Called(ctx, comment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, comment *domain.Comment) error); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *CommentRepository) UpdateStatus(ctx context.Context, id uint, status domain.CommentStatus) error {
+	ret := m.Called(ctx, id, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint, status domain.CommentStatus) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *CommentRepository) DeleteByArticles(ctx context.Context, articleIDs []uint) error {
+	ret := m.Called(ctx, articleIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleIDs []uint) error); ok {
+		r0 = rf(ctx, articleIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,108 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+)
+
+type CommentService struct {
+	mock.Mock
+}
+
+func (m *CommentService) FetchThreads(ctx context.Context, articleID uint, cursor *domain.CommentCursor, size uint) ([]*domain.Comment, *domain.CommentCursor, error) {
+	ret := m.Called(ctx, articleID, cursor, size)
+
+	var r0 []*domain.Comment
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint, cursor *domain.CommentCursor, size uint) []*domain.Comment); ok {
+		r0 = rf(ctx, articleID, cursor, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Comment)
+		}
+	}
+
+	var r1 *domain.CommentCursor
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint, cursor *domain.CommentCursor, size uint) *domain.CommentCursor); ok {
+		r1 = rf(ctx, articleID, cursor, size)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.CommentCursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, articleID uint, cursor *domain.CommentCursor, size uint) error); ok {
+		r2 = rf(ctx, articleID, cursor, size)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *CommentService) Fetch(ctx context.Context, filter *domain.CommentFilter, cursor *domain.CommentCursor, size uint) ([]*domain.Comment, *domain.CommentCursor, error) {
+	ret := m.Called(ctx, filter, cursor, size)
+
+	var r0 []*domain.Comment
+	if rf, ok := ret.Get(0).(func(ctx context.Context, filter *domain.CommentFilter, cursor *domain.CommentCursor, size uint) []*domain.Comment); ok {
+		r0 = rf(ctx, filter, cursor, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Comment)
+		}
+	}
+
+	var r1 *domain.CommentCursor
+	if rf, ok := ret.Get(1).(func(ctx context.Context, filter *domain.CommentFilter, cursor *domain.CommentCursor, size uint) *domain.CommentCursor); ok {
+		r1 = rf(ctx, filter, cursor, size)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.CommentCursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(ctx context.Context, filter *domain.CommentFilter, cursor *domain.CommentCursor, size uint) error); ok {
+		r2 = rf(ctx, filter, cursor, size)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+func (m *CommentService) Store(ctx context.Context, comment *domain.Comment) error {
+	ret := m.Called(ctx, comment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, comment *domain.Comment) error); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *CommentService) Moderate(ctx context.Context, id uint, status domain.CommentStatus) (*domain.Comment, error) {
+	ret := m.Called(ctx, id, status)
+
+	var r0 *domain.Comment
+	if rf, ok := ret.Get(0).(func(ctx context.Context, id uint, status domain.CommentStatus) *domain.Comment); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Comment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, id uint, status domain.CommentStatus) error); ok {
+		r1 = rf(ctx, id, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type JobLockRepository struct {
+	mock.Mock
+}
+
+func (m *JobLockRepository) Acquire(ctx context.Context, name string, holder string, until time.Time) (bool, error) {
+	ret := m.Called(ctx, name, holder, until)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(ctx context.Context, name string, holder string, until time.Time) bool); ok {
+		r0 = rf(ctx, name, holder, until)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, name string, holder string, until time.Time) error); ok {
+		r1 = rf(ctx, name, holder, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *JobLockRepository) Release(ctx context.Context, name string, holder string) error {
+	ret := m.Called(ctx, name, holder)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, name string, holder string) error); ok {
+		r0 = rf(ctx, name, holder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,102 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+	"time"
+)
+
+type RankingRepository struct {
+	mock.Mock
+}
+
+func (m *RankingRepository) FetchEngagement(ctx context.Context, since time.Time) ([]*domain.ArticleEngagement, error) {
+	ret := m.Called(ctx, since)
+
+	var r0 []*domain.ArticleEngagement
+	if rf, ok := ret.Get(0).(func(ctx context.Context, since time.Time) []*domain.ArticleEngagement); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ArticleEngagement)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, since time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *RankingRepository) Replace(ctx context.Context, window domain.RankingWindow, rankings []*domain.ArticleRanking) error {
+	ret := m.Called(ctx, window, rankings)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, window domain.RankingWindow, rankings []*domain.ArticleRanking) error); ok {
+		r0 = rf(ctx, window, rankings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *RankingRepository) Fetch(ctx context.Context, window domain.RankingWindow, authorID uint, size uint) ([]*domain.ArticleRanking, error) {
+	ret := m.Called(ctx, window, authorID, size)
+
+	var r0 []*domain.ArticleRanking
+	if rf, ok := ret.Get(0).(func(ctx context.Context, window domain.RankingWindow, authorID uint, size uint) []*domain.ArticleRanking); ok {
+		r0 = rf(ctx, window, authorID, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ArticleRanking)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, window domain.RankingWindow, authorID uint, size uint) error); ok {
+		r1 = rf(ctx, window, authorID, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *RankingRepository) PruneViews(ctx context.Context, before time.Time) (int64, error) {
+	ret := m.Called(ctx, before)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context, before time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, before time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *RankingRepository) DeleteByArticles(ctx context.Context, articleIDs []uint) error {
+	ret := m.Called(ctx, articleIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleIDs []uint) error); ok {
+		r0 = rf(ctx, articleIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,76 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+	"time"
+)
+
+type RankingService struct {
+	mock.Mock
+}
+
+func (m *RankingService) Trending(ctx context.Context, window domain.RankingWindow, size uint) ([]*domain.ArticleRanking, error) {
+	ret := m.Called(ctx, window, size)
+
+	var r0 []*domain.ArticleRanking
+	if rf, ok := ret.Get(0).(func(ctx context.Context, window domain.RankingWindow, size uint) []*domain.ArticleRanking); ok {
+		r0 = rf(ctx, window, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ArticleRanking)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, window domain.RankingWindow, size uint) error); ok {
+		r1 = rf(ctx, window, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *RankingService) TopByAuthor(ctx context.Context, authorID uint, window domain.RankingWindow, size uint) ([]*domain.ArticleRanking, error) {
+	ret := m.Called(ctx, authorID, window, size)
+
+	var r0 []*domain.ArticleRanking
+	if rf, ok := ret.Get(0).(func(ctx context.Context, authorID uint, window domain.RankingWindow, size uint) []*domain.ArticleRanking); ok {
+		r0 = rf(ctx, authorID, window, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ArticleRanking)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, authorID uint, window domain.RankingWindow, size uint) error); ok {
+		r1 = rf(ctx, authorID, window, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *RankingService) Compute(ctx context.Context, now time.Time) (int64, error) {
+	ret := m.Called(ctx, now)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(ctx context.Context, now time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, now time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
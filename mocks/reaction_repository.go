@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+)
+
+type ReactionRepository struct {
+	mock.Mock
+}
+
+func (m *ReactionRepository) Count(ctx context.Context, articleID uint) ([]*domain.ReactionCount, error) {
+	ret := m.Called(ctx, articleID)
+
+	var r0 []*domain.ReactionCount
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint) []*domain.ReactionCount); ok {
+		r0 = rf(ctx, articleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ReactionCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint) error); ok {
+		r1 = rf(ctx, articleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ReactionRepository) Store(ctx context.Context, reaction *domain.Reaction) error {
+	ret := m.Called(ctx, reaction)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, reaction *domain.Reaction) error); ok {
+		r0 = rf(ctx, reaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ReactionRepository) Delete(ctx context.Context, articleID uint, visitor string, kind domain.ReactionKind) error {
+	ret := m.Called(ctx, articleID, visitor, kind)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint, visitor string, kind domain.ReactionKind) error); ok {
+		r0 = rf(ctx, articleID, visitor, kind)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *ReactionRepository) DeleteByArticles(ctx context.Context, articleIDs []uint) error {
+	ret := m.Called(ctx, articleIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleIDs []uint) error); ok {
+		r0 = rf(ctx, articleIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,77 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+)
+
+type ReactionService struct {
+	mock.Mock
+}
+
+func (m *ReactionService) Count(ctx context.Context, articleID uint) ([]*domain.ReactionCount, error) {
+	ret := m.Called(ctx, articleID)
+
+	var r0 []*domain.ReactionCount
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint) []*domain.ReactionCount); ok {
+		r0 = rf(ctx, articleID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ReactionCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint) error); ok {
+		r1 = rf(ctx, articleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ReactionService) React(ctx context.Context, articleID uint, visitor string, kind domain.ReactionKind) ([]*domain.ReactionCount, error) {
+	ret := m.Called(ctx, articleID, visitor, kind)
+
+	var r0 []*domain.ReactionCount
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint, visitor string, kind domain.ReactionKind) []*domain.ReactionCount); ok {
+		r0 = rf(ctx, articleID, visitor, kind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ReactionCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint, visitor string, kind domain.ReactionKind) error); ok {
+		r1 = rf(ctx, articleID, visitor, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *ReactionService) Unreact(ctx context.Context, articleID uint, visitor string, kind domain.ReactionKind) ([]*domain.ReactionCount, error) {
+	ret := m.Called(ctx, articleID, visitor, kind)
+
+	var r0 []*domain.ReactionCount
+	if rf, ok := ret.Get(0).(func(ctx context.Context, articleID uint, visitor string, kind domain.ReactionKind) []*domain.ReactionCount); ok {
+		r0 = rf(ctx, articleID, visitor, kind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ReactionCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, articleID uint, visitor string, kind domain.ReactionKind) error); ok {
+		r1 = rf(ctx, articleID, visitor, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+)
+
+type TagRepository struct {
+	mock.Mock
+}
+
+func (m *TagRepository) FetchUsage(ctx context.Context, status domain.ArticleStatus) ([]*domain.TagUsage, error) {
+	ret := m.Called(ctx, status)
+
+	var r0 []*domain.TagUsage
+	if rf, ok := ret.Get(0).(func(ctx context.Context, status domain.ArticleStatus) []*domain.TagUsage); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.TagUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, status domain.ArticleStatus) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *TagRepository) FirstOrCreate(ctx context.Context, names []string) ([]*domain.Tag, error) {
+	ret := m.Called(ctx, names)
+
+	var r0 []*domain.Tag
+	if rf, ok := ret.Get(0).(func(ctx context.Context, names []string) []*domain.Tag); ok {
+		r0 = rf(ctx, names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Tag)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, names []string) error); ok {
+		r1 = rf(ctx, names)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
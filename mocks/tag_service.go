@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"go-clean-architecture/internal/domain"
+)
+
+type TagService struct {
+	mock.Mock
+}
+
+func (m *TagService) FetchUsage(ctx context.Context, status domain.ArticleStatus) ([]*domain.TagUsage, error) {
+	ret := m.Called(ctx, status)
+
+	var r0 []*domain.TagUsage
+	if rf, ok := ret.Get(0).(func(ctx context.Context, status domain.ArticleStatus) []*domain.TagUsage); ok {
+		r0 = rf(ctx, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.TagUsage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(ctx context.Context, status domain.ArticleStatus) error); ok {
+		r1 = rf(ctx, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
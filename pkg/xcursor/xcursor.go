@@ -0,0 +1,61 @@
+package xcursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Signer encodes arbitrary values into opaque, tamper-proof tokens. A token
+// is the base64url encoded JSON payload followed by its HMAC-SHA256
+// signature, separated by a dot.
+type Signer struct {
+	secret []byte
+}
+
+func New(secret []byte) *Signer {
+	return &Signer{secret: secret}
+}
+
+func (s *Signer) Encode(v any) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), nil
+}
+
+func (s *Signer) Decode(token string, v any) error {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalidCursor
+	}
+
+	expected, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, s.sign(encoded)) {
+		return ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(payload, v); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func (s *Signer) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,72 @@
+package xcursor
+
+import (
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+type payload struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uint      `json:"id"`
+}
+
+func TestSigner_EncodeDecode(t *testing.T) {
+	signer := New([]byte("secret"))
+	want := payload{CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC), ID: 42}
+
+	token, err := signer.Encode(want)
+	assert.NoError(t, err)
+
+	var got payload
+	assert.NoError(t, signer.Decode(token, &got))
+	assert.True(t, want.CreatedAt.Equal(got.CreatedAt))
+	assert.Equal(t, want.ID, got.ID)
+}
+
+func TestSigner_Encode_Error(t *testing.T) {
+	signer := New([]byte("secret"))
+	_, err := signer.Encode(make(chan int))
+	assert.Error(t, err)
+}
+
+func TestSigner_Decode_Tampered(t *testing.T) {
+	signer := New([]byte("secret"))
+	token, err := signer.Encode(payload{ID: 1})
+	assert.NoError(t, err)
+
+	other, err := signer.Encode(payload{ID: 2})
+	assert.NoError(t, err)
+
+	forged := strings.Split(other, ".")[0] + "." + strings.Split(token, ".")[1]
+	var got payload
+	assert.ErrorIs(t, signer.Decode(forged, &got), ErrInvalidCursor)
+}
+
+func TestSigner_Decode_WrongSecret(t *testing.T) {
+	token, err := New([]byte("secret")).Encode(payload{ID: 1})
+	assert.NoError(t, err)
+
+	var got payload
+	assert.ErrorIs(t, New([]byte("other")).Decode(token, &got), ErrInvalidCursor)
+}
+
+func TestSigner_Decode_Malformed(t *testing.T) {
+	signer := New([]byte("secret"))
+	var got payload
+
+	assert.ErrorIs(t, signer.Decode("no-separator", &got), ErrInvalidCursor)
+	assert.ErrorIs(t, signer.Decode("abc.!!!", &got), ErrInvalidCursor)
+
+	notBase64 := "!!!"
+	assert.ErrorIs(t, signer.Decode(notBase64+"."+signatureOf(signer, notBase64), &got), ErrInvalidCursor)
+
+	notJSON := "bm90LWpzb24"
+	assert.ErrorIs(t, signer.Decode(notJSON+"."+signatureOf(signer, notJSON), &got), ErrInvalidCursor)
+}
+
+func signatureOf(s *Signer, encoded string) string {
+	return base64.RawURLEncoding.EncodeToString(s.sign(encoded))
+}
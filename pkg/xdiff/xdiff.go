@@ -0,0 +1,176 @@
+package xdiff
+
+import (
+	"strings"
+	"unicode"
+)
+
+type Operation string
+
+const (
+	Equal  Operation = "equal"
+	Insert Operation = "insert"
+	Delete Operation = "delete"
+)
+
+// Change is a run of text that is kept, inserted or deleted. Joining the
+// equal and deleted runs gives the old text, joining the equal and inserted
+// runs gives the new one.
+type Change struct {
+	Operation Operation `json:"op" enums:"equal,insert,delete"`
+	Text      string    `json:"text"`
+}
+
+// Words returns the changes from a to b word by word. Runs of whitespace are
+// compared as words of their own, so a change in spacing does not mark the
+// surrounding words as changed. It uses Myers' algorithm, so the result is a
+// shortest edit script, equivalent to the longest common subsequence.
+func Words(a string, b string) []Change {
+	return diff(tokenize(a), tokenize(b))
+}
+
+// tokenize splits s into alternating runs of whitespace and non-whitespace.
+func tokenize(s string) []string {
+	var tokens []string
+	start, space := 0, false
+	for i, r := range s {
+		if i > start && unicode.IsSpace(r) != space {
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+		space = unicode.IsSpace(r)
+	}
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
+
+func diff(a []string, b []string) []Change {
+	// a common prefix and suffix do not need the quadratic search
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var changes []Change
+	changes = appendChange(changes, Equal, a[:prefix]...)
+	for _, change := range myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		changes = appendChange(changes, change.Operation, change.Text)
+	}
+	changes = appendChange(changes, Equal, a[len(a)-suffix:]...)
+
+	return changes
+}
+
+// myers returns one change per token. trace[d][k+d] holds the furthest x
+// reached on diagonal k = x - y with d edits, or -1 when the diagonal cannot
+// be reached inside the edit graph.
+func myers(a []string, b []string) []Change {
+	n, m := len(a), len(b)
+	var trace [][]int
+
+	for d := 0; d <= n+m; d++ {
+		v := make([]int, 2*d+1)
+		for k := -d; k <= d; k += 2 {
+			x := 0
+			if d > 0 {
+				var ok bool
+				if x, _, ok = step(trace[d-1], d, k, n, m); !ok {
+					v[k+d] = -1
+					continue
+				}
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+d] = x
+
+			if x == n && y == m {
+				trace = append(trace, v)
+				return backtrack(a, b, trace)
+			}
+		}
+		trace = append(trace, v)
+	}
+
+	return nil
+}
+
+// step picks the move onto diagonal k in round d: down from diagonal k+1
+// (an insertion) or right from diagonal k-1 (a deletion), whichever reaches
+// further.
+func step(prev []int, d int, k int, n int, m int) (x int, down bool, ok bool) {
+	downX, rightX := -1, -1
+	if k+1 <= d-1 && prev[k+1+d-1] >= 0 && prev[k+1+d-1]-k <= m {
+		downX = prev[k+1+d-1]
+	}
+	if k-1 >= -(d-1) && prev[k-1+d-1] >= 0 && prev[k-1+d-1]+1 <= n {
+		rightX = prev[k-1+d-1] + 1
+	}
+
+	switch {
+	case downX < 0 && rightX < 0:
+		return 0, false, false
+	case downX >= rightX:
+		return downX, true, true
+	default:
+		return rightX, false, true
+	}
+}
+
+func backtrack(a []string, b []string, trace [][]int) []Change {
+	var reversed []Change
+	x, y := len(a), len(b)
+
+	for d := len(trace) - 1; d > 0; d-- {
+		k := x - y
+		startX, down, _ := step(trace[d-1], d, k, len(a), len(b))
+		startY := startX - k
+
+		for x > startX && y > startY {
+			reversed = append(reversed, Change{Operation: Equal, Text: a[x-1]})
+			x--
+			y--
+		}
+		if down {
+			reversed = append(reversed, Change{Operation: Insert, Text: b[y-1]})
+			y--
+		} else {
+			reversed = append(reversed, Change{Operation: Delete, Text: a[x-1]})
+			x--
+		}
+	}
+	for x > 0 {
+		reversed = append(reversed, Change{Operation: Equal, Text: a[x-1]})
+		x--
+	}
+
+	changes := make([]Change, 0, len(reversed))
+	for i := len(reversed) - 1; i >= 0; i-- {
+		changes = append(changes, reversed[i])
+	}
+	return changes
+}
+
+// appendChange adds tokens to changes, merging them into the last change
+// when it has the same operation.
+func appendChange(changes []Change, operation Operation, tokens ...string) []Change {
+	if len(tokens) == 0 {
+		return changes
+	}
+
+	text := strings.Join(tokens, "")
+	if last := len(changes) - 1; last >= 0 && changes[last].Operation == operation {
+		changes[last].Text += text
+		return changes
+	}
+	return append(changes, Change{Operation: operation, Text: text})
+}
@@ -0,0 +1,127 @@
+package xdiff
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected []Change
+	}{
+		{
+			name:     "equal",
+			a:        "the quick fox",
+			b:        "the quick fox",
+			expected: []Change{{Equal, "the quick fox"}},
+		},
+		{
+			name:     "replace word",
+			a:        "the quick brown fox",
+			b:        "the slow brown fox",
+			expected: []Change{{Equal, "the "}, {Delete, "quick"}, {Insert, "slow"}, {Equal, " brown fox"}},
+		},
+		{
+			name:     "insert words",
+			a:        "hello world",
+			b:        "hello big wide world",
+			expected: []Change{{Equal, "hello "}, {Insert, "big wide "}, {Equal, "world"}},
+		},
+		{
+			name:     "delete everything",
+			a:        "gone",
+			b:        "",
+			expected: []Change{{Delete, "gone"}},
+		},
+		{
+			name:     "from empty",
+			a:        "",
+			b:        "new  text",
+			expected: []Change{{Insert, "new  text"}},
+		},
+		{
+			name:     "both empty",
+			a:        "",
+			b:        "",
+			expected: nil,
+		},
+		{
+			name:     "whitespace only",
+			a:        "one two",
+			b:        "one\ntwo",
+			expected: []Change{{Equal, "one"}, {Delete, " "}, {Insert, "\n"}, {Equal, "two"}},
+		},
+		{
+			name:     "unicode spaces",
+			a:        "a b",
+			b:        "a c",
+			expected: []Change{{Equal, "a "}, {Delete, "b"}, {Insert, "c"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Words(tt.a, tt.b))
+		})
+	}
+}
+
+func TestWords_ShortestScript(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	words := []string{"a", "b", "c", " ", "  "}
+	text := func() string {
+		var sb strings.Builder
+		for i := random.Intn(30); i > 0; i-- {
+			sb.WriteString(words[random.Intn(len(words))])
+		}
+		return sb.String()
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := text(), text()
+		changes := Words(a, b)
+
+		var old, new strings.Builder
+		edits := 0
+		for _, change := range changes {
+			switch change.Operation {
+			case Equal:
+				old.WriteString(change.Text)
+				new.WriteString(change.Text)
+			case Delete:
+				old.WriteString(change.Text)
+				edits += len(tokenize(change.Text))
+			case Insert:
+				new.WriteString(change.Text)
+				edits += len(tokenize(change.Text))
+			}
+		}
+		assert.Equal(t, a, old.String())
+		assert.Equal(t, b, new.String())
+
+		ta, tb := tokenize(a), tokenize(b)
+		assert.LessOrEqual(t, edits, len(ta)+len(tb)-2*lcs(ta, tb), "%q -> %q", a, b)
+	}
+}
+
+func lcs(a []string, b []string) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				dp[i][j] = dp[i-1][j-1] + 1
+			} else {
+				dp[i][j] = max(dp[i-1][j], dp[i][j-1])
+			}
+		}
+	}
+	return dp[len(a)][len(b)]
+}
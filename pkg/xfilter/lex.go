@@ -0,0 +1,99 @@
+package xfilter
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+// is reports whether the token is the keyword, ignoring case.
+func (t token) is(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func (t token) keyword() bool {
+	return t.is("and") || t.is("or") || t.is("not") || t.is("null")
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of filter"
+	case tokenString:
+		return fmt.Sprintf("string %q", t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// lex splits a filter into tokens, ending with a tokenEOF. A word is any run
+// of characters other than white space, parentheses, commas and quotes.
+func lex(filter string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(filter); {
+		switch c := filter[i]; c {
+		case ' ', '\t', '\n', '\r':
+			i++
+		case '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", offset: i})
+			i++
+		case ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", offset: i})
+			i++
+		case ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", offset: i})
+			i++
+		case '"':
+			text, end, err := lexString(filter, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, offset: i})
+			i = end
+		default:
+			start := i
+			for i < len(filter) && !strings.ContainsRune(" \t\n\r(),\"", rune(filter[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: filter[start:i], offset: start})
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, offset: len(filter)}), nil
+}
+
+// lexString reads the quoted string starting at start and returns its text
+// and the offset after the closing quote.
+func lexString(filter string, start int) (string, int, error) {
+	var b strings.Builder
+	for i := start + 1; i < len(filter); i++ {
+		switch c := filter[i]; c {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 < len(filter) && (filter[i+1] == '"' || filter[i+1] == '\\') {
+				i++
+				b.WriteByte(filter[i])
+				continue
+			}
+			return "", 0, errorf(i, `invalid escape in string, only \" and \\ are allowed`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, errorf(start, "string is not closed")
+}
@@ -0,0 +1,169 @@
+package xfilter
+
+import (
+	"gorm.io/gorm/clause"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type is the type of the values a field is compared with.
+type Type int
+
+const (
+	// TypeString fields are compared with quoted strings.
+	TypeString Type = iota
+	// TypeInteger fields are compared with unquoted integers.
+	TypeInteger
+	// TypeTime fields are compared with a date such as 2024-01-01, which is
+	// midnight UTC, or an RFC 3339 time. Either may be quoted.
+	TypeTime
+)
+
+// Field is a field that filters may refer to.
+type Field struct {
+	Column string
+	Type   Type
+	// Nullable fields may be compared with null using eq and ne.
+	Nullable bool
+}
+
+// Schema is the whitelist of the fields of a resource, by the name used in
+// filters.
+type Schema map[string]Field
+
+var sqlOperators = map[Operator]string{Eq: "=", Ne: "<>", Gt: ">", Ge: ">=", Lt: "<", Le: "<="}
+
+// Compile checks a syntax tree against the schema and translates it into a
+// condition for a GORM query. Values are passed as parameters, never spliced
+// into the SQL. Errors are of type *Error.
+func (s Schema) Compile(node Node) (clause.Expr, error) {
+	switch n := node.(type) {
+	case *And:
+		return s.join(n.Left, "AND", n.Right)
+	case *Or:
+		return s.join(n.Left, "OR", n.Right)
+	case *Not:
+		expr, err := s.Compile(n.Expr)
+		if err != nil {
+			return clause.Expr{}, err
+		}
+		return clause.Expr{SQL: "NOT (" + expr.SQL + ")", Vars: expr.Vars}, nil
+	case *Comparison:
+		return s.comparison(n)
+	}
+	return clause.Expr{}, errorf(0, "unknown node %T", node)
+}
+
+// join compiles both sides in parentheses, so that the grouping of the tree
+// does not depend on the precedence of the database.
+func (s Schema) join(left Node, op string, right Node) (clause.Expr, error) {
+	l, err := s.Compile(left)
+	if err != nil {
+		return clause.Expr{}, err
+	}
+	r, err := s.Compile(right)
+	if err != nil {
+		return clause.Expr{}, err
+	}
+	return clause.Expr{
+		SQL:  "(" + l.SQL + " " + op + " " + r.SQL + ")",
+		Vars: append(l.Vars, r.Vars...),
+	}, nil
+}
+
+func (s Schema) comparison(n *Comparison) (clause.Expr, error) {
+	field, ok := s[n.Field]
+	if !ok {
+		return clause.Expr{}, errorf(n.Pos, "field %q must be one of %s", n.Field, strings.Join(s.names(), ", "))
+	}
+	column := clause.Column{Name: field.Column}
+
+	switch n.Op {
+	case Contains, StartsWith, EndsWith:
+		if field.Type != TypeString {
+			return clause.Expr{}, errorf(n.Pos, "operator %s is only supported for string fields", n.Op)
+		}
+	}
+
+	values := make([]any, len(n.Values))
+	for i, v := range n.Values {
+		if v.Kind == Null {
+			if !field.Nullable {
+				return clause.Expr{}, errorf(v.Pos, "%s cannot be null", n.Field)
+			}
+			if n.Op != Eq && n.Op != Ne {
+				return clause.Expr{}, errorf(v.Pos, "null can only be compared with eq or ne")
+			}
+			if n.Op == Eq {
+				return clause.Expr{SQL: "? IS NULL", Vars: []any{column}}, nil
+			}
+			return clause.Expr{SQL: "? IS NOT NULL", Vars: []any{column}}, nil
+		}
+
+		value, err := convert(n.Field, field.Type, v)
+		if err != nil {
+			return clause.Expr{}, err
+		}
+		values[i] = value
+	}
+
+	switch n.Op {
+	case In:
+		return clause.Expr{SQL: "? IN ?", Vars: []any{column, values}}, nil
+	case Contains:
+		return like(column, "%"+escapeLike(values[0].(string))+"%"), nil
+	case StartsWith:
+		return like(column, escapeLike(values[0].(string))+"%"), nil
+	case EndsWith:
+		return like(column, "%"+escapeLike(values[0].(string))), nil
+	}
+	return clause.Expr{SQL: "? " + sqlOperators[n.Op] + " ?", Vars: []any{column, values[0]}}, nil
+}
+
+func (s Schema) names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func convert(name string, typ Type, v Value) (any, error) {
+	switch typ {
+	case TypeInteger:
+		if v.Kind == Literal {
+			if i, err := strconv.ParseInt(v.Text, 10, 64); err == nil {
+				return i, nil
+			}
+		}
+		return nil, errorf(v.Pos, "%s must be compared with an integer", name)
+	case TypeTime:
+		if t, err := time.Parse(time.RFC3339, v.Text); err == nil {
+			return t, nil
+		}
+		if t, err := time.Parse(time.DateOnly, v.Text); err == nil {
+			return t, nil
+		}
+		return nil, errorf(v.Pos, "%s must be compared with a date or an RFC 3339 time", name)
+	}
+
+	if v.Kind != String {
+		return nil, errorf(v.Pos, "%s must be compared with a quoted string", name)
+	}
+	return v.Text, nil
+}
+
+// like uses ! as the escape character, because MySQL and SQLite disagree on
+// the default one.
+func like(column clause.Column, pattern string) clause.Expr {
+	return clause.Expr{SQL: "? LIKE ? ESCAPE '!'", Vars: []any{column, pattern}}
+}
+
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,102 @@
+package xfilter
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/clause"
+	"testing"
+	"time"
+)
+
+var testSchema = Schema{
+	"title":       {Column: "title", Type: TypeString},
+	"authorId":    {Column: "author_id", Type: TypeInteger},
+	"createdAt":   {Column: "created_at", Type: TypeTime},
+	"publishedAt": {Column: "published_at", Type: TypeTime, Nullable: true},
+}
+
+func TestSchema_Compile(t *testing.T) {
+	title := clause.Column{Name: "title"}
+	authorID := clause.Column{Name: "author_id"}
+	createdAt := clause.Column{Name: "created_at"}
+	publishedAt := clause.Column{Name: "published_at"}
+
+	tests := []struct {
+		name     string
+		filter   string
+		expected clause.Expr
+	}{
+		{
+			name:     "comparisons",
+			filter:   `title co "go" and createdAt gt 2024-01-01 and authorId in (1,2)`,
+			expected: clause.Expr{SQL: "((? LIKE ? ESCAPE '!' AND ? > ?) AND ? IN ?)", Vars: []any{title, "%go%", createdAt, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), authorID, []any{int64(1), int64(2)}}},
+		},
+		{
+			name:     "or and not",
+			filter:   `not (authorId eq 1 or authorId ne 2)`,
+			expected: clause.Expr{SQL: "NOT ((? = ? OR ? <> ?))", Vars: []any{authorID, int64(1), authorID, int64(2)}},
+		},
+		{
+			name:     "ranges",
+			filter:   `createdAt ge "2024-01-01T07:00:00+07:00" and createdAt lt 2024-02-01 and authorId le 3`,
+			expected: clause.Expr{SQL: "((? >= ? AND ? < ?) AND ? <= ?)", Vars: []any{createdAt, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), createdAt, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), authorID, int64(3)}},
+		},
+		{
+			name:     "like escapes",
+			filter:   `title sw "50%_!" or title ew "go"`,
+			expected: clause.Expr{SQL: "(? LIKE ? ESCAPE '!' OR ? LIKE ? ESCAPE '!')", Vars: []any{title, "50!%!_!!%", title, "%go"}},
+		},
+		{
+			name:     "null",
+			filter:   `publishedAt eq null or publishedAt ne NULL`,
+			expected: clause.Expr{SQL: "(? IS NULL OR ? IS NOT NULL)", Vars: []any{publishedAt, publishedAt}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.filter)
+			assert.NoError(t, err)
+
+			expr, err := testSchema.Compile(node)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected.SQL, expr.SQL)
+			assert.Len(t, expr.Vars, len(tt.expected.Vars))
+			for i, v := range tt.expected.Vars {
+				if want, ok := v.(time.Time); ok {
+					assert.True(t, want.Equal(expr.Vars[i].(time.Time)), "var %d", i)
+					continue
+				}
+				assert.Equal(t, v, expr.Vars[i], "var %d", i)
+			}
+		})
+	}
+}
+
+func TestSchema_Compile_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		expected *Error
+	}{
+		{name: "unknown field", filter: `authorId eq 1 and content co "go"`, expected: &Error{Offset: 18, Message: `field "content" must be one of authorId, createdAt, publishedAt, title`}},
+		{name: "like on integer", filter: `authorId co "1"`, expected: &Error{Offset: 0, Message: "operator co is only supported for string fields"}},
+		{name: "integer", filter: `authorId eq "1"`, expected: &Error{Offset: 12, Message: "authorId must be compared with an integer"}},
+		{name: "integer in list", filter: `authorId in (1, 2.5)`, expected: &Error{Offset: 16, Message: "authorId must be compared with an integer"}},
+		{name: "time", filter: `createdAt gt yesterday`, expected: &Error{Offset: 13, Message: "createdAt must be compared with a date or an RFC 3339 time"}},
+		{name: "string", filter: `title eq go`, expected: &Error{Offset: 9, Message: "title must be compared with a quoted string"}},
+		{name: "not nullable", filter: `createdAt eq null`, expected: &Error{Offset: 13, Message: "createdAt cannot be null"}},
+		{name: "null operator", filter: `publishedAt gt null`, expected: &Error{Offset: 15, Message: "null can only be compared with eq or ne"}},
+		{name: "not", filter: `not title eq 1`, expected: &Error{Offset: 13, Message: "title must be compared with a quoted string"}},
+		{name: "or", filter: `title eq "a" or title eq 1`, expected: &Error{Offset: 25, Message: "title must be compared with a quoted string"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.filter)
+			assert.NoError(t, err)
+
+			_, err = testSchema.Compile(node)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package xfilter
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MaxLength is the longest filter, in bytes, that Parse accepts.
+	MaxLength = 1000
+	// maxDepth bounds the nesting of parentheses and not, so that a hostile
+	// filter cannot exhaust the stack.
+	maxDepth = 32
+	// maxValues bounds the list of an in comparison.
+	maxValues = 100
+)
+
+// Operator compares a field with its values.
+type Operator string
+
+const (
+	Eq         Operator = "eq"
+	Ne         Operator = "ne"
+	Gt         Operator = "gt"
+	Ge         Operator = "ge"
+	Lt         Operator = "lt"
+	Le         Operator = "le"
+	Contains   Operator = "co"
+	StartsWith Operator = "sw"
+	EndsWith   Operator = "ew"
+	In         Operator = "in"
+)
+
+var operators = map[Operator]bool{
+	Eq: true, Ne: true, Gt: true, Ge: true, Lt: true, Le: true,
+	Contains: true, StartsWith: true, EndsWith: true, In: true,
+}
+
+// ValueKind tells how a value was written in the filter.
+type ValueKind int
+
+const (
+	// String is a double quoted string, in which \" and \\ escape a quote and
+	// a backslash.
+	String ValueKind = iota
+	// Literal is an unquoted number, date or time.
+	Literal
+	// Null is the keyword null.
+	Null
+)
+
+// Node is a node of the syntax tree of a filter: an *And, *Or, *Not or
+// *Comparison.
+type Node interface {
+	// Offset is the byte offset of the node in the filter.
+	Offset() int
+}
+
+// And matches when both sides match.
+type And struct {
+	Left, Right Node
+}
+
+// Or matches when either side matches.
+type Or struct {
+	Left, Right Node
+}
+
+// Not matches when Expr does not.
+type Not struct {
+	Expr Node
+	Pos  int
+}
+
+// Comparison compares a field with one value, or with a list of values for
+// the in operator.
+type Comparison struct {
+	Field  string
+	Op     Operator
+	Values []Value
+	Pos    int
+}
+
+// Value is an operand of a comparison. Text is unquoted and unescaped.
+type Value struct {
+	Kind ValueKind
+	Text string
+	Pos  int
+}
+
+func (n *And) Offset() int        { return n.Left.Offset() }
+func (n *Or) Offset() int         { return n.Left.Offset() }
+func (n *Not) Offset() int        { return n.Pos }
+func (n *Comparison) Offset() int { return n.Pos }
+
+// Error is a syntax or validation error in a filter.
+type Error struct {
+	// Offset is the byte offset in the filter where the error was found.
+	Offset  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at offset %d", e.Message, e.Offset)
+}
+
+func errorf(offset int, format string, args ...any) *Error {
+	return &Error{Offset: offset, Message: fmt.Sprintf(format, args...)}
+}
+
+// Parse parses a filter such as
+//
+//	title co "go" and (createdAt gt 2024-01-01 or not authorId in (1, 2))
+//
+// into its syntax tree. Comparisons are joined with and, or and not, which
+// bind in the order not, and, or, and may be grouped with parentheses.
+// Keywords and operators are case-insensitive; field names are not checked
+// until the tree is compiled against a Schema. Errors are of type *Error.
+func Parse(filter string) (Node, error) {
+	if len(filter) > MaxLength {
+		return nil, errorf(MaxLength, "filter is longer than %d bytes", MaxLength)
+	}
+
+	tokens, err := lex(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	node, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errorf(tok.offset, "unexpected %s", tok)
+	}
+	return node, nil
+}
+
+type parser struct {
+	tokens []token
+	next   int
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) take() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *parser) or() (Node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("or") {
+		p.take()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (Node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("and") {
+		p.take()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (Node, error) {
+	tok := p.peek()
+	if tok.kind != tokenLParen && !tok.is("not") {
+		return p.comparison()
+	}
+
+	if p.depth++; p.depth > maxDepth {
+		return nil, errorf(tok.offset, "filter is nested deeper than %d levels", maxDepth)
+	}
+	defer func() { p.depth-- }()
+
+	p.take()
+	if tok.kind == tokenWord {
+		expr, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: expr, Pos: tok.offset}, nil
+	}
+
+	expr, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if end := p.take(); end.kind != tokenRParen {
+		return nil, errorf(end.offset, "expected ) to close ( at offset %d, found %s", tok.offset, end)
+	}
+	return expr, nil
+}
+
+func (p *parser) comparison() (Node, error) {
+	field := p.take()
+	if field.kind != tokenWord || field.keyword() {
+		return nil, errorf(field.offset, "expected a field name, found %s", field)
+	}
+
+	tok := p.take()
+	op := Operator(strings.ToLower(tok.text))
+	if tok.kind != tokenWord || !operators[op] {
+		return nil, errorf(tok.offset, "expected an operator after %s, found %s", field.text, tok)
+	}
+
+	comparison := &Comparison{Field: field.text, Op: op, Pos: field.offset}
+	if op != In {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		comparison.Values = []Value{value}
+		return comparison, nil
+	}
+
+	if open := p.take(); open.kind != tokenLParen {
+		return nil, errorf(open.offset, "expected ( after in, found %s", open)
+	}
+	for {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		if len(comparison.Values) == maxValues {
+			return nil, errorf(value.Pos, "in lists more than %d values", maxValues)
+		}
+		comparison.Values = append(comparison.Values, value)
+
+		switch tok := p.take(); tok.kind {
+		case tokenComma:
+		case tokenRParen:
+			return comparison, nil
+		default:
+			return nil, errorf(tok.offset, "expected , or ) in the values of in, found %s", tok)
+		}
+	}
+}
+
+func (p *parser) value() (Value, error) {
+	tok := p.take()
+	switch {
+	case tok.kind == tokenString:
+		return Value{Kind: String, Text: tok.text, Pos: tok.offset}, nil
+	case tok.is("null"):
+		return Value{Kind: Null, Pos: tok.offset}, nil
+	case tok.kind == tokenWord && !tok.keyword():
+		return Value{Kind: Literal, Text: tok.text, Pos: tok.offset}, nil
+	}
+	return Value{}, errorf(tok.offset, "expected a value, found %s", tok)
+}
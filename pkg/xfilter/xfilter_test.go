@@ -0,0 +1,107 @@
+package xfilter
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		expected Node
+	}{
+		{
+			name:   "comparison",
+			filter: `title co "go"`,
+			expected: &Comparison{Field: "title", Op: Contains, Pos: 0, Values: []Value{
+				{Kind: String, Text: "go", Pos: 9},
+			}},
+		},
+		{
+			name:   "precedence",
+			filter: `a eq 1 or b eq 2 and not c EQ null`,
+			expected: &Or{
+				Left: &Comparison{Field: "a", Op: Eq, Pos: 0, Values: []Value{{Kind: Literal, Text: "1", Pos: 5}}},
+				Right: &And{
+					Left: &Comparison{Field: "b", Op: Eq, Pos: 10, Values: []Value{{Kind: Literal, Text: "2", Pos: 15}}},
+					Right: &Not{Pos: 21, Expr: &Comparison{Field: "c", Op: Eq, Pos: 25, Values: []Value{
+						{Kind: Null, Pos: 30},
+					}}},
+				},
+			},
+		},
+		{
+			name:   "parentheses",
+			filter: `(a eq 1 OR b eq 2) And c eq 3`,
+			expected: &And{
+				Left: &Or{
+					Left:  &Comparison{Field: "a", Op: Eq, Pos: 1, Values: []Value{{Kind: Literal, Text: "1", Pos: 6}}},
+					Right: &Comparison{Field: "b", Op: Eq, Pos: 11, Values: []Value{{Kind: Literal, Text: "2", Pos: 16}}},
+				},
+				Right: &Comparison{Field: "c", Op: Eq, Pos: 23, Values: []Value{{Kind: Literal, Text: "3", Pos: 28}}},
+			},
+		},
+		{
+			name:   "in",
+			filter: `authorId in (1,2, "3")`,
+			expected: &Comparison{Field: "authorId", Op: In, Pos: 0, Values: []Value{
+				{Kind: Literal, Text: "1", Pos: 13},
+				{Kind: Literal, Text: "2", Pos: 15},
+				{Kind: String, Text: "3", Pos: 18},
+			}},
+		},
+		{
+			name:   "escapes",
+			filter: `title eq "say \"hi\" \\ (or not)"`,
+			expected: &Comparison{Field: "title", Op: Eq, Pos: 0, Values: []Value{
+				{Kind: String, Text: `say "hi" \ (or not)`, Pos: 9},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, node)
+		})
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		expected *Error
+	}{
+		{name: "empty", filter: " ", expected: &Error{Offset: 1, Message: "expected a field name, found end of filter"}},
+		{name: "keyword as field", filter: "and eq 1", expected: &Error{Offset: 0, Message: `expected a field name, found "and"`}},
+		{name: "unknown operator", filter: "title like 1", expected: &Error{Offset: 6, Message: `expected an operator after title, found "like"`}},
+		{name: "missing value", filter: "title eq", expected: &Error{Offset: 8, Message: "expected a value, found end of filter"}},
+		{name: "keyword as value", filter: "title eq or", expected: &Error{Offset: 9, Message: `expected a value, found "or"`}},
+		{name: "unclosed parenthesis", filter: "(a eq 1", expected: &Error{Offset: 7, Message: "expected ) to close ( at offset 0, found end of filter"}},
+		{name: "trailing", filter: "a eq 1 b", expected: &Error{Offset: 7, Message: `unexpected "b"`}},
+		{name: "in without list", filter: "a in 1", expected: &Error{Offset: 5, Message: `expected ( after in, found "1"`}},
+		{name: "in list", filter: "a in (1 2)", expected: &Error{Offset: 8, Message: `expected , or ) in the values of in, found "2"`}},
+		{name: "unclosed string", filter: `a eq "go`, expected: &Error{Offset: 5, Message: "string is not closed"}},
+		{name: "invalid escape", filter: `a eq "\n"`, expected: &Error{Offset: 6, Message: `invalid escape in string, only \" and \\ are allowed`}},
+		{name: "too long", filter: strings.Repeat(" ", MaxLength+1), expected: &Error{Offset: MaxLength, Message: "filter is longer than 1000 bytes"}},
+		{name: "too deep", filter: strings.Repeat("(", maxDepth+1), expected: &Error{Offset: maxDepth, Message: "filter is nested deeper than 32 levels"}},
+		{name: "too many values", filter: "a in (" + strings.Repeat("1,", maxValues) + "1)", expected: &Error{Offset: 206, Message: "in lists more than 100 values"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.filter)
+			assert.Nil(t, node)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestError_Error(t *testing.T) {
+	err := &Error{Offset: 3, Message: "unexpected \"b\""}
+	assert.EqualError(t, err, `unexpected "b" at offset 3`)
+}
@@ -0,0 +1,278 @@
+package ximage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+)
+
+var errTruncated = errors.New("image is truncated")
+
+// jpegDroppedMarkers are the JPEG segments removed by Strip: APP1 holds
+// EXIF and XMP data, APP13 holds IPTC data and COM holds comments.
+var jpegDroppedMarkers = map[byte]bool{0xe1: true, 0xed: true, 0xfe: true}
+
+// pngDroppedChunks are the PNG chunks removed by Strip.
+var pngDroppedChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// gifKeptApplications are the GIF application extensions kept by Strip,
+// which set how many times animations loop. The others, such as XMP data,
+// are removed along with comments.
+var gifKeptApplications = map[string]bool{"NETSCAPE2.0": true, "ANIMEXTS1.0": true}
+
+// jpegSegments calls fn with the marker and the whole of each segment of a
+// JPEG image before its scan, and returns the offset of the scan.
+func jpegSegments(data []byte, fn func(marker byte, segment []byte)) (int, error) {
+	if len(data) < 2 || data[0] != 0xff || data[1] != 0xd8 {
+		return 0, errors.New("image is not a JPEG image")
+	}
+
+	i := 2
+	for {
+		if i+1 >= len(data) || data[i] != 0xff {
+			return 0, errTruncated
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xff:
+			// fill byte
+			i++
+			continue
+		case marker == 0xda || marker == 0xd9:
+			return i, nil
+		case marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7):
+			fn(marker, data[i:i+2])
+			i += 2
+			continue
+		}
+
+		if i+4 > len(data) {
+			return 0, errTruncated
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end > len(data) {
+			return 0, errTruncated
+		}
+		fn(marker, data[i:end])
+		i = end
+	}
+}
+
+// stripJPEG removes the metadata segments of a JPEG image.
+func stripJPEG(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...)
+	scan, err := jpegSegments(data, func(marker byte, segment []byte) {
+		if !jpegDroppedMarkers[marker] {
+			out = append(out, segment...)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(out, data[scan:]...), nil
+}
+
+// stripPNG removes the metadata chunks of a PNG image.
+func stripPNG(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errors.New("image is not a PNG image")
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	for i := len(pngSignature); i < len(data); {
+		if i+8 > len(data) {
+			return nil, errTruncated
+		}
+		// length, type, data and checksum
+		end := i + 12 + int(binary.BigEndian.Uint32(data[i:]))
+		if end > len(data) || end < i {
+			return nil, errTruncated
+		}
+		if !pngDroppedChunks[string(data[i+4:i+8])] {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	return out, nil
+}
+
+// stripGIF removes the comment and application extensions of a GIF image,
+// and anything after its trailer.
+func stripGIF(data []byte) ([]byte, error) {
+	if len(data) < 13 || (string(data[:6]) != "GIF87a" && string(data[:6]) != "GIF89a") {
+		return nil, errors.New("image is not a GIF image")
+	}
+
+	// header, logical screen descriptor and global color table
+	i := 13 + gifColorTable(data[10])
+	if i > len(data) {
+		return nil, errTruncated
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:i]...)
+	for {
+		if i >= len(data) {
+			return nil, errTruncated
+		}
+
+		start := i
+		switch data[i] {
+		case 0x3b:
+			return append(out, data[i]), nil
+		case 0x21:
+			if i+2 > len(data) {
+				return nil, errTruncated
+			}
+			label := data[i+1]
+			end, err := gifSubBlocks(data, i+2)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			if label == 0xfe {
+				continue
+			}
+			if label == 0xff && (start+14 > end || data[start+2] != 11 || !gifKeptApplications[string(data[start+3:start+14])]) {
+				continue
+			}
+		case 0x2c:
+			// image descriptor, local color table and LZW code size
+			i += 10
+			if i > len(data) {
+				return nil, errTruncated
+			}
+			i += gifColorTable(data[i-1]) + 1
+			end, err := gifSubBlocks(data, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+		default:
+			return nil, errors.New("image is not a GIF image")
+		}
+		out = append(out, data[start:i]...)
+	}
+}
+
+// gifColorTable returns the size of the color table announced by the
+// packed fields of a GIF descriptor.
+func gifColorTable(packed byte) int {
+	if packed&0x80 == 0 {
+		return 0
+	}
+	return 3 << (packed&0x07 + 1)
+}
+
+// gifSubBlocks returns the end of the data sub-blocks of a GIF block
+// starting at i, after their terminator.
+func gifSubBlocks(data []byte, i int) (int, error) {
+	for {
+		if i >= len(data) {
+			return 0, errTruncated
+		}
+		size := int(data[i])
+		i += size + 1
+		if size == 0 {
+			return i, nil
+		}
+	}
+}
+
+// orientation reads the EXIF orientation of a JPEG image, from 1 to 8, which
+// is 1 for images displayed as they are stored.
+func orientation(data []byte) int {
+	o := 1
+	_, _ = jpegSegments(data, func(marker byte, segment []byte) {
+		if marker != 0xe1 || len(segment) < 10 || string(segment[4:10]) != "Exif\x00\x00" {
+			return
+		}
+		if v := exifOrientation(segment[10:]); v >= 1 && v <= 8 {
+			o = v
+		}
+	})
+	return o
+}
+
+// exifOrientation reads the orientation tag of the first directory of TIFF
+// data, or returns 0.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		// the orientation is a single short
+		if order.Uint16(tiff[entry:]) == 0x0112 && order.Uint16(tiff[entry+2:]) == 3 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 0
+}
+
+// swapsSides tells whether images of the orientation are displayed with
+// their width and height swapped.
+func swapsSides(o int) bool {
+	return o >= 5 && o <= 8
+}
+
+// orient returns the image as displayed with the EXIF orientation o.
+func orient(src *image.RGBA, o int) *image.RGBA {
+	if o < 2 || o > 8 {
+		return src
+	}
+
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dw, dh := w, h
+	if swapsSides(o) {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			// the cases tell how the image is stored
+			var sx, sy int
+			switch o {
+			case 2: // mirrored
+				sx, sy = w-1-x, y
+			case 3: // rotated by 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored upside down
+				sx, sy = x, h-1-y
+			case 5: // mirrored and rotated by 90° counterclockwise
+				sx, sy = y, x
+			case 6: // rotated by 90° counterclockwise
+				sx, sy = y, h-1-x
+			case 7: // mirrored and rotated by 90° clockwise
+				sx, sy = w-1-y, h-1-x
+			case 8: // rotated by 90° clockwise
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
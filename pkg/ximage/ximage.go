@@ -0,0 +1,253 @@
+package ximage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"golang.org/x/image/draw"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"mime"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrTooLarge is returned for images with more pixels than allowed, which
+// are not decoded so that small files cannot take up a lot of memory.
+var ErrTooLarge = errors.New("image has too many pixels")
+
+// Variant is a resized version of images, which fit in Width by Height
+// pixels.
+type Variant struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// Variants is a list of variants, as configured.
+type Variants []Variant
+
+var variantPattern = regexp.MustCompile(`^([a-z0-9][a-z0-9-]*):([1-9][0-9]*)x([1-9][0-9]*)$`)
+
+// ParseVariants parses a comma separated list of variants, each a name
+// followed by its largest size, such as "thumbnail:150x150,medium:800x600".
+func ParseVariants(value string) (Variants, error) {
+	var variants Variants
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		match := variantPattern.FindStringSubmatch(strings.ToLower(item))
+		if match == nil {
+			return nil, fmt.Errorf("variant %q must be written as name:WIDTHxHEIGHT", item)
+		}
+		if seen[match[1]] {
+			return nil, fmt.Errorf("variant %q is defined twice", match[1])
+		}
+		seen[match[1]] = true
+
+		width, err := strconv.Atoi(match[2])
+		if err != nil {
+			return nil, fmt.Errorf("variant %q has an invalid width: %w", item, err)
+		}
+		height, err := strconv.Atoi(match[3])
+		if err != nil {
+			return nil, fmt.Errorf("variant %q has an invalid height: %w", item, err)
+		}
+		variants = append(variants, Variant{Name: match[1], Width: width, Height: height})
+	}
+	return variants, nil
+}
+
+// UnmarshalText parses variants from configuration.
+func (v *Variants) UnmarshalText(text []byte) error {
+	variants, err := ParseVariants(string(text))
+	if err != nil {
+		return err
+	}
+	*v = variants
+	return nil
+}
+
+// String formats the variants as parsed by ParseVariants.
+func (v Variants) String() string {
+	items := make([]string, len(v))
+	for i, variant := range v {
+		items[i] = fmt.Sprintf("%s:%dx%d", variant.Name, variant.Width, variant.Height)
+	}
+	return strings.Join(items, ",")
+}
+
+// Find returns the variant with the given name.
+func (v Variants) Find(name string) (Variant, bool) {
+	for _, variant := range v {
+		if variant.Name == name {
+			return variant, true
+		}
+	}
+	return Variant{}, false
+}
+
+// Fit returns the size of an image of width by height pixels scaled down to
+// fit in maxWidth by maxHeight, keeping its aspect ratio. Images that fit
+// already keep their size, they are never scaled up.
+func Fit(width, height, maxWidth, maxHeight int) (int, int) {
+	if width <= maxWidth && height <= maxHeight {
+		return width, height
+	}
+
+	scale := math.Min(float64(maxWidth)/float64(width), float64(maxHeight)/float64(height))
+	return max(1, int(math.Round(float64(width)*scale))), max(1, int(math.Round(float64(height)*scale)))
+}
+
+// Supported tells whether images of the media type can be resized.
+func Supported(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
+
+// Info describes an image as it is displayed, with the width and height
+// swapped for images whose metadata rotates them.
+type Info struct {
+	Width  int
+	Height int
+	// Format is jpeg, png or gif.
+	Format string
+}
+
+// Processor reads and resizes JPEG, PNG and GIF images.
+type Processor struct {
+	// Quality is the quality of the JPEG images written, from 1 to 100.
+	Quality int
+	// MaxPixels is the largest number of pixels of the images read, 0
+	// allows any size.
+	MaxPixels int
+}
+
+// Info reads the size and format of an image without decoding it.
+func (p *Processor) Info(data []byte) (Info, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Info{}, err
+	}
+	if format != "jpeg" && format != "png" && format != "gif" {
+		return Info{}, fmt.Errorf("images of format %s are not supported", format)
+	}
+	if p.MaxPixels > 0 && int64(config.Width)*int64(config.Height) > int64(p.MaxPixels) {
+		return Info{}, ErrTooLarge
+	}
+
+	info := Info{Width: config.Width, Height: config.Height, Format: format}
+	if format == "jpeg" && swapsSides(orientation(data)) {
+		info.Width, info.Height = info.Height, info.Width
+	}
+	return info, nil
+}
+
+// Strip removes the metadata of an image, such as the EXIF data holding the
+// camera and the location where a photo was taken, leaving the pixels as
+// they are. JPEG images that the metadata rotates are rotated and written
+// again instead, since they would not be displayed upright anymore.
+func (p *Processor) Strip(data []byte) ([]byte, error) {
+	info, err := p.Info(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch info.Format {
+	case "jpeg":
+		if o := orientation(data); o != 1 {
+			img, err := p.decode(data)
+			if err != nil {
+				return nil, err
+			}
+			return p.encode(orient(toRGBA(img), o), info.Format)
+		}
+		return stripJPEG(data)
+	case "png":
+		return stripPNG(data)
+	case "gif":
+		return stripGIF(data)
+	}
+	return data, nil
+}
+
+// Resize scales an image down to fit in maxWidth by maxHeight and returns
+// it with its media type, without metadata. JPEG images stay JPEG images,
+// while PNG and GIF images are written as PNG images, of the first frame
+// for animated ones.
+func (p *Processor) Resize(data []byte, maxWidth, maxHeight int) ([]byte, string, error) {
+	info, err := p.Info(data)
+	if err != nil {
+		return nil, "", err
+	}
+	img, err := p.decode(data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	o := 1
+	if info.Format == "jpeg" {
+		o = orientation(data)
+	}
+	width, height := Fit(info.Width, info.Height, maxWidth, maxHeight)
+	if swapsSides(o) {
+		width, height = height, width
+	}
+
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), img, img.Bounds(), draw.Src, nil)
+
+	out, err := p.encode(orient(scaled, o), info.Format)
+	if err != nil {
+		return nil, "", err
+	}
+	if info.Format == "jpeg" {
+		return out, "image/jpeg", nil
+	}
+	return out, "image/png", nil
+}
+
+func (p *Processor) decode(data []byte) (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+// encode writes JPEG images as JPEG and the others as PNG.
+func (p *Processor) encode(img image.Image, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: p.Quality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
@@ -0,0 +1,242 @@
+package ximage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// testImage is a w by h image, red on its first row and blue elsewhere.
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{B: 255, A: 255})
+		}
+	}
+	for x := 0; x < w; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	return img
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}))
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// withExif inserts an EXIF segment with the orientation o after the start
+// of a JPEG image.
+func withExif(data []byte, o uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, o)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xff, 0xe1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	segment = append(segment, payload...)
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+// withText inserts a tEXt chunk after the header of a PNG image.
+func withText(data []byte, text string) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(text)))
+	chunk = append(chunk, "tEXt"+text...)
+	chunk = append(chunk, 0, 0, 0, 0)
+	// signature and IHDR chunk
+	header := 8 + 12 + 13
+	return append(append(append([]byte{}, data[:header]...), chunk...), data[header:]...)
+}
+
+// withGIFComment adds a comment and an XMP application extension before the
+// trailer of a GIF image, and data after it.
+func withGIFComment(data []byte, comment string) []byte {
+	extensions := append([]byte{0x21, 0xfe, byte(len(comment))}, comment+"\x00"...)
+	extensions = append(extensions, "\x21\xff\x0bXMP DataXMP\x04<x/>\x00"...)
+	out := append(append([]byte{}, data[:len(data)-1]...), extensions...)
+	return append(out, "\x3b"+comment...)
+}
+
+func TestParseVariants(t *testing.T) {
+	variants, err := ParseVariants(" thumbnail:150x150, Medium:800x600,,")
+	assert.NoError(t, err)
+	assert.Equal(t, Variants{{Name: "thumbnail", Width: 150, Height: 150}, {Name: "medium", Width: 800, Height: 600}}, variants)
+	assert.Equal(t, "thumbnail:150x150,medium:800x600", variants.String())
+
+	variant, ok := variants.Find("medium")
+	assert.True(t, ok)
+	assert.Equal(t, 600, variant.Height)
+	_, ok = variants.Find("large")
+	assert.False(t, ok)
+
+	for _, value := range []string{"thumbnail", "thumbnail:150", "thumbnail:0x10", "a b:1x1", "a:1x1,a:2x2"} {
+		_, err := ParseVariants(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name                      string
+		width, height, maxW, maxH int
+		expectedW, expectedH      int
+	}{
+		{name: "fits", width: 100, height: 50, maxW: 150, maxH: 150, expectedW: 100, expectedH: 50},
+		{name: "landscape", width: 1600, height: 1200, maxW: 800, maxH: 800, expectedW: 800, expectedH: 600},
+		{name: "portrait", width: 1200, height: 1600, maxW: 800, maxH: 800, expectedW: 600, expectedH: 800},
+		{name: "narrow", width: 10000, height: 1, maxW: 100, maxH: 100, expectedW: 100, expectedH: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h := Fit(tt.width, tt.height, tt.maxW, tt.maxH)
+			assert.Equal(t, tt.expectedW, w)
+			assert.Equal(t, tt.expectedH, h)
+		})
+	}
+}
+
+func TestSupported(t *testing.T) {
+	assert.True(t, Supported("image/jpeg"))
+	assert.True(t, Supported("image/png"))
+	assert.True(t, Supported("image/gif"))
+	assert.False(t, Supported("image/webp"))
+	assert.False(t, Supported("text/plain; charset=utf-8"))
+}
+
+func TestProcessor_Info(t *testing.T) {
+	p := &Processor{Quality: 90, MaxPixels: 100 * 100}
+
+	info, err := p.Info(encodePNG(t, testImage(40, 20)))
+	assert.NoError(t, err)
+	assert.Equal(t, Info{Width: 40, Height: 20, Format: "png"}, info)
+
+	info, err = p.Info(withExif(encodeJPEG(t, testImage(40, 20)), 6))
+	assert.NoError(t, err)
+	assert.Equal(t, Info{Width: 20, Height: 40, Format: "jpeg"}, info)
+
+	_, err = p.Info(encodePNG(t, testImage(200, 100)))
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	_, err = p.Info([]byte("not an image"))
+	assert.Error(t, err)
+}
+
+func TestProcessor_Strip(t *testing.T) {
+	p := &Processor{Quality: 90}
+
+	t.Run("jpeg", func(t *testing.T) {
+		original := encodeJPEG(t, testImage(40, 20))
+		stripped, err := p.Strip(withExif(original, 1))
+		assert.NoError(t, err)
+		assert.Equal(t, original, stripped)
+	})
+
+	t.Run("jpeg-rotated", func(t *testing.T) {
+		stripped, err := p.Strip(withExif(encodeJPEG(t, testImage(40, 20)), 6))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(stripped), "Exif")
+
+		img, err := jpeg.Decode(bytes.NewReader(stripped))
+		assert.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 20, 40), img.Bounds())
+		// the red first row is now the last column
+		r, _, b, _ := img.At(19, 20).RGBA()
+		assert.Greater(t, r, b)
+	})
+
+	t.Run("png", func(t *testing.T) {
+		original := encodePNG(t, testImage(40, 20))
+		stripped, err := p.Strip(withText(original, "Author\x00Ana"))
+		assert.NoError(t, err)
+		assert.Equal(t, original, stripped)
+	})
+
+	t.Run("gif", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, gif.Encode(&buf, testImage(40, 20), nil))
+		stripped, err := p.Strip(withGIFComment(buf.Bytes(), "Author: Ana"))
+		assert.NoError(t, err)
+		assert.Equal(t, buf.Bytes(), stripped)
+	})
+
+	t.Run("gif-animated", func(t *testing.T) {
+		frame := image.NewPaletted(image.Rect(0, 0, 40, 20), color.Palette{color.Black, color.White})
+		var buf bytes.Buffer
+		assert.NoError(t, gif.EncodeAll(&buf, &gif.GIF{Image: []*image.Paletted{frame, frame}, Delay: []int{10, 10}}))
+		stripped, err := p.Strip(withGIFComment(buf.Bytes(), "Author: Ana"))
+		assert.NoError(t, err)
+		// the loop count is kept
+		assert.Equal(t, buf.Bytes(), stripped)
+		assert.Contains(t, string(stripped), "NETSCAPE2.0")
+	})
+
+	t.Run("error-gif-truncated", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, gif.Encode(&buf, testImage(40, 20), nil))
+		_, err := stripGIF(buf.Bytes()[:buf.Len()-1])
+		assert.Error(t, err)
+	})
+
+	t.Run("error-truncated", func(t *testing.T) {
+		original := encodeJPEG(t, testImage(40, 20))
+		_, err := p.Strip(original[:20])
+		assert.Error(t, err)
+	})
+}
+
+func TestProcessor_Resize(t *testing.T) {
+	p := &Processor{Quality: 90}
+
+	t.Run("jpeg", func(t *testing.T) {
+		data, contentType, err := p.Resize(withExif(encodeJPEG(t, testImage(400, 200)), 8), 100, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, "image/jpeg", contentType)
+		assert.NotContains(t, string(data), "Exif")
+
+		config, err := jpeg.DecodeConfig(bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 50, config.Width)
+		assert.Equal(t, 100, config.Height)
+	})
+
+	t.Run("gif", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, gif.Encode(&buf, testImage(400, 200), nil))
+		data, contentType, err := p.Resize(buf.Bytes(), 100, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, "image/png", contentType)
+
+		config, err := png.DecodeConfig(bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 100, config.Width)
+		assert.Equal(t, 50, config.Height)
+	})
+
+	t.Run("smaller", func(t *testing.T) {
+		data, _, err := p.Resize(encodePNG(t, testImage(40, 20)), 100, 100)
+		assert.NoError(t, err)
+
+		config, err := png.DecodeConfig(bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 40, config.Width)
+		assert.Equal(t, 20, config.Height)
+	})
+}
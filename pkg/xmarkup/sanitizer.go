@@ -0,0 +1,134 @@
+package xmarkup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/microcosm-cc/bluemonday"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Sanitizer removes the markup that is not allowed from HTML.
+type Sanitizer interface {
+	Sanitize(html string) string
+	// Key identifies what the sanitizer allows, so that HTML sanitized with
+	// other settings can be told apart.
+	Key() string
+}
+
+// Allowlist maps the allowed elements to their allowed attributes.
+type Allowlist map[string][]string
+
+// DefaultAllowlist allows the markup written by the Markdown renderer.
+var DefaultAllowlist = Allowlist{
+	"p": nil, "br": nil, "hr": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"em": nil, "strong": nil, "del": nil, "blockquote": nil,
+	"pre": nil, "code": {"class"},
+	"ul": nil, "ol": {"start"}, "li": nil,
+	"a":     {"href", "title"},
+	"img":   {"src", "alt", "title"},
+	"table": nil, "thead": nil, "tbody": nil, "tr": nil, "th": {"align"}, "td": {"align"},
+}
+
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// ParseAllowlist parses a comma separated list of elements, each optionally
+// followed by its attributes in brackets separated by |, such as
+// "p,br,a[href|title]".
+func ParseAllowlist(value string) (Allowlist, error) {
+	allowlist := make(Allowlist)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		element, attrs := item, ""
+		if i := strings.IndexByte(item, '['); i >= 0 {
+			if !strings.HasSuffix(item, "]") {
+				return nil, fmt.Errorf("element %q has no closing bracket", item)
+			}
+			element, attrs = item[:i], item[i+1:len(item)-1]
+		}
+		element = strings.ToLower(strings.TrimSpace(element))
+		if !namePattern.MatchString(element) {
+			return nil, fmt.Errorf("element %q is not a valid name", element)
+		}
+
+		if _, ok := allowlist[element]; !ok {
+			allowlist[element] = nil
+		}
+		for _, attr := range strings.Split(attrs, "|") {
+			attr = strings.ToLower(strings.TrimSpace(attr))
+			if attr == "" {
+				continue
+			}
+			if !namePattern.MatchString(attr) {
+				return nil, fmt.Errorf("attribute %q of element %q is not a valid name", attr, element)
+			}
+			allowlist[element] = append(allowlist[element], attr)
+		}
+	}
+	return allowlist, nil
+}
+
+// UnmarshalText parses an allowlist from configuration.
+func (a *Allowlist) UnmarshalText(text []byte) error {
+	allowlist, err := ParseAllowlist(string(text))
+	if err != nil {
+		return err
+	}
+	*a = allowlist
+	return nil
+}
+
+// String formats the allowlist as parsed by ParseAllowlist, with sorted
+// elements and attributes.
+func (a Allowlist) String() string {
+	elements := make([]string, 0, len(a))
+	for element, attrs := range a {
+		if len(attrs) == 0 {
+			elements = append(elements, element)
+			continue
+		}
+		sorted := append([]string(nil), attrs...)
+		sort.Strings(sorted)
+		elements = append(elements, element+"["+strings.Join(sorted, "|")+"]")
+	}
+	sort.Strings(elements)
+	return strings.Join(elements, ",")
+}
+
+type policySanitizer struct {
+	policy *bluemonday.Policy
+	key    string
+}
+
+// NewSanitizer returns a Sanitizer that keeps the elements and attributes of
+// allowlist and drops the rest, with the content of script and style
+// elements. URLs must be relative or use http, https or mailto, and links
+// get rel="nofollow".
+func NewSanitizer(allowlist Allowlist) Sanitizer {
+	policy := bluemonday.NewPolicy()
+	policy.AllowStandardURLs()
+	for element, attrs := range allowlist {
+		policy.AllowElements(element)
+		if len(attrs) > 0 {
+			policy.AllowAttrs(attrs...).OnElements(element)
+		}
+	}
+
+	sum := sha256.Sum256([]byte(allowlist.String()))
+	return &policySanitizer{policy: policy, key: hex.EncodeToString(sum[:8])}
+}
+
+func (s *policySanitizer) Sanitize(html string) string {
+	return s.policy.Sanitize(html)
+}
+
+func (s *policySanitizer) Key() string {
+	return s.key
+}
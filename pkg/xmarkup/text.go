@@ -0,0 +1,103 @@
+package xmarkup
+
+import (
+	"golang.org/x/net/html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// blockElements are the elements whose text is kept apart from the text
+// around them.
+var blockElements = map[string]bool{
+	"p": true, "br": true, "hr": true, "div": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"blockquote": true, "pre": true, "ul": true, "ol": true, "li": true,
+	"table": true, "tr": true, "th": true, "td": true,
+}
+
+// Text returns the text of HTML with the entities decoded. The text of block
+// elements is put on lines of its own, and scripts and styles are dropped.
+func Text(source string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(source))
+
+	var b strings.Builder
+	skip := ""
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return strings.TrimSpace(b.String())
+		case html.TextToken:
+			if skip == "" {
+				b.Write(tokenizer.Text())
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			if skip == "" && (string(name) == "script" || string(name) == "style") {
+				skip = string(name)
+			}
+			if blockElements[string(name)] {
+				b.WriteByte('\n')
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if string(name) == skip {
+				skip = ""
+			}
+			if blockElements[string(name)] {
+				b.WriteByte('\n')
+			}
+		}
+	}
+}
+
+// CountWords counts the words of text in any script. Letters and digits make
+// up words, joined by apostrophes and hyphens as in "don't" and "well-known".
+// Chinese and Japanese are written without spaces, so each of their
+// characters counts as a word.
+func CountWords(text string) int {
+	count := 0
+	inWord := false
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			count++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r):
+			if !inWord {
+				count++
+				inWord = true
+			}
+		case inWord && (r == '\'' || r == '’' || r == '-'):
+		default:
+			inWord = false
+		}
+	}
+	return count
+}
+
+// Excerpt returns the start of text with its whitespace collapsed, cut at a
+// word boundary so that it has at most size characters. A cut excerpt ends
+// with "…", which is not counted.
+func Excerpt(text string, size int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= size {
+		return text
+	}
+
+	// the byte offset of the first character past size
+	end := 0
+	for i := 0; i < size; i++ {
+		_, n := utf8.DecodeRuneInString(text[end:])
+		end += n
+	}
+	cut := text[:end]
+	if text[end] != ' ' {
+		if i := strings.LastIndexByte(cut, ' '); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.TrimRightFunc(cut, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
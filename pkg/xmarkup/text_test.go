@@ -0,0 +1,71 @@
+package xmarkup
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{name: "blocks", source: "<h1>Title</h1><p>First &amp; <em>second</em></p><ul><li>a</li><li>b</li></ul>", expected: "Title\n\nFirst & second\n\n\na\n\nb"},
+		{name: "line break", source: "<p>one<br>two</p>", expected: "one\ntwo"},
+		{name: "script and style", source: "<p>a</p><script>if (a < b) {}</script><style>p{}</style><p>b</p>", expected: "a\n\nb"},
+		{name: "plain", source: "just text", expected: "just text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Text(tt.source))
+		})
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected int
+	}{
+		{name: "words", text: "The quick brown fox", expected: 4},
+		{name: "punctuation", text: "Hello, world! (Again.)  ", expected: 3},
+		{name: "joined", text: "don't stop — well-known 2024-01-01", expected: 4},
+		{name: "accents", text: "café déjà vu naïve", expected: 4},
+		{name: "cyrillic", text: "Привет мир", expected: 2},
+		{name: "chinese", text: "你好世界", expected: 4},
+		{name: "mixed", text: "Go言語 is fun", expected: 5},
+		{name: "empty", text: " \n ", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CountWords(tt.text))
+		})
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		size     int
+		expected string
+	}{
+		{name: "short", text: "Short\n\ntext ", size: 20, expected: "Short text"},
+		{name: "exact", text: "four", size: 4, expected: "four"},
+		{name: "cut at space", text: "one two three", size: 7, expected: "one two…"},
+		{name: "cut inside word", text: "one two three", size: 10, expected: "one two…"},
+		{name: "trailing punctuation", text: "one, two three", size: 6, expected: "one…"},
+		{name: "long word", text: "abcdefghij", size: 4, expected: "abcd…"},
+		{name: "multibyte", text: "日本語のテキスト", size: 3, expected: "日本語…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Excerpt(tt.text, tt.size))
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package xmarkup
+
+import (
+	"bytes"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+	stdhtml "html"
+	"strings"
+)
+
+// rendererVersion changes whenever the renderer writes different HTML for
+// the same input, so that HTML made by an older version is not reused.
+const rendererVersion = "1"
+
+// Renderer turns content into sanitized HTML.
+type Renderer struct {
+	markdown  goldmark.Markdown
+	sanitizer Sanitizer
+}
+
+// NewRenderer returns a Renderer that sanitizes its output with sanitizer.
+// Markdown is rendered with the GitHub extensions.
+func NewRenderer(sanitizer Sanitizer) *Renderer {
+	return &Renderer{
+		markdown: goldmark.New(
+			goldmark.WithExtensions(extension.GFM),
+			// raw HTML is kept here and left to the sanitizer
+			goldmark.WithRendererOptions(html.WithUnsafe()),
+		),
+		sanitizer: sanitizer,
+	}
+}
+
+// Key identifies the HTML written by the renderer: it changes with the
+// renderer version and the settings of the sanitizer.
+func (r *Renderer) Key() string {
+	return rendererVersion + "-" + r.sanitizer.Key()
+}
+
+// Markdown renders Markdown source.
+func (r *Renderer) Markdown(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := r.markdown.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return r.sanitizer.Sanitize(buf.String()), nil
+}
+
+// HTML sanitizes HTML source.
+func (r *Renderer) HTML(source string) string {
+	return r.sanitizer.Sanitize(source)
+}
+
+// Plain renders plain text: blank lines separate paragraphs and other line
+// breaks are kept.
+func (r *Renderer) Plain(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+
+	var b strings.Builder
+	for _, paragraph := range strings.Split(source, "\n\n") {
+		paragraph = strings.Trim(paragraph, "\n")
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+		lines := strings.Split(paragraph, "\n")
+		for i, line := range lines {
+			lines[i] = stdhtml.EscapeString(line)
+		}
+		b.WriteString("<p>" + strings.Join(lines, "<br>\n") + "</p>\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package xmarkup
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRenderer_Markdown(t *testing.T) {
+	r := NewRenderer(NewSanitizer(DefaultAllowlist))
+
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{name: "heading and emphasis", source: "# Title\n\nSome **bold** text", expected: "<h1>Title</h1>\n<p>Some <strong>bold</strong> text</p>\n"},
+		{name: "fenced code", source: "```go\nfmt.Println(\"<hi>\")\n```", expected: "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;hi&gt;&#34;)\n</code></pre>\n"},
+		{name: "table", source: "| a |\n|---|\n| b |", expected: "<table>\n<thead>\n<tr>\n<th>a</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>b</td>\n</tr>\n</tbody>\n</table>\n"},
+		{name: "script", source: "hi <script>alert(1)</script>", expected: "<p>hi </p>\n"},
+		{name: "javascript link", source: "[x](javascript:alert(1))", expected: "<p>x</p>\n"},
+		{name: "event handler", source: "<img src=\"a.png\" onerror=\"alert(1)\">", expected: "<img src=\"a.png\">"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := r.Markdown(tt.source)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, html)
+		})
+	}
+}
+
+func TestRenderer_HTML(t *testing.T) {
+	r := NewRenderer(NewSanitizer(Allowlist{"p": nil, "a": {"href"}}))
+
+	html := r.HTML(`<p onclick="x()">Hi <a href="https://example.com" title="t">there</a><iframe src="x"></iframe></p><style>p{}</style>`)
+	assert.Equal(t, `<p>Hi <a href="https://example.com" rel="nofollow">there</a></p>`, html)
+}
+
+func TestRenderer_Plain(t *testing.T) {
+	r := NewRenderer(NewSanitizer(DefaultAllowlist))
+
+	html := r.Plain("first <b>line</b>\r\nsecond line\n\n\n\nnext paragraph\n")
+	assert.Equal(t, "<p>first &lt;b&gt;line&lt;/b&gt;<br>\nsecond line</p>\n<p>next paragraph</p>\n", html)
+}
+
+func TestRenderer_Key(t *testing.T) {
+	first := NewRenderer(NewSanitizer(Allowlist{"p": nil, "a": {"title", "href"}}))
+	same := NewRenderer(NewSanitizer(Allowlist{"a": {"href", "title"}, "p": nil}))
+	other := NewRenderer(NewSanitizer(Allowlist{"p": nil}))
+
+	assert.Equal(t, first.Key(), same.Key())
+	assert.NotEqual(t, first.Key(), other.Key())
+}
+
+func TestParseAllowlist(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected Allowlist
+		err      string
+	}{
+		{name: "elements and attributes", value: " p, A[href | Title], img[src|alt] ,,", expected: Allowlist{"p": nil, "a": {"href", "title"}, "img": {"src", "alt"}}},
+		{name: "empty attributes", value: "p[]", expected: Allowlist{"p": nil}},
+		{name: "empty", value: "", expected: Allowlist{}},
+		{name: "unclosed bracket", value: "a[href", err: `element "a[href" has no closing bracket`},
+		{name: "invalid element", value: "p,<b>", err: `element "<b>" is not a valid name`},
+		{name: "invalid attribute", value: "a[on click]", err: `attribute "on click" of element "a" is not a valid name`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowlist, err := ParseAllowlist(tt.value)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, allowlist)
+		})
+	}
+}
+
+func TestAllowlist_String(t *testing.T) {
+	allowlist := Allowlist{"p": nil, "a": {"title", "href"}}
+	assert.Equal(t, "a[href|title],p", allowlist.String())
+
+	parsed, err := ParseAllowlist(allowlist.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "a[href|title],p", parsed.String())
+}
+
+func TestAllowlist_UnmarshalText(t *testing.T) {
+	var allowlist Allowlist
+	assert.NoError(t, allowlist.UnmarshalText([]byte("p,a[href]")))
+	assert.Equal(t, Allowlist{"p": nil, "a": {"href"}}, allowlist)
+
+	assert.Error(t, allowlist.UnmarshalText([]byte("a[")))
+}
@@ -0,0 +1,77 @@
+package xrank
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Decay tells how engagement loses weight as it ages.
+type Decay string
+
+const (
+	// Exponential halves the weight of engagement every half-life.
+	Exponential Decay = "exponential"
+	// Linear lowers the weight of engagement evenly, down to nothing at the
+	// end of the window.
+	Linear Decay = "linear"
+	// None weighs all engagement within the window the same.
+	None Decay = "none"
+)
+
+// DefaultHalfLife is the half-life used when a scorer has none, as a
+// fraction of the window.
+const DefaultHalfLife = 0.25
+
+// ParseDecay parses the name of a decay.
+func ParseDecay(value string) (Decay, error) {
+	switch decay := Decay(strings.ToLower(strings.TrimSpace(value))); decay {
+	case Exponential, Linear, None:
+		return decay, nil
+	}
+	return "", fmt.Errorf("decay %q must be one of exponential, linear or none", value)
+}
+
+// UnmarshalText parses a decay from configuration.
+func (d *Decay) UnmarshalText(text []byte) error {
+	decay, err := ParseDecay(string(text))
+	if err != nil {
+		return err
+	}
+	*d = decay
+	return nil
+}
+
+// Scorer weighs engagement by its age within a window, so that recent
+// engagement counts more than older engagement.
+type Scorer struct {
+	Decay Decay
+	// HalfLife is the half-life of exponential decay as a fraction of the
+	// window, so that a single setting suits windows of any length.
+	HalfLife float64
+}
+
+// Weight returns the weight of engagement of the given age within window,
+// from 1 for engagement happening now to 0 for engagement outside the
+// window. Engagement from the future, which clocks running apart can
+// produce, weighs as much as current engagement.
+func (s Scorer) Weight(age, window time.Duration) float64 {
+	if window <= 0 || age > window {
+		return 0
+	}
+	age = max(age, 0)
+
+	switch s.Decay {
+	case Linear:
+		return 1 - float64(age)/float64(window)
+	case None:
+		return 1
+	}
+
+	halfLife := s.HalfLife
+	if halfLife <= 0 {
+		halfLife = DefaultHalfLife
+	}
+	return math.Exp2(-float64(age) / (halfLife * float64(window)))
+}
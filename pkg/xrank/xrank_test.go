@@ -0,0 +1,51 @@
+package xrank
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseDecay(t *testing.T) {
+	decay, err := ParseDecay(" Linear ")
+	assert.NoError(t, err)
+	assert.Equal(t, Linear, decay)
+
+	_, err = ParseDecay("quadratic")
+	assert.Error(t, err)
+}
+
+func TestDecay_UnmarshalText(t *testing.T) {
+	var decay Decay
+	assert.NoError(t, decay.UnmarshalText([]byte("none")))
+	assert.Equal(t, None, decay)
+
+	assert.Error(t, decay.UnmarshalText([]byte("")))
+}
+
+func TestScorer_Weight(t *testing.T) {
+	window := 24 * time.Hour
+	tests := []struct {
+		name   string
+		scorer Scorer
+		age    time.Duration
+		want   float64
+	}{
+		{name: "exponential now", scorer: Scorer{Decay: Exponential, HalfLife: 0.5}, age: 0, want: 1},
+		{name: "exponential half-life", scorer: Scorer{Decay: Exponential, HalfLife: 0.5}, age: 12 * time.Hour, want: 0.5},
+		{name: "exponential window", scorer: Scorer{Decay: Exponential, HalfLife: 0.5}, age: window, want: 0.25},
+		{name: "exponential default half-life", scorer: Scorer{Decay: Exponential}, age: 6 * time.Hour, want: 0.5},
+		{name: "empty decay is exponential", scorer: Scorer{}, age: 12 * time.Hour, want: 0.25},
+		{name: "linear", scorer: Scorer{Decay: Linear}, age: 18 * time.Hour, want: 0.25},
+		{name: "none", scorer: Scorer{Decay: None}, age: 23 * time.Hour, want: 1},
+		{name: "outside the window", scorer: Scorer{Decay: None}, age: 25 * time.Hour, want: 0},
+		{name: "future", scorer: Scorer{Decay: Linear}, age: -time.Hour, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.scorer.Weight(tt.age, window), 1e-9)
+		})
+	}
+
+	assert.Zero(t, Scorer{}.Weight(0, 0))
+}
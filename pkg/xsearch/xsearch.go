@@ -0,0 +1,99 @@
+package xsearch
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Terms returns the lowercased words of a search query in order, without
+// duplicates. Anything that is not a letter or a digit separates words, so
+// the terms are safe to pass to a full-text engine without escaping.
+func Terms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, span := range words(query) {
+		term := strings.ToLower(query[span.start:span.end])
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// Snippet returns the part of text around the first of the terms as HTML,
+// with every occurrence of a term wrapped in <mark>. It keeps at most size
+// words and marks a cut-off start or end with an ellipsis; a size of 0 keeps
+// the whole text. The text is escaped, so the snippet is safe to embed in a
+// page.
+func Snippet(text string, terms []string, size int) string {
+	spans := words(text)
+	match := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		match[term] = true
+	}
+
+	first := 0
+	for i, span := range spans {
+		if match[strings.ToLower(text[span.start:span.end])] {
+			first = i
+			break
+		}
+	}
+
+	from, to := 0, len(spans)
+	start, end := 0, len(text)
+	if size > 0 && len(spans) > size {
+		// keep a little context before the match and more after it
+		from = min(max(first-size/3, 0), len(spans)-size)
+		to = from + size
+		start, end = spans[from].start, spans[to-1].end
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, span := range spans[from:to] {
+		word := text[span.start:span.end]
+		if !match[strings.ToLower(word)] {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:span.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(word))
+		b.WriteString("</mark>")
+		pos = span.end
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+	if to < len(spans) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+type span struct {
+	start int
+	end   int
+}
+
+// words returns the byte ranges of the runs of letters and digits in s.
+func words(s string) []span {
+	var spans []span
+	start := -1
+	for i, r := range s {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if inWord && start < 0 {
+			start = i
+		} else if !inWord && start >= 0 {
+			spans = append(spans, span{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, span{start, len(s)})
+	}
+	return spans
+}
@@ -0,0 +1,90 @@
+package xsearch
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{name: "words", query: "Clean Architecture", expected: []string{"clean", "architecture"}},
+		{name: "duplicates", query: "go GO Go fiber", expected: []string{"go", "fiber"}},
+		{name: "operators", query: `"go" -fiber +(gorm*)`, expected: []string{"go", "fiber", "gorm"}},
+		{name: "unicode", query: "café déjà-vu 2024", expected: []string{"café", "déjà", "vu", "2024"}},
+		{name: "empty", query: " !? ", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Terms(tt.query))
+		})
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		terms    []string
+		size     int
+		expected string
+	}{
+		{
+			name:     "whole text",
+			text:     "Clean architecture in Go",
+			terms:    []string{"go", "clean"},
+			expected: "<mark>Clean</mark> architecture in <mark>Go</mark>",
+		},
+		{
+			name:     "no match",
+			text:     "one two three four",
+			terms:    []string{"five"},
+			size:     2,
+			expected: "one two…",
+		},
+		{
+			name:     "window around match",
+			text:     "one two three four five six seven eight nine",
+			terms:    []string{"six"},
+			size:     3,
+			expected: "…five <mark>six</mark> seven…",
+		},
+		{
+			name:     "window at end",
+			text:     "one two three four five",
+			terms:    []string{"five"},
+			size:     3,
+			expected: "…three four <mark>five</mark>",
+		},
+		{
+			name:     "short text",
+			text:     "  go fiber\n",
+			terms:    []string{"fiber"},
+			size:     10,
+			expected: "  go <mark>fiber</mark>\n",
+		},
+		{
+			name:     "escaped",
+			text:     "<b>go</b> & fiber",
+			terms:    []string{"b", "fiber"},
+			expected: "&lt;<mark>b</mark>&gt;go&lt;/<mark>b</mark>&gt; &amp; <mark>fiber</mark>",
+		},
+		{
+			name:     "empty",
+			text:     "",
+			terms:    []string{"go"},
+			size:     5,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Snippet(tt.text, tt.terms, tt.size))
+		})
+	}
+}